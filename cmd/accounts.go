@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/client"
+	"github.com/dorkitude/simple/internal/config"
+	"github.com/dorkitude/simple/internal/output"
+	"github.com/dorkitude/simple/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var accountsCmd = &cobra.Command{
+	Use:     "accounts",
+	Aliases: []string{"account"},
+	Short:   "List and choose DNSimple accounts",
+	Long: `List the accounts your token can access and choose which one commands use.
+
+User tokens can reach several accounts; commands refuse to guess between them
+until one is chosen with 'simple accounts use', --account or DNSIMPLE_ACCOUNT.`,
+}
+
+type accountInfo struct {
+	ID      int64  `json:"id"`
+	Email   string `json:"email"`
+	Plan    string `json:"plan_identifier"`
+	Current bool   `json:"current"`
+}
+
+var accountColumns = []output.Column[accountInfo]{
+	{Name: "current", Header: "Current", Value: func(a accountInfo) string { return strconv.FormatBool(a.Current) }, Style: boolMark},
+	{Name: "id", Header: "ID", Value: func(a accountInfo) string { return strconv.FormatInt(a.ID, 10) }},
+	{Name: "email", Header: "Email", Value: func(a accountInfo) string { return a.Email }, Style: styleWith(ui.AccentStyle)},
+	{Name: "plan_identifier", Header: "Plan", Value: func(a accountInfo) string { return a.Plan }, Style: styleWith(ui.SubtleStyle)},
+}
+
+var accountsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List accessible accounts",
+	Long: `List the accounts the current token can access and mark the one in use.
+
+Examples:
+  simple accounts list
+  simple accounts list --json
+  simple accounts list -o csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		accounts, err := client.ListAccounts(ctx, sandboxFlag)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			cfg = &config.Config{}
+		}
+		current, _ := cfg.AccountID(accountFlag)
+
+		infos := make([]accountInfo, 0, len(accounts))
+		for _, a := range accounts {
+			infos = append(infos, accountInfo{
+				ID:      a.ID,
+				Email:   a.Email,
+				Plan:    a.PlanIdentifier,
+				Current: strconv.FormatInt(a.ID, 10) == current,
+			})
+		}
+
+		err = output.List(renderer, infos, output.View[accountInfo]{
+			Title:   fmt.Sprintf("👥 %d accounts", len(infos)),
+			Empty:   "No accounts found",
+			Columns: accountColumns,
+		})
+		if err != nil || !renderer.Format.IsTable() {
+			return err
+		}
+
+		if current == "" && len(infos) > 1 {
+			fmt.Println()
+			fmt.Println(ui.Warn("No account chosen. Run '" + BinName() + " accounts use <id|email>'."))
+		}
+		return nil
+	},
+}
+
+var accountsUseCmd = &cobra.Command{
+	Use:   "use [id|email]",
+	Short: "Choose the account commands use",
+	Long: `Persist the account used by the current profile.
+
+Examples:
+  simple accounts use 1010
+  simple accounts use ops@example.com`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		accounts, err := client.ListAccounts(ctx, sandboxFlag)
+		if err != nil {
+			return err
+		}
+
+		account := matchAccount(accounts, args[0])
+		if account == nil {
+			return fmt.Errorf("no accessible account matches '%s' — see '%s accounts list'", args[0], BinName())
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		profile, err := cfg.Current()
+		if err != nil {
+			return err
+		}
+		profile.AccountID = strconv.FormatInt(account.ID, 10)
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		client.InvalidateCurrent()
+		forgetCompletions()
+
+		if ok, err := printValue(account); ok {
+			return err
+		}
+
+		name, _ := cfg.ProfileName()
+		fmt.Println(ui.Success(fmt.Sprintf("Now using account %d (%s) for profile '%s'", account.ID, account.Email, name)))
+		if _, from := cfg.AccountID(accountFlag); from == config.AccountEnv {
+			fmt.Println(ui.Warn(config.AccountEnv + " is set and still takes precedence in this shell"))
+		}
+		return nil
+	},
+}
+
+var accountsCurrentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Show the account in use",
+	Long: `Show the account commands use and where the choice came from.
+
+Examples:
+  simple accounts current
+  simple accounts current --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		cfg, err := config.Load()
+		if err != nil {
+			cfg = &config.Config{}
+		}
+		id, from := cfg.AccountID(accountFlag)
+		if id == "" {
+			app, err := getApp(ctx)
+			if err != nil {
+				return err
+			}
+			id, from = app.AccountID, "whoami"
+		}
+
+		info := currentAccountInfo{ID: id, Source: from}
+		if accounts, err := client.ListAccounts(ctx, sandboxFlag); err == nil {
+			if a := matchAccount(accounts, id); a != nil {
+				info.Email, info.Plan = a.Email, a.PlanIdentifier
+			}
+		}
+
+		return output.Item(renderer, info, output.View[currentAccountInfo]{
+			Title: "👤 Current account",
+			Columns: []output.Column[currentAccountInfo]{
+				{Name: "id", Header: "ID", Value: func(a currentAccountInfo) string { return a.ID }},
+				{Name: "email", Header: "Email", OmitEmpty: true, Value: func(a currentAccountInfo) string { return a.Email }},
+				{Name: "plan_identifier", Header: "Plan", OmitEmpty: true, Value: func(a currentAccountInfo) string { return a.Plan }},
+				{Name: "source", Header: "Source", Value: func(a currentAccountInfo) string { return a.Source }},
+			},
+		})
+	},
+}
+
+type currentAccountInfo struct {
+	ID     string `json:"id"`
+	Email  string `json:"email,omitempty"`
+	Plan   string `json:"plan_identifier,omitempty"`
+	Source string `json:"source"`
+}
+
+// matchAccount finds an account by numeric ID or case-insensitive email.
+func matchAccount(accounts []dnsimple.Account, key string) *dnsimple.Account {
+	for i := range accounts {
+		a := &accounts[i]
+		if strconv.FormatInt(a.ID, 10) == key || strings.EqualFold(a.Email, key) {
+			return a
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(accountsCmd)
+	accountsCmd.AddCommand(accountsListCmd)
+	accountsCmd.AddCommand(accountsUseCmd)
+	accountsCmd.AddCommand(accountsCurrentCmd)
+}
@@ -1,12 +1,11 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
-	"strings"
 
 	"github.com/dorkitude/simple/internal/client"
 	"github.com/dorkitude/simple/internal/config"
@@ -23,49 +22,137 @@ var authCmd = &cobra.Command{
 var authLoginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Login with a DNSimple API token",
-	Long:  `Prompts for your API token and validates it against the DNSimple API.`,
+	Long: `Prompts for your API token and validates it against the DNSimple API.
+
+With --encrypt the token file is encrypted with a passphrase (scrypt + AES-GCM).
+The passphrase is read from SIMPLE_TOKEN_PASSPHRASE or prompted for once per run.
+
+If the profile has a token_command, login runs it instead of prompting and only
+validates the result and caches the account.
+
+With --oauth, login runs the OAuth authorization-code flow instead: it listens
+on localhost for the callback, opens the DNSimple authorize page, and exchanges
+the returned code for a token. The client secret can come from
+DNSIMPLE_CLIENT_SECRET. The callback listens on a random port unless
+--callback-addr pins one; register http://<callback-addr>/callback as the
+application's redirect URI.
+
+For CI, skip login entirely and set DNSIMPLE_TOKEN (and optionally
+DNSIMPLE_ACCOUNT); nothing is written to disk.
+
+Examples:
+  simple auth login
+  simple auth login --encrypt
+  echo "$TOKEN" | simple auth login --token-stdin --force
+  simple auth login --oauth --client-id abc123 --client-secret ...
+  simple auth login --oauth --client-id abc123 --callback-addr 127.0.0.1:8910
+  SIMPLE_TOKEN_PASSPHRASE=... simple auth login --encrypt`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
-		if config.HasToken() {
-			fmt.Println(ui.Warn("Already authenticated. Use '" + BinName() + " auth logout' first to re-authenticate."))
-			return nil
+		cfg, err := config.Load()
+		if err != nil {
+			cfg = &config.Config{}
+		}
+		profile, err := cfg.Current()
+		if err != nil {
+			return err
+		}
+		if sandboxFlag {
+			profile.Environment = config.EnvSandbox
+		}
+		encrypt, _ := cmd.Flags().GetBool("encrypt")
+		plaintext, _ := cmd.Flags().GetBool("plaintext")
+		tokenStdin, _ := cmd.Flags().GetBool("token-stdin")
+		force, _ := cmd.Flags().GetBool("force")
+		switch {
+		case encrypt && plaintext:
+			return fmt.Errorf("--encrypt and --plaintext cannot be used together")
+		case encrypt:
+			profile.TokenStorage = config.StorageEncrypted
+		case plaintext:
+			profile.TokenStorage = ""
 		}
 
-		fmt.Println(ui.TitleStyle.Render("🔐 DNSimple Authentication"))
-		fmt.Println()
-		fmt.Print("Enter your API token: ")
+		if useOAuth, _ := cmd.Flags().GetBool("oauth"); useOAuth {
+			return oauthLogin(cmd, cfg, profile, force)
+		}
 
-		reader := bufio.NewReader(os.Stdin)
-		token, _ := reader.ReadString('\n')
-		token = strings.TrimSpace(token)
+		if tokenStdin && profile.StorageMode() == config.StorageCommand {
+			return fmt.Errorf("--token-stdin cannot be used with a profile that has a token_command")
+		}
 
+		if !tokenStdin {
+			fmt.Println(ui.Title("🔐 DNSimple Authentication"))
+			fmt.Println()
+		}
+		if config.TokenSource() == config.SourceEnv {
+			fmt.Fprintln(os.Stderr, ui.Warn(config.TokenEnv+" is set and takes precedence over the stored token."))
+		}
+
+		var token string
+		switch {
+		case profile.StorageMode() == config.StorageCommand:
+			fmt.Println(ui.SubtleStyle.Render("Reading token from token_command: " + profile.TokenCommand))
+			if token, err = config.RunTokenCommand(ctx, profile.TokenCommand); err != nil {
+				return err
+			}
+		case config.HasTokenFile() && !force:
+			fmt.Println(ui.Warn("Already authenticated. Use '" + BinName() + " auth logout' first or pass --force to re-authenticate."))
+			return nil
+		case tokenStdin:
+			if token, err = readLine(ctx); err != nil {
+				return err
+			}
+		default:
+			if token, err = readSecret(ctx, "Enter your API token: "); err != nil {
+				return err
+			}
+		}
 		if token == "" {
 			return fmt.Errorf("token cannot be empty")
 		}
 
-		fmt.Println()
-		fmt.Println(ui.SubtleStyle.Render("Validating token..."))
+		if !tokenStdin {
+			fmt.Println(ui.SubtleStyle.Render("Validating token..."))
+		}
 
-		whoami, err := client.ValidateToken(ctx, token, sandboxFlag)
+		whoami, err := client.ValidateToken(ctx, token, profile.IsSandbox())
 		if err != nil {
 			return err
 		}
 
-		// Save token
-		if err := config.SaveToken(token); err != nil {
-			return fmt.Errorf("failed to save token: %w", err)
+		if profile.StorageMode() != config.StorageCommand {
+			if profile.StorageMode() == config.StorageEncrypted && !config.HasPassphrase() {
+				pass, err := readNewPassphrase(ctx)
+				if err != nil {
+					return err
+				}
+				config.SetPassphrase(pass)
+			}
+			// SaveToken reads the storage mode from the saved config.
+			if err := config.Save(cfg); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+			if err := config.SaveToken(token); err != nil {
+				return fmt.Errorf("failed to save token: %w", err)
+			}
 		}
 
 		// Resolve and cache account ID
-		cfg := &config.Config{Sandbox: sandboxFlag}
+		profile.AccountID = ""
+		_ = config.Save(cfg)
+		var chooseAccount bool
 		if whoami.Account != nil {
-			cfg.AccountID = strconv.FormatInt(whoami.Account.ID, 10)
+			profile.AccountID = strconv.FormatInt(whoami.Account.ID, 10)
 		} else if whoami.User != nil {
-			// User token — try to auto-resolve account
-			app, err := client.NewFromFlags(ctx, "", sandboxFlag)
+			// User token — resolves only if the user has a single account
+			app, err := client.NewFromToken(ctx, token, profile.IsSandbox())
+			var multi *client.MultipleAccountsError
 			if err == nil {
-				cfg.AccountID = app.AccountID
+				profile.AccountID = app.AccountID
+			} else if errors.As(err, &multi) {
+				chooseAccount = true
 			}
 		}
 		_ = config.Save(cfg)
@@ -78,13 +165,206 @@ var authLoginCmd = &cobra.Command{
 			fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("User: %s (ID: %d)", whoami.User.Email, whoami.User.ID)))
 		}
 
-		tokenPath, _ := config.TokenPath()
-		fmt.Println(ui.SubtleStyle.Render("Token saved to: " + tokenPath))
+		if profile.StorageMode() != config.StorageCommand {
+			tokenPath, _ := config.TokenPath()
+			fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Token saved to: %s (%s)", tokenPath, profile.StorageMode())))
+		}
+		if chooseAccount {
+			fmt.Println()
+			fmt.Println(ui.Warn("This user can access several accounts. Pick one with '" + BinName() + " accounts use <id|email>'."))
+		}
+
+		return nil
+	},
+}
+
+// oauthLogin obtains a token through the OAuth authorization-code flow and
+// stores it on the profile in effect.
+func oauthLogin(cmd *cobra.Command, cfg *config.Config, profile *config.Profile, force bool) error {
+	ctx := cmd.Context()
 
+	if profile.StorageMode() == config.StorageCommand {
+		return fmt.Errorf("--oauth cannot be used with a profile that has a token_command")
+	}
+	if config.HasTokenFile() && !force {
+		fmt.Println(ui.Warn("Already authenticated. Use '" + BinName() + " auth logout' first or pass --force to re-authenticate."))
 		return nil
+	}
+
+	clientID, _ := cmd.Flags().GetString("client-id")
+	clientSecret, _ := cmd.Flags().GetString("client-secret")
+	if clientSecret == "" {
+		clientSecret = os.Getenv("DNSIMPLE_CLIENT_SECRET")
+	}
+	authorizeURL, _ := cmd.Flags().GetString("authorize-url")
+	tokenURL, _ := cmd.Flags().GetString("token-url")
+	noBrowser, _ := cmd.Flags().GetBool("no-browser")
+	callbackAddr, _ := cmd.Flags().GetString("callback-addr")
+	if clientID == "" {
+		return fmt.Errorf("--client-id is required with --oauth")
+	}
+	if authorizeURL == "" {
+		authorizeURL = client.DefaultAuthorizeURL
+		if profile.IsSandbox() {
+			authorizeURL = client.DefaultSandboxAuthorizeURL
+		}
+	}
+	if tokenURL == "" {
+		tokenURL = client.DefaultTokenBaseURL
+		if profile.IsSandbox() {
+			tokenURL = client.DefaultSandboxTokenBaseURL
+		}
+	}
+
+	fmt.Println(ui.Title("🔐 DNSimple OAuth Login"))
+	fmt.Println()
+
+	token, err := client.OAuthLogin(ctx, client.OAuthConfig{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		AuthorizeURL: authorizeURL,
+		TokenBaseURL: tokenURL,
+		ListenAddr:   callbackAddr,
+		Timeout:      cfg.Timeout(),
+	}, func(u string) {
+		fmt.Println("Open this URL to authorize simple:")
+		fmt.Println(ui.AccentStyle.Render(u))
+		fmt.Println()
+		if !noBrowser {
+			if err := openBrowser(u); err != nil {
+				fmt.Println(ui.SubtleStyle.Render("Could not open a browser: " + err.Error()))
+			}
+		}
+		fmt.Println(ui.SubtleStyle.Render("Waiting for the authorization callback... (Ctrl-C to cancel)"))
+	})
+	if err != nil {
+		return err
+	}
+
+	if profile.StorageMode() == config.StorageEncrypted && !config.HasPassphrase() {
+		pass, err := readNewPassphrase(ctx)
+		if err != nil {
+			return err
+		}
+		config.SetPassphrase(pass)
+	}
+	// SaveToken reads the storage mode from the saved config.
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	if err := config.SaveToken(token.Token); err != nil {
+		return fmt.Errorf("failed to save token: %w", err)
+	}
+	profile.AccountID = ""
+	if token.AccountID != 0 {
+		profile.AccountID = strconv.FormatInt(token.AccountID, 10)
+	}
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.Success("Authenticated with DNSimple! 🎉"))
+	if profile.AccountID != "" {
+		fmt.Println(ui.SubtleStyle.Render("Account ID: " + profile.AccountID))
+	}
+	tokenPath, _ := config.TokenPath()
+	fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Token saved to: %s (%s)", tokenPath, profile.StorageMode())))
+	return nil
+}
+
+var authEncryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypt the stored token with a passphrase",
+	Long: `Re-writes the stored token encrypted with a passphrase and switches the
+profile to encrypted storage.
+
+Examples:
+  simple auth encrypt`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return convertTokenStorage(cmd, config.StorageEncrypted)
+	},
+}
+
+var authDecryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "Store the token in plaintext again",
+	Long: `Decrypts the stored token and switches the profile back to plaintext storage.
+
+Examples:
+  simple auth decrypt`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return convertTokenStorage(cmd, config.StoragePlaintext)
 	},
 }
 
+// convertTokenStorage rewrites the stored token of the profile in effect
+// using the given storage mode.
+func convertTokenStorage(cmd *cobra.Command, mode string) error {
+	ctx := cmd.Context()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	profile, err := cfg.Current()
+	if err != nil {
+		return err
+	}
+	if profile.StorageMode() == config.StorageCommand {
+		return fmt.Errorf("profile reads its token from token_command; nothing is stored locally")
+	}
+
+	token, err := config.LoadToken()
+	if err != nil {
+		return err
+	}
+
+	if mode == config.StorageEncrypted {
+		pass, err := readNewPassphrase(ctx)
+		if err != nil {
+			return err
+		}
+		config.SetPassphrase(pass)
+		profile.TokenStorage = config.StorageEncrypted
+	} else {
+		profile.TokenStorage = ""
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	if err := config.SaveToken(token); err != nil {
+		return fmt.Errorf("failed to save token: %w", err)
+	}
+
+	fmt.Println(ui.Success("Token storage is now " + profile.StorageMode() + "."))
+	return nil
+}
+
+// readNewPassphrase asks for a new passphrase twice. SIMPLE_TOKEN_PASSPHRASE
+// is used as-is when set.
+func readNewPassphrase(ctx context.Context) (string, error) {
+	if env := os.Getenv("SIMPLE_TOKEN_PASSPHRASE"); env != "" {
+		return env, nil
+	}
+	pass, err := readSecret(ctx, "New passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if pass == "" {
+		return "", fmt.Errorf("passphrase cannot be empty")
+	}
+	again, err := readSecret(ctx, "Repeat passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if again != pass {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	return pass, nil
+}
+
 var authLogoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Remove stored credentials",
@@ -103,17 +383,49 @@ var authStatusCmd = &cobra.Command{
 	Short: "Check authentication status",
 	Long:  `Check if you are currently authenticated with DNSimple.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			cfg = &config.Config{}
+		}
+		name, source := cfg.ProfileName()
+		profile, err := cfg.Current()
+		if err != nil {
+			return err
+		}
+
 		if config.HasToken() {
 			fmt.Println(ui.Success("Authenticated"))
-			tokenPath, _ := config.TokenPath()
-			fmt.Println(ui.SubtleStyle.Render("Token location: " + tokenPath))
+			fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Profile: %s (from %s)", name, source)))
+			fmt.Println(ui.SubtleStyle.Render("Environment: " + profile.EnvironmentName()))
+			switch config.TokenSource() {
+			case config.SourceEnv:
+				fmt.Println(ui.SubtleStyle.Render("Token source: " + config.TokenEnv + " (environment)"))
+				if config.HasTokenFile() {
+					tokenPath, _ := config.TokenPath()
+					fmt.Println(ui.SubtleStyle.Render("Stored token (overridden): " + tokenPath))
+				}
+			case config.SourceCommand:
+				fmt.Println(ui.SubtleStyle.Render("Token source: token_command (" + profile.TokenCommand + ")"))
+			default:
+				tokenPath, _ := config.TokenPath()
+				fmt.Println(ui.SubtleStyle.Render("Token location: " + tokenPath))
+				storage := config.StoragePlaintext
+				if config.TokenEncrypted() {
+					storage = config.StorageEncrypted
+					if !config.HasPassphrase() {
+						storage += " (passphrase will be prompted; set SIMPLE_TOKEN_PASSPHRASE to skip)"
+					}
+				}
+				fmt.Println(ui.SubtleStyle.Render("Token storage: " + storage))
+			}
 
-			cfg, _ := config.Load()
-			if cfg != nil && cfg.AccountID != "" {
-				fmt.Println(ui.SubtleStyle.Render("Account ID: " + cfg.AccountID))
+			if id, from := cfg.AccountID(accountFlag); id != "" {
+				fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Account ID: %s (from %s)", id, from)))
 			}
+			fmt.Println()
+			fmt.Println(ui.SubtleStyle.Render("Precedence: token " + config.TokenEnv + " > token_command > token file; account --account > " + config.AccountEnv + " > config"))
 		} else {
-			fmt.Println(ui.Warn("Not authenticated"))
+			fmt.Println(ui.Warn(fmt.Sprintf("Not authenticated (profile: %s)", name)))
 			fmt.Println(ui.SubtleStyle.Render("Run '" + BinName() + " auth login' to authenticate"))
 		}
 		return nil
@@ -125,7 +437,7 @@ var authSetupCmd = &cobra.Command{
 	Short: "Instructions for getting a DNSimple API token",
 	Long:  `Prints instructions for creating a DNSimple API token.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println(ui.TitleStyle.Render("🔧 DNSimple API Token Setup"))
+		fmt.Println(ui.Title("🔧 DNSimple API Token Setup"))
 		fmt.Println()
 		fmt.Println(ui.SubtleStyle.Render("DNSimple uses API tokens for authentication. Here's how to get one:"))
 		fmt.Println()
@@ -144,13 +456,26 @@ var authSetupCmd = &cobra.Command{
 		fmt.Println(ui.SubtleStyle.Render("For sandbox testing, use https://sandbox.dnsimple.com"))
 		fmt.Println(ui.SubtleStyle.Render("and pass --sandbox when running commands."))
 		fmt.Println()
-		fmt.Println(ui.SubtleStyle.Render("That's it! Much simpler than OAuth. 🎉"))
+		fmt.Println(ui.SubtleStyle.Render(ui.Text("That's it! Much simpler than OAuth. 🎉")))
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(authCmd)
 	authCmd.AddCommand(authLoginCmd)
+	authLoginCmd.Flags().Bool("encrypt", false, "Encrypt the stored token with a passphrase")
+	authLoginCmd.Flags().Bool("plaintext", false, "Store the token unencrypted")
+	authLoginCmd.Flags().Bool("token-stdin", false, "Read the token from stdin without prompting")
+	authLoginCmd.Flags().Bool("force", false, "Replace an existing stored token")
+	authLoginCmd.Flags().Bool("oauth", false, "Log in through the OAuth authorization-code flow")
+	authLoginCmd.Flags().String("client-id", "", "OAuth application client ID")
+	authLoginCmd.Flags().String("client-secret", "", "OAuth application client secret (or DNSIMPLE_CLIENT_SECRET)")
+	authLoginCmd.Flags().String("authorize-url", "", "OAuth authorize endpoint (default: DNSimple's, sandbox-aware)")
+	authLoginCmd.Flags().String("token-url", "", "API base URL for the token exchange (default: DNSimple's, sandbox-aware)")
+	authLoginCmd.Flags().String("callback-addr", "127.0.0.1:0", "Address the OAuth callback listens on; the redirect URI is http://<addr>/callback")
+	authLoginCmd.Flags().Bool("no-browser", false, "Print the authorize URL without opening a browser")
+	authCmd.AddCommand(authEncryptCmd)
+	authCmd.AddCommand(authDecryptCmd)
 	authCmd.AddCommand(authLogoutCmd)
 	authCmd.AddCommand(authStatusCmd)
 	authCmd.AddCommand(authSetupCmd)
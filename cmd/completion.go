@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/cache"
+	"github.com/dorkitude/simple/internal/client"
+	"github.com/dorkitude/simple/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish]",
+	Short: "Generate shell completion scripts",
+	Long: `Generate a completion script for your shell.
+
+Zone names, domain names, record IDs and record types complete from the
+API. Listings are cached for a couple of minutes in the config directory, so
+repeated tab presses stay fast and don't use up the API rate limit.
+
+Bash (needs bash-completion v2):
+  simple completion bash > ~/.local/share/bash-completion/completions/simple
+
+Zsh:
+  simple completion zsh > "${fpath[1]}/_simple"
+
+Fish:
+  simple completion fish > ~/.config/fish/completions/simple.fish`,
+	Args:                  cobra.ExactArgs(1),
+	ValidArgs:             []string{"bash", "zsh", "fish"},
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(out, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(out)
+		case "fish":
+			return rootCmd.GenFishCompletion(out, true)
+		}
+		return fmt.Errorf("unsupported shell %q (want bash, zsh or fish)", args[0])
+	},
+}
+
+// completionTimeout bounds the API calls behind a single tab press.
+const completionTimeout = 5 * time.Second
+
+// recordTypes are the record types DNSimple accepts, with a short
+// description for shells that show one.
+var recordTypes = []string{
+	"A\tIPv4 address",
+	"AAAA\tIPv6 address",
+	"ALIAS\tCNAME-like record at the apex",
+	"CAA\tCertificate authority authorization",
+	"CNAME\tCanonical name",
+	"DS\tDelegation signer",
+	"HINFO\tHost information",
+	"MX\tMail exchanger",
+	"NAPTR\tName authority pointer",
+	"NS\tName server",
+	"POOL\tRound-robin pool",
+	"PTR\tPointer",
+	"SPF\tSender policy framework",
+	"SRV\tService locator",
+	"SSHFP\tSSH fingerprint",
+	"TXT\tText",
+	"URL\tURL redirect",
+}
+
+func completeRecordTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return withPrefix(recordTypes, strings.ToUpper(toComplete)), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeDomainArg completes the domain name taken as the first argument.
+func completeDomainArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := completionContext(cmd)
+	defer cancel()
+	domains, _ := cachedDomains(ctx)
+	choices := make([]string, 0, len(domains))
+	for _, d := range domains {
+		choices = append(choices, d.Name+"\t"+domainSummary(d))
+	}
+	return withPrefix(choices, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeZoneArg completes the zone name taken as the first argument.
+func completeZoneArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return withPrefix(zoneChoices(cmd), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeZoneRecordArgs completes "[zone] [record-id]".
+func completeZoneRecordArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return withPrefix(zoneChoices(cmd), toComplete), cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		ctx, cancel := completionContext(cmd)
+		defer cancel()
+		records, _ := cachedRecords(ctx, args[0])
+		choices := make([]string, 0, len(records))
+		for _, r := range records {
+			choices = append(choices, strconv.FormatInt(r.ID, 10)+"\t"+truncateRunes(recordSummary(r), 60))
+		}
+		return withPrefix(choices, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func zoneChoices(cmd *cobra.Command) []string {
+	ctx, cancel := completionContext(cmd)
+	defer cancel()
+	zones, _ := cachedZones(ctx)
+	choices := make([]string, 0, len(zones))
+	for _, z := range zones {
+		choices = append(choices, z.Name)
+	}
+	return choices
+}
+
+// completionContext prepares for API calls behind a tab press. Completion
+// runs before --profile is applied, must never block on a passphrase
+// prompt, and should give up quickly.
+func completionContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	if profileFlag != "" {
+		config.SetProfile(profileFlag)
+	}
+	config.SetPassphrasePrompt(func() (string, error) {
+		return "", errors.New("token passphrase needed")
+	})
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithTimeout(ctx, completionTimeout)
+}
+
+func domainSummary(d dnsimple.Domain) string {
+	if d.ExpiresAt == "" {
+		return d.State
+	}
+	return d.State + ", expires " + dateOnly(d.ExpiresAt)
+}
+
+func recordSummary(r dnsimple.ZoneRecord) string {
+	return fmt.Sprintf("%s %s %s", r.Type, recordName(r), r.Content)
+}
+
+func cachedDomains(ctx context.Context) ([]dnsimple.Domain, error) {
+	return cachedList(ctx, "domains", listAllDomains)
+}
+
+func cachedZones(ctx context.Context) ([]dnsimple.Zone, error) {
+	return cachedList(ctx, "zones", listAllZones)
+}
+
+func cachedRecords(ctx context.Context, zone string) ([]dnsimple.ZoneRecord, error) {
+	return cachedList(ctx, "records:"+zone, func(ctx context.Context, app *client.App) ([]dnsimple.ZoneRecord, error) {
+		return listAllRecords(ctx, app, zone)
+	})
+}
+
+// cachedList returns a listing from the on-disk cache, fetching and
+// storing it on a miss. Listings are keyed on the account they belong to,
+// however it was chosen, so --account, DNSIMPLE_ACCOUNT and the profile's
+// account all share one entry per account. Completion callers ignore the
+// error: a failed completion should offer nothing rather than print into
+// the prompt.
+func cachedList[T any](ctx context.Context, kind string, fetch func(context.Context, *client.App) ([]T, error)) ([]T, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	profile, err := cfg.Current()
+	if err != nil {
+		return nil, err
+	}
+	var app *client.App
+	accountID, _ := cfg.AccountID(accountFlag)
+	if accountID == "" {
+		if app, err = getApp(ctx); err != nil {
+			return nil, err
+		}
+		accountID = app.AccountID
+	}
+	sandbox := sandboxFlag || profile.IsSandbox()
+	key := strings.Join([]string{config.CurrentProfileName(), accountID, strconv.FormatBool(sandbox), kind}, "|")
+
+	store, err := cache.Default()
+	if err != nil {
+		return nil, err
+	}
+	var items []T
+	if store.Get(key, &items) {
+		return items, nil
+	}
+
+	if app == nil {
+		if app, err = getApp(ctx); err != nil {
+			return nil, err
+		}
+	}
+	items, err = fetch(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	_ = store.Put(key, items)
+	return items, nil
+}
+
+// forgetCompletions drops cached listings after a command changes them.
+func forgetCompletions() {
+	if store, err := cache.Default(); err == nil {
+		_ = store.Clear()
+	}
+}
+
+func withPrefix(choices []string, prefix string) []string {
+	if prefix == "" {
+		return choices
+	}
+	var out []string
+	for _, c := range choices {
+		if strings.HasPrefix(strings.ToLower(c), strings.ToLower(prefix)) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
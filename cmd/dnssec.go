@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/filter"
+	"github.com/dorkitude/simple/internal/output"
+	"github.com/dorkitude/simple/internal/tui"
+	"github.com/dorkitude/simple/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var dnssecCmd = &cobra.Command{
+	Use:   "dnssec",
+	Short: "Manage DNSSEC signing for domains",
+	Long: `Show, enable, or disable DNSSEC signing for a domain.
+
+After enabling DNSSEC, publish the domain's DS record at the parent zone
+(see "simple ds") so resolvers can validate the signatures.`,
+}
+
+var dnssecStatusCmd = &cobra.Command{
+	Use:               "status [domain]",
+	Short:             "Show whether DNSSEC is enabled",
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeDomainArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickDomain)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Domains.GetDnssec(ctx, app.AccountID, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get DNSSEC status: %w", err)
+		}
+
+		if ok, err := printValue(resp.Data); ok {
+			return err
+		}
+
+		if resp.Data.Enabled {
+			fmt.Println(ui.Success(fmt.Sprintf("DNSSEC is enabled for '%s'", args[0])))
+		} else {
+			fmt.Println(ui.Warn(fmt.Sprintf("DNSSEC is disabled for '%s'", args[0])))
+		}
+		return nil
+	},
+}
+
+var dnssecEnableCmd = &cobra.Command{
+	Use:   "enable [domain]",
+	Short: "Enable DNSSEC for a domain",
+	Long: `Start signing a domain's zone with DNSSEC.
+
+For domains registered elsewhere, add the DS record shown by
+"simple ds list" at your registrar once signing is active.`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeDomainArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setDnssec(cmd, args, true)
+	},
+}
+
+var dnssecDisableCmd = &cobra.Command{
+	Use:   "disable [domain]",
+	Short: "Disable DNSSEC for a domain",
+	Long: `Stop signing a domain's zone with DNSSEC.
+
+Remove the domain's DS records at the parent first, or validating resolvers
+will treat the unsigned answers as bogus.`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeDomainArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setDnssec(cmd, args, false)
+	},
+}
+
+// setDnssec runs dnssec enable and dnssec disable.
+func setDnssec(cmd *cobra.Command, args []string, enabled bool) error {
+	args, err := pickArgs(cmd, args, pickDomain)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	app, err := getApp(ctx)
+	if err != nil {
+		return err
+	}
+
+	domain := args[0]
+	if enabled {
+		_, err = app.Client.Domains.EnableDnssec(ctx, app.AccountID, domain)
+		if err != nil {
+			return fmt.Errorf("failed to enable DNSSEC: %w", err)
+		}
+	} else {
+		_, err = app.Client.Domains.DisableDnssec(ctx, app.AccountID, domain)
+		if err != nil {
+			return fmt.Errorf("failed to disable DNSSEC: %w", err)
+		}
+	}
+
+	// Disabling answers 204 with no body, so report the state ourselves.
+	if ok, err := printValue(dnsimple.Dnssec{Enabled: enabled}); ok {
+		return err
+	}
+
+	if enabled {
+		fmt.Println(ui.Success(fmt.Sprintf("DNSSEC enabled for '%s'", domain)))
+	} else {
+		fmt.Println(ui.Success(fmt.Sprintf("DNSSEC disabled for '%s'", domain)))
+	}
+	return nil
+}
+
+var dsCmd = &cobra.Command{
+	Use:   "ds",
+	Short: "Manage delegation signer records",
+	Long: `List, view, create, and delete the delegation signer (DS) records DNSimple
+publishes at the registry for a domain it is the registrar of.`,
+}
+
+var dsListCmd = &cobra.Command{
+	Use:   "list [domain]",
+	Short: "List DS records for a domain",
+	Long: `List the delegation signer records for a domain.
+
+Examples:
+  simple ds list example.com
+  simple ds list example.com --json`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeDomainArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickDomain)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		where, err := whereFilter(cmd, dnsimple.DelegationSignerRecord{})
+		if err != nil {
+			return err
+		}
+
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Domains.ListDelegationSignerRecords(ctx, app.AccountID, args[0], nil)
+		if err != nil {
+			return fmt.Errorf("failed to list DS records: %w", err)
+		}
+
+		items, err := filter.Select(where, resp.Data)
+		if err != nil {
+			return err
+		}
+
+		return output.List(renderer, items, output.View[dnsimple.DelegationSignerRecord]{
+			Title:   fmt.Sprintf("🔏 %d DS records for %s", len(items), args[0]),
+			Empty:   "No DS records found",
+			Columns: dsColumns,
+			Layout:  listLayout(cmd),
+		})
+	},
+}
+
+var dsColumns = []output.Column[dnsimple.DelegationSignerRecord]{
+	{Name: "id", Header: "ID", Value: func(r dnsimple.DelegationSignerRecord) string { return strconv.FormatInt(r.ID, 10) }},
+	{Name: "keytag", Header: "Keytag", Value: func(r dnsimple.DelegationSignerRecord) string { return r.Keytag }, Style: styleWith(ui.AccentStyle)},
+	{Name: "algorithm", Header: "Algorithm", Value: func(r dnsimple.DelegationSignerRecord) string { return r.Algorithm }},
+	{Name: "digest_type", Header: "Digest Type", Value: func(r dnsimple.DelegationSignerRecord) string { return r.DigestType }, OmitEmpty: true},
+	{Name: "digest", Header: "Digest", Value: func(r dnsimple.DelegationSignerRecord) string { return r.Digest }, Truncate: 24, OmitEmpty: true},
+	{Name: "public_key", Header: "Public Key", Value: func(r dnsimple.DelegationSignerRecord) string { return r.PublicKey }, Wide: true, OmitEmpty: true},
+	{Name: "created_at", Header: "Created", Value: func(r dnsimple.DelegationSignerRecord) string { return r.CreatedAt }, Wide: true},
+}
+
+var dsGetCmd = &cobra.Command{
+	Use:               "get [domain] [ds-id]",
+	Short:             "Get DS record details",
+	Args:              argsOrPick(2),
+	ValidArgsFunction: completeDomainArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickDomain, pickDSRecord)
+		if err != nil {
+			return err
+		}
+
+		dsID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid DS record ID: %w", err)
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Domains.GetDelegationSignerRecord(ctx, app.AccountID, args[0], dsID)
+		if err != nil {
+			return fmt.Errorf("failed to get DS record: %w", err)
+		}
+
+		return output.Item(renderer, *resp.Data, output.View[dnsimple.DelegationSignerRecord]{
+			Title:   fmt.Sprintf("🔏 DS record %d for %s", resp.Data.ID, args[0]),
+			Columns: dsColumns,
+		})
+	},
+}
+
+var dsCreateCmd = &cobra.Command{
+	Use:   "create [domain]",
+	Short: "Add a DS record",
+	Long: `Add a delegation signer record for a domain.
+
+Most TLDs take a digest; a few take the public key instead.
+
+Examples:
+  simple ds create example.com --algorithm 13 --digest-type 2 --keytag 2371 --digest 0123abcd...
+  simple ds create example.eu --algorithm 13 --public-key 'mdsswUyr3DPW...'`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeDomainArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickDomain)
+		if err != nil {
+			return err
+		}
+
+		algorithm, _ := cmd.Flags().GetString("algorithm")
+		digest, _ := cmd.Flags().GetString("digest")
+		digestType, _ := cmd.Flags().GetString("digest-type")
+		keytag, _ := cmd.Flags().GetString("keytag")
+		publicKey, _ := cmd.Flags().GetString("public-key")
+		if algorithm == "" {
+			return fmt.Errorf("--algorithm is required")
+		}
+		if digest == "" && publicKey == "" {
+			return fmt.Errorf("give --digest (with --digest-type and --keytag) or --public-key")
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Domains.CreateDelegationSignerRecord(ctx, app.AccountID, args[0], dnsimple.DelegationSignerRecord{
+			Algorithm:  algorithm,
+			Digest:     digest,
+			DigestType: digestType,
+			Keytag:     keytag,
+			PublicKey:  publicKey,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create DS record: %w", err)
+		}
+
+		if ok, err := printValue(resp.Data); ok {
+			return err
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("DS record created! (ID: %d)", resp.Data.ID)))
+		return nil
+	},
+}
+
+var dsDeleteCmd = &cobra.Command{
+	Use:   "delete [domain] [ds-id]",
+	Short: "Delete a DS record",
+	Long: `PERMANENTLY delete a delegation signer record.
+
+Examples:
+  simple ds delete example.com 24`,
+	Args:              argsOrPick(2),
+	ValidArgsFunction: completeDomainArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickDomain, pickDSRecord)
+		if err != nil {
+			return err
+		}
+
+		dsID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid DS record ID: %w", err)
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = app.Client.Domains.DeleteDelegationSignerRecord(ctx, app.AccountID, args[0], dsID)
+		if err != nil {
+			return fmt.Errorf("failed to delete DS record: %w", err)
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("DS record %d deleted.", dsID)))
+		return nil
+	},
+}
+
+// pickDSRecord chooses a DS record of the domain picked (or given) before it.
+func pickDSRecord(cmd *cobra.Command, args []string) (string, error) {
+	domain := args[0]
+	ctx := cmd.Context()
+	app, err := getApp(ctx)
+	if err != nil {
+		return "", err
+	}
+	resp, err := app.Client.Domains.ListDelegationSignerRecords(ctx, app.AccountID, domain, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to list DS records: %w", err)
+	}
+	if len(resp.Data) == 0 {
+		return "", fmt.Errorf("domain '%s' has no DS records", domain)
+	}
+	items := make([]tui.PickItem, 0, len(resp.Data))
+	for _, r := range resp.Data {
+		items = append(items, tui.PickItem{
+			Value:  strconv.FormatInt(r.ID, 10),
+			Label:  fmt.Sprintf("keytag %s", r.Keytag),
+			Detail: fmt.Sprintf("alg %s  digest type %s  #%d", r.Algorithm, r.DigestType, r.ID),
+		})
+	}
+	return tui.Pick("Choose a DS record for "+domain, items)
+}
+
+func init() {
+	rootCmd.AddCommand(dnssecCmd)
+	dnssecCmd.AddCommand(dnssecStatusCmd)
+	dnssecCmd.AddCommand(dnssecEnableCmd)
+	dnssecCmd.AddCommand(dnssecDisableCmd)
+
+	rootCmd.AddCommand(dsCmd)
+
+	dsCmd.AddCommand(dsListCmd)
+	addLayoutFlags(dsListCmd, output.ColumnNames(dsColumns))
+	addWhereFlag(dsListCmd)
+
+	dsCmd.AddCommand(dsGetCmd)
+
+	dsCmd.AddCommand(dsCreateCmd)
+	dsCreateCmd.Flags().String("algorithm", "", "DNSSEC algorithm number, e.g. 13 (required)")
+	dsCreateCmd.Flags().String("digest", "", "Digest of the key, in hex")
+	dsCreateCmd.Flags().String("digest-type", "", "Digest algorithm number, e.g. 2 for SHA-256")
+	dsCreateCmd.Flags().String("keytag", "", "Key tag of the signing key")
+	dsCreateCmd.Flags().String("public-key", "", "Public key, for registries that take it instead of a digest")
+
+	dsCmd.AddCommand(dsDeleteCmd)
+}
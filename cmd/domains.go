@@ -1,10 +1,12 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/filter"
+	"github.com/dorkitude/simple/internal/output"
 	"github.com/dorkitude/simple/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -24,76 +26,107 @@ var domainsListCmd = &cobra.Command{
 Examples:
   simple domains list
   simple domains list --filter example
-  simple domains list --json`,
+  simple domains list --json
+  simple domains list -o csv
+  simple domains list -o jsonpath='{[*].name}'
+  simple domains list --sort-by expires_at --columns name,expires_at,auto_renew
+  simple domains list --group-by state
+  simple domains list --where 'state == "registered" && !auto_renew && expires_at < "2027-01-01"'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		app, err := getApp(ctx)
+		ctx := cmd.Context()
+		where, err := whereFilter(cmd, dnsimple.Domain{})
 		if err != nil {
 			return err
 		}
 
-		filter, _ := cmd.Flags().GetString("filter")
-		page, _ := cmd.Flags().GetInt("page")
-		perPage, _ := cmd.Flags().GetInt("per-page")
-
-		opts := &dnsimple.DomainListOptions{}
-		if filter != "" {
-			opts.NameLike = dnsimpleString(filter)
-		}
-		if page > 0 {
-			opts.Page = dnsimpleInt(page)
-		}
-		if perPage > 0 {
-			opts.PerPage = dnsimpleInt(perPage)
+		all, err := allPages(cmd)
+		if err != nil {
+			return err
 		}
 
-		resp, err := app.Client.Domains.ListDomains(ctx, app.AccountID, opts)
+		app, err := getApp(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to list domains: %w", err)
+			return err
 		}
 
-		if printJSON(resp.Data) {
-			return nil
+		nameFilter, _ := cmd.Flags().GetString("filter")
+		domains, err := listPages(cmd, all, func(lo dnsimple.ListOptions) ([]dnsimple.Domain, *dnsimple.Pagination, error) {
+			opts := &dnsimple.DomainListOptions{ListOptions: lo}
+			if nameFilter != "" {
+				opts.NameLike = dnsimpleString(nameFilter)
+			}
+			resp, err := app.Client.Domains.ListDomains(ctx, app.AccountID, opts)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to list domains: %w", err)
+			}
+			return resp.Data, resp.Pagination, nil
+		})
+		if err != nil {
+			return err
 		}
 
-		if len(resp.Data) == 0 {
-			fmt.Println(ui.Warn("No domains found"))
-			return nil
+		items, err := filter.Select(where, domains)
+		if err != nil {
+			return err
 		}
 
-		fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("🌐 %d domains", len(resp.Data))))
-		fmt.Println()
+		return output.List(renderer, items, output.View[dnsimple.Domain]{
+			Title:   fmt.Sprintf("🌐 %d domains", len(items)),
+			Empty:   "No domains found",
+			Columns: domainListColumns,
+			Layout:  listLayout(cmd),
+		})
+	},
+}
 
-		for _, d := range resp.Data {
-			stateColor := ui.SuccessStyle
-			if d.State != "registered" && d.State != "hosted" {
-				stateColor = ui.WarningStyle
-			}
+var domainListColumns = []output.Column[dnsimple.Domain]{
+	{Name: "id", Header: "ID", Wide: true, Value: func(d dnsimple.Domain) string { return strconv.FormatInt(d.ID, 10) }},
+	{Name: "name", Header: "Name", Value: func(d dnsimple.Domain) string { return d.Name }, Style: styleWith(ui.AccentStyle)},
+	{Name: "state", Header: "State", Value: func(d dnsimple.Domain) string { return d.State }, Style: domainStateStyle},
+	{Name: "expires_at", Header: "Expires", Value: func(d dnsimple.Domain) string { return dateOnly(d.ExpiresAt) }},
+	{Name: "auto_renew", Header: "Auto-Renew", Value: func(d dnsimple.Domain) string { return strconv.FormatBool(d.AutoRenew) }, Style: boolMark},
+	{Name: "private_whois", Header: "Private WHOIS", Wide: true, Value: func(d dnsimple.Domain) string { return strconv.FormatBool(d.PrivateWhois) }, Style: boolMark},
+	{Name: "created_at", Header: "Created", Wide: true, Value: func(d dnsimple.Domain) string { return d.CreatedAt }},
+	{Name: "updated_at", Header: "Updated", Wide: true, Value: func(d dnsimple.Domain) string { return d.UpdatedAt }},
+}
 
-			fmt.Printf("  %-30s %s",
-				ui.AccentStyle.Render(d.Name),
-				stateColor.Render(d.State),
-			)
-			if d.ExpiresAt != "" {
-				fmt.Printf("  %s", ui.SubtleStyle.Render("expires: "+d.ExpiresAt[:10]))
-			}
-			if d.AutoRenew {
-				fmt.Printf("  %s", ui.SubtleStyle.Render("↻"))
-			}
-			fmt.Println()
-		}
+var domainDetailColumns = []output.Column[dnsimple.Domain]{
+	{Name: "id", Header: "ID", Value: func(d dnsimple.Domain) string { return strconv.FormatInt(d.ID, 10) }},
+	{Name: "name", Header: "Name", Value: func(d dnsimple.Domain) string { return d.Name }},
+	{Name: "unicode_name", Header: "Unicode", OmitEmpty: true, Value: func(d dnsimple.Domain) string {
+		if d.UnicodeName == d.Name {
+			return ""
+		}
+		return d.UnicodeName
+	}},
+	{Name: "state", Header: "State", Value: func(d dnsimple.Domain) string { return d.State }},
+	{Name: "auto_renew", Header: "Auto-Renew", Value: func(d dnsimple.Domain) string { return strconv.FormatBool(d.AutoRenew) }},
+	{Name: "private_whois", Header: "Private WHOIS", Value: func(d dnsimple.Domain) string { return strconv.FormatBool(d.PrivateWhois) }},
+	{Name: "expires_at", Header: "Expires", OmitEmpty: true, Value: func(d dnsimple.Domain) string { return d.ExpiresAt }},
+	{Name: "created_at", Header: "Created", Value: func(d dnsimple.Domain) string { return d.CreatedAt }},
+	{Name: "updated_at", Header: "Updated", Value: func(d dnsimple.Domain) string { return d.UpdatedAt }},
+}
 
-		return nil
-	},
+func domainStateStyle(state string) string {
+	if state != "registered" && state != "hosted" {
+		return ui.WarningStyle.Render(state)
+	}
+	return ui.SuccessStyle.Render(state)
 }
 
 var domainsGetCmd = &cobra.Command{
-	Use:   "get [domain]",
-	Short: "Get domain details",
-	Long:  `Display detailed information about a specific domain.`,
-	Args:  cobra.ExactArgs(1),
+	Use:               "get [domain]",
+	Short:             "Get domain details",
+	Long:              `Display detailed information about a specific domain.`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeDomainArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		args, err := pickArgs(cmd, args, pickDomain)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
 		app, err := getApp(ctx)
 		if err != nil {
 			return err
@@ -104,28 +137,10 @@ var domainsGetCmd = &cobra.Command{
 			return fmt.Errorf("failed to get domain: %w", err)
 		}
 
-		if printJSON(resp.Data) {
-			return nil
-		}
-
-		d := resp.Data
-		fmt.Println(ui.TitleStyle.Render("🌐 " + d.Name))
-		fmt.Println()
-		fmt.Printf("  %-14s %d\n", "ID:", d.ID)
-		fmt.Printf("  %-14s %s\n", "Name:", d.Name)
-		if d.UnicodeName != d.Name && d.UnicodeName != "" {
-			fmt.Printf("  %-14s %s\n", "Unicode:", d.UnicodeName)
-		}
-		fmt.Printf("  %-14s %s\n", "State:", d.State)
-		fmt.Printf("  %-14s %v\n", "Auto-Renew:", d.AutoRenew)
-		fmt.Printf("  %-14s %v\n", "Private WHOIS:", d.PrivateWhois)
-		if d.ExpiresAt != "" {
-			fmt.Printf("  %-14s %s\n", "Expires:", d.ExpiresAt)
-		}
-		fmt.Printf("  %-14s %s\n", "Created:", d.CreatedAt)
-		fmt.Printf("  %-14s %s\n", "Updated:", d.UpdatedAt)
-
-		return nil
+		return output.Item(renderer, *resp.Data, output.View[dnsimple.Domain]{
+			Title:   "🌐 " + resp.Data.Name,
+			Columns: domainDetailColumns,
+		})
 	},
 }
 
@@ -138,7 +153,7 @@ Examples:
   simple domains create example.com`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := cmd.Context()
 		app, err := getApp(ctx)
 		if err != nil {
 			return err
@@ -149,9 +164,10 @@ Examples:
 		if err != nil {
 			return fmt.Errorf("failed to create domain: %w", err)
 		}
+		forgetCompletions()
 
-		if printJSON(resp.Data) {
-			return nil
+		if ok, err := printValue(resp.Data); ok {
+			return err
 		}
 
 		fmt.Println(ui.Success(fmt.Sprintf("Domain '%s' added! (ID: %d)", resp.Data.Name, resp.Data.ID)))
@@ -166,9 +182,10 @@ var domainsDeleteCmd = &cobra.Command{
 
 Examples:
   simple domains delete example.com`,
-	Args: cobra.ExactArgs(1),
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeDomainArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := cmd.Context()
 		app, err := getApp(ctx)
 		if err != nil {
 			return err
@@ -178,6 +195,7 @@ Examples:
 		if err != nil {
 			return fmt.Errorf("failed to delete domain: %w", err)
 		}
+		forgetCompletions()
 
 		fmt.Println(ui.Success(fmt.Sprintf("Domain '%s' deleted.", args[0])))
 		return nil
@@ -189,8 +207,10 @@ func init() {
 
 	domainsCmd.AddCommand(domainsListCmd)
 	domainsListCmd.Flags().StringP("filter", "f", "", "Filter domains by name")
-	domainsListCmd.Flags().Int("page", 0, "Page number")
-	domainsListCmd.Flags().Int("per-page", 0, "Results per page")
+	domainsListCmd.Flags().Int("page", 0, "Page number (not with --where, --sort-by or --group-by)")
+	domainsListCmd.Flags().Int("per-page", 0, "Results per page (not with --where, --sort-by or --group-by)")
+	addLayoutFlags(domainsListCmd, output.ColumnNames(domainListColumns))
+	addWhereFlag(domainsListCmd)
 
 	domainsCmd.AddCommand(domainsGetCmd)
 	domainsCmd.AddCommand(domainsCreateCmd)
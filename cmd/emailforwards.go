@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/client"
+	"github.com/dorkitude/simple/internal/filter"
+	"github.com/dorkitude/simple/internal/forwarding"
+	"github.com/dorkitude/simple/internal/output"
+	"github.com/dorkitude/simple/internal/tui"
+	"github.com/dorkitude/simple/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var emailForwardsCmd = &cobra.Command{
+	Use:     "email-forwards",
+	Aliases: []string{"email-forward", "forwards"},
+	Short:   "Manage email forwards",
+	Long: `List, view, create, and delete the email forwards of a domain.
+
+A forward sends mail for an alias at the domain on to another address. An
+alias of * (or --catch-all) forwards every address at the domain, which
+suits parked domains.`,
+}
+
+var emailForwardsListCmd = &cobra.Command{
+	Use:   "list [domain]",
+	Short: "List email forwards for a domain",
+	Long: `List the email forwards of a domain.
+
+Examples:
+  simple email-forwards list example.com
+  simple email-forwards list example.com --json`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeDomainArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickDomain)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		where, err := whereFilter(cmd, dnsimple.EmailForward{})
+		if err != nil {
+			return err
+		}
+
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		forwards, err := listAllEmailForwards(ctx, app, args[0])
+		if err != nil {
+			return err
+		}
+
+		items, err := filter.Select(where, forwards)
+		if err != nil {
+			return err
+		}
+
+		return output.List(renderer, items, output.View[dnsimple.EmailForward]{
+			Title:   fmt.Sprintf("📨 %d email forwards for %s", len(items), args[0]),
+			Empty:   "No email forwards found",
+			Columns: emailForwardColumns,
+			Layout:  listLayout(cmd),
+		})
+	},
+}
+
+var emailForwardColumns = []output.Column[dnsimple.EmailForward]{
+	{Name: "id", Header: "ID", Value: func(f dnsimple.EmailForward) string { return strconv.FormatInt(f.ID, 10) }},
+	{Name: "from", Header: "From", Value: forwarding.From, Style: styleWith(ui.AccentStyle)},
+	{Name: "to", Header: "To", Value: forwarding.To},
+	{Name: "catch_all", Header: "Catch-All", Value: func(f dnsimple.EmailForward) string { return strconv.FormatBool(forwarding.IsCatchAll(f)) }, Style: boolMark},
+	{Name: "created_at", Header: "Created", Value: func(f dnsimple.EmailForward) string { return f.CreatedAt }, Wide: true},
+	{Name: "updated_at", Header: "Updated", Value: func(f dnsimple.EmailForward) string { return f.UpdatedAt }, Wide: true},
+}
+
+var emailForwardsGetCmd = &cobra.Command{
+	Use:               "get [domain] [forward-id]",
+	Short:             "Get email forward details",
+	Args:              argsOrPick(2),
+	ValidArgsFunction: completeDomainArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickDomain, pickEmailForward)
+		if err != nil {
+			return err
+		}
+
+		forwardID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid email forward ID: %w", err)
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Domains.GetEmailForward(ctx, app.AccountID, args[0], forwardID)
+		if err != nil {
+			return fmt.Errorf("failed to get email forward: %w", err)
+		}
+
+		return output.Item(renderer, *resp.Data, output.View[dnsimple.EmailForward]{
+			Title:   fmt.Sprintf("📨 Email forward %d for %s", resp.Data.ID, args[0]),
+			Columns: emailForwardColumns,
+		})
+	},
+}
+
+var emailForwardsCreateCmd = &cobra.Command{
+	Use:   "create [domain]",
+	Short: "Add an email forward",
+	Long: `Forward mail for an alias at a domain to another address.
+
+Examples:
+  simple email-forwards create example.com --from hello --to team@example.net
+  simple email-forwards create parked.example --catch-all --to inbox@example.net`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeDomainArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickDomain)
+		if err != nil {
+			return err
+		}
+		domain := args[0]
+
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		catchAll, _ := cmd.Flags().GetBool("catch-all")
+		if catchAll {
+			if from != "" {
+				return fmt.Errorf("--catch-all conflicts with --from")
+			}
+			from = "*"
+		}
+		alias, err := forwarding.Alias(from, domain)
+		if err != nil {
+			return fmt.Errorf("--from: %w", err)
+		}
+		destination, err := forwarding.Destination(to)
+		if err != nil {
+			return fmt.Errorf("--to: %w", err)
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Domains.CreateEmailForward(ctx, app.AccountID, domain, dnsimple.EmailForward{
+			AliasName:        alias,
+			DestinationEmail: destination,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create email forward: %w", err)
+		}
+
+		if ok, err := printValue(resp.Data); ok {
+			return err
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("Email forward created! %s → %s (ID: %d)", forwarding.From(*resp.Data), forwarding.To(*resp.Data), resp.Data.ID)))
+		return nil
+	},
+}
+
+var emailForwardsDeleteCmd = &cobra.Command{
+	Use:   "delete [domain] [forward-id]",
+	Short: "Delete an email forward",
+	Long: `PERMANENTLY delete an email forward. Mail for its alias is no longer
+forwarded.
+
+Examples:
+  simple email-forwards delete example.com 17706`,
+	Args:              argsOrPick(2),
+	ValidArgsFunction: completeDomainArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickDomain, pickEmailForward)
+		if err != nil {
+			return err
+		}
+
+		forwardID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid email forward ID: %w", err)
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = app.Client.Domains.DeleteEmailForward(ctx, app.AccountID, args[0], forwardID)
+		if err != nil {
+			return fmt.Errorf("failed to delete email forward: %w", err)
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("Email forward %d deleted.", forwardID)))
+		return nil
+	},
+}
+
+func listAllEmailForwards(ctx context.Context, app *client.App, domain string) ([]dnsimple.EmailForward, error) {
+	return fetchAll(func(opts dnsimple.ListOptions) ([]dnsimple.EmailForward, *dnsimple.Pagination, error) {
+		resp, err := app.Client.Domains.ListEmailForwards(ctx, app.AccountID, domain, &opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list email forwards: %w", err)
+		}
+		return resp.Data, resp.Pagination, nil
+	})
+}
+
+// pickEmailForward chooses an email forward of the domain picked (or given)
+// before it.
+func pickEmailForward(cmd *cobra.Command, args []string) (string, error) {
+	domain := args[0]
+	ctx := cmd.Context()
+	app, err := getApp(ctx)
+	if err != nil {
+		return "", err
+	}
+	forwards, err := listAllEmailForwards(ctx, app, domain)
+	if err != nil {
+		return "", err
+	}
+	if len(forwards) == 0 {
+		return "", fmt.Errorf("domain '%s' has no email forwards", domain)
+	}
+	items := make([]tui.PickItem, 0, len(forwards))
+	for _, f := range forwards {
+		items = append(items, tui.PickItem{
+			Value:  strconv.FormatInt(f.ID, 10),
+			Label:  forwarding.From(f),
+			Detail: fmt.Sprintf("→ %s  #%d", forwarding.To(f), f.ID),
+		})
+	}
+	return tui.Pick("Choose an email forward for "+domain, items)
+}
+
+func init() {
+	rootCmd.AddCommand(emailForwardsCmd)
+
+	emailForwardsCmd.AddCommand(emailForwardsListCmd)
+	addLayoutFlags(emailForwardsListCmd, output.ColumnNames(emailForwardColumns))
+	addWhereFlag(emailForwardsListCmd)
+
+	emailForwardsCmd.AddCommand(emailForwardsGetCmd)
+
+	emailForwardsCmd.AddCommand(emailForwardsCreateCmd)
+	emailForwardsCreateCmd.Flags().String("from", "", "Alias at the domain, such as hello, or * for a catch-all")
+	emailForwardsCreateCmd.Flags().String("to", "", "Address to forward mail to (required)")
+	emailForwardsCreateCmd.Flags().Bool("catch-all", false, "Forward every address at the domain")
+
+	emailForwardsCmd.AddCommand(emailForwardsDeleteCmd)
+}
@@ -1,11 +1,24 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
 	"github.com/dnsimple/dnsimple-go/dnsimple"
 	"github.com/dorkitude/simple/internal/client"
+	"github.com/dorkitude/simple/internal/filter"
 	"github.com/dorkitude/simple/internal/output"
+	"github.com/dorkitude/simple/internal/ui"
+	"github.com/spf13/cobra"
 )
 
 // getApp returns an authenticated App from stored credentials and flags.
@@ -13,13 +26,248 @@ func getApp(ctx context.Context) (*client.App, error) {
 	return client.NewFromFlags(ctx, accountFlag, sandboxFlag)
 }
 
-// printJSON outputs v as JSON if --json flag is set, returns true if it did.
-func printJSON(v interface{}) bool {
-	if jsonOutput {
-		output.JSON(v)
-		return true
+// stdin buffers standard input for the whole run. Every prompt reads through
+// it, so input one read buffers ahead (such as a second piped line) is still
+// there for the next.
+var stdin = bufio.NewReader(os.Stdin)
+
+// readLine reads one trimmed line from stdin, giving up if ctx is cancelled.
+func readLine(ctx context.Context) (string, error) {
+	lines := make(chan string, 1)
+	go func() {
+		line, _ := stdin.ReadString('\n')
+		lines <- strings.TrimSpace(line)
+	}()
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case line := <-lines:
+		return line, nil
+	}
+}
+
+// readSecret prompts on stderr and reads one line from stdin without echo
+// when stdin is a terminal, giving up if ctx is cancelled.
+func readSecret(ctx context.Context, prompt string) (string, error) {
+	fd := os.Stdin.Fd()
+	if !term.IsTerminal(fd) {
+		return readLine(ctx)
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	defer fmt.Fprintln(os.Stderr)
+
+	state, err := term.GetState(fd)
+	if err != nil {
+		return "", err
+	}
+	type result struct {
+		line string
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		b, err := term.ReadPassword(fd)
+		results <- result{strings.TrimSpace(string(b)), err}
+	}()
+	select {
+	case <-ctx.Done():
+		// ReadPassword is still blocked; put echo back before exiting.
+		_ = term.Restore(fd, state)
+		return "", ctx.Err()
+	case r := <-results:
+		return r.line, r.err
+	}
+}
+
+// openBrowser opens url with the platform's default handler.
+func openBrowser(url string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("open", url)
+	case "windows":
+		c = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		c = exec.Command("xdg-open", url)
+	}
+	return c.Start()
+}
+
+// printValue renders v in the --output format unless it is the default
+// table, returning true if it did. Commands print their own human output
+// when it returns false.
+func printValue(v interface{}) (bool, error) {
+	return renderer.Value(v)
+}
+
+// structuredOutput reports whether --output asks for data rather than the
+// human-readable view, for commands that print before they finish.
+func structuredOutput() bool {
+	return renderer.Format.Kind != "" && renderer.Format.Kind != output.KindTable
+}
+
+// addLayoutFlags registers --columns, --sort-by, --group-by and --wide on a
+// list command. columns is the help listing from output.ColumnNames.
+func addLayoutFlags(cmd *cobra.Command, columns string) {
+	cmd.Flags().StringSlice("columns", nil, "Columns to show, in order (available: "+columns+"; * = --wide only)")
+	cmd.Flags().StringSlice("sort-by", nil, "Sort by columns; prefix with - for descending (e.g. ttl,-name)")
+	cmd.Flags().String("group-by", "", "Group rows by a column")
+	cmd.Flags().Bool("wide", false, "Show all columns and don't truncate long values")
+}
+
+// listLayout reads the flags added by addLayoutFlags.
+func listLayout(cmd *cobra.Command) output.Layout {
+	columns, _ := cmd.Flags().GetStringSlice("columns")
+	sortBy, _ := cmd.Flags().GetStringSlice("sort-by")
+	groupBy, _ := cmd.Flags().GetString("group-by")
+	wide, _ := cmd.Flags().GetBool("wide")
+	return output.Layout{Columns: columns, SortBy: sortBy, GroupBy: groupBy, Wide: wide}
+}
+
+// addWhereFlag registers --where on a command that selects resources.
+func addWhereFlag(cmd *cobra.Command) {
+	cmd.Flags().String("where", "", `Client-side filter, e.g. 'type in ("A","AAAA") && ttl < 300'`)
+}
+
+// whereFilter parses --where and checks its fields against sample, the
+// zero value of the resource being filtered. It returns nil when the flag
+// is unset.
+func whereFilter(cmd *cobra.Command, sample interface{}) (*filter.Expr, error) {
+	src, _ := cmd.Flags().GetString("where")
+	if strings.TrimSpace(src) == "" {
+		return nil, nil
+	}
+	expr, err := filter.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := expr.Validate(sample); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+// styleWith adapts a lipgloss style to an output column style.
+func styleWith(s lipgloss.Style) func(string) string {
+	return func(cell string) string { return s.Render(cell) }
+}
+
+// boolMark shows true as a filled dot in tables.
+func boolMark(cell string) string {
+	if cell == "true" {
+		return ui.SuccessStyle.Render("●")
+	}
+	return ""
+}
+
+// listAllDomains fetches every page of the account's domains.
+func listAllDomains(ctx context.Context, app *client.App) ([]dnsimple.Domain, error) {
+	return fetchAll(func(opts dnsimple.ListOptions) ([]dnsimple.Domain, *dnsimple.Pagination, error) {
+		resp, err := app.Client.Domains.ListDomains(ctx, app.AccountID, &dnsimple.DomainListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list domains: %w", err)
+		}
+		return resp.Data, resp.Pagination, nil
+	})
+}
+
+// listAllZones fetches every page of the account's zones.
+func listAllZones(ctx context.Context, app *client.App) ([]dnsimple.Zone, error) {
+	return fetchAll(func(opts dnsimple.ListOptions) ([]dnsimple.Zone, *dnsimple.Pagination, error) {
+		resp, err := app.Client.Zones.ListZones(ctx, app.AccountID, &dnsimple.ZoneListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list zones: %w", err)
+		}
+		return resp.Data, resp.Pagination, nil
+	})
+}
+
+// listAllRecords fetches every page of a zone's records.
+func listAllRecords(ctx context.Context, app *client.App, zone string) ([]dnsimple.ZoneRecord, error) {
+	return fetchAll(func(opts dnsimple.ListOptions) ([]dnsimple.ZoneRecord, *dnsimple.Pagination, error) {
+		resp, err := app.Client.Zones.ListRecords(ctx, app.AccountID, zone, &dnsimple.ZoneRecordListOptions{ListOptions: opts})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list records: %w", err)
+		}
+		return resp.Data, resp.Pagination, nil
+	})
+}
+
+// allPages reports whether a list command must fetch every page rather than
+// the one --page and --per-page select: --where, --sort-by and --group-by
+// work on the whole listing, so they cannot be combined with --page or
+// --per-page.
+func allPages(cmd *cobra.Command) (bool, error) {
+	var whole []string
+	for _, name := range []string{"where", "sort-by", "group-by"} {
+		if cmd.Flags().Changed(name) {
+			whole = append(whole, "--"+name)
+		}
+	}
+	if len(whole) == 0 {
+		return false, nil
+	}
+	for _, name := range []string{"page", "per-page"} {
+		if cmd.Flags().Changed(name) {
+			return false, fmt.Errorf("--%s cannot be used with %s; filtering, sorting and grouping fetch every page", name, strings.Join(whole, ", "))
+		}
+	}
+	return true, nil
+}
+
+// listPages fetches every page of a listing when all is set, and otherwise
+// the page chosen by --page and --per-page.
+func listPages[T any](cmd *cobra.Command, all bool, page func(dnsimple.ListOptions) ([]T, *dnsimple.Pagination, error)) ([]T, error) {
+	if all {
+		return fetchAll(page)
+	}
+	var opts dnsimple.ListOptions
+	if n, _ := cmd.Flags().GetInt("page"); n > 0 {
+		opts.Page = dnsimpleInt(n)
+	}
+	if n, _ := cmd.Flags().GetInt("per-page"); n > 0 {
+		opts.PerPage = dnsimpleInt(n)
+	}
+	items, _, err := page(opts)
+	return items, err
+}
+
+// fetchAll walks every page of a listing.
+func fetchAll[T any](page func(dnsimple.ListOptions) ([]T, *dnsimple.Pagination, error)) ([]T, error) {
+	var all []T
+	for n := 1; ; n++ {
+		items, p, err := page(dnsimple.ListOptions{Page: dnsimple.Int(n), PerPage: dnsimple.Int(100)})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if p == nil || n >= p.TotalPages {
+			return all, nil
+		}
+	}
+}
+
+// hookTimeout bounds a single --exec run.
+const hookTimeout = 30 * time.Second
+
+// runHook runs an --exec command through the shell with payload on stdin
+// and extra environment variables. Its output goes to stderr so it never
+// mixes with a stream on stdout.
+func runHook(ctx context.Context, command string, payload []byte, env []string) error {
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", command)
 	}
-	return false
+	c.Stdin = bytes.NewReader(payload)
+	c.Stdout = os.Stderr
+	c.Stderr = os.Stderr
+	c.Env = append(os.Environ(), env...)
+	return c.Run()
 }
 
 // dnsimpleInt returns a pointer to an int (for SDK optional fields).
@@ -32,10 +280,10 @@ func dnsimpleString(v string) *string {
 	return dnsimple.String(v)
 }
 
-// truncate shortens a string to maxLen, appending "..." if truncated.
-func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+// dateOnly trims an API timestamp to its date.
+func dateOnly(ts string) string {
+	if len(ts) >= 10 {
+		return ts[:10]
 	}
-	return s[:maxLen-3] + "..."
+	return ts
 }
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/dorkitude/simple/internal/tui"
+	"github.com/dorkitude/simple/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// argPicker chooses the next missing positional argument, given the ones
+// already known.
+type argPicker func(cmd *cobra.Command, args []string) (string, error)
+
+// canPrompt reports whether missing arguments may be picked interactively:
+// not with --no-input, and only when a person is at a terminal.
+func canPrompt() bool {
+	return !noInputFlag && term.IsTerminal(os.Stdin.Fd()) && term.IsTerminal(os.Stderr.Fd())
+}
+
+// argsOrPick accepts exactly n arguments, or fewer when the rest can be
+// picked interactively.
+func argsOrPick(n int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) < n && canPrompt() {
+			return nil
+		}
+		return cobra.ExactArgs(n)(cmd, args)
+	}
+}
+
+// pickArgs fills in missing arguments with the pickers, in order, and
+// echoes the full command so it can be rerun without prompts.
+func pickArgs(cmd *cobra.Command, args []string, pickers ...argPicker) ([]string, error) {
+	if len(args) >= len(pickers) {
+		return args, nil
+	}
+	for _, pick := range pickers[len(args):] {
+		v, err := pick(cmd, args)
+		if errors.Is(err, tui.ErrPickCancelled) {
+			return nil, context.Canceled
+		}
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+	fmt.Fprintln(os.Stderr, ui.SubtleStyle.Render("→ "+cmd.CommandPath()+" "+strings.Join(args, " ")))
+	return args, nil
+}
+
+// confirmPrompt asks a yes/no question on stderr. Anything but y or yes,
+// including end of input, is no.
+func confirmPrompt(question string) (bool, error) {
+	fmt.Fprint(os.Stderr, ui.WarningStyle.Render(question)+" [y/N] ")
+	line, err := stdin.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+// typedAnswer asks for want to be typed back on stderr, for actions that
+// cost money, and returns what was typed. End of input is an empty answer.
+func typedAnswer(question, want string) (string, error) {
+	fmt.Fprintln(os.Stderr, ui.WarningStyle.Render(question))
+	fmt.Fprintf(os.Stderr, "Type %s to confirm: ", want)
+	line, err := stdin.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func pickZone(cmd *cobra.Command, args []string) (string, error) {
+	zones, err := cachedZones(cmd.Context())
+	if err != nil {
+		return "", err
+	}
+	if len(zones) == 0 {
+		return "", fmt.Errorf("no zones found")
+	}
+	items := make([]tui.PickItem, 0, len(zones))
+	for _, z := range zones {
+		detail := ""
+		if !z.Active {
+			detail = "inactive"
+		}
+		items = append(items, tui.PickItem{Value: z.Name, Label: z.Name, Detail: detail})
+	}
+	return tui.Pick("Choose a zone", items)
+}
+
+func pickDomain(cmd *cobra.Command, args []string) (string, error) {
+	domains, err := cachedDomains(cmd.Context())
+	if err != nil {
+		return "", err
+	}
+	if len(domains) == 0 {
+		return "", fmt.Errorf("no domains found")
+	}
+	items := make([]tui.PickItem, 0, len(domains))
+	for _, d := range domains {
+		items = append(items, tui.PickItem{Value: d.Name, Label: d.Name, Detail: domainSummary(d)})
+	}
+	return tui.Pick("Choose a domain", items)
+}
+
+// pickRecord chooses a record in the zone picked (or given) before it.
+func pickRecord(cmd *cobra.Command, args []string) (string, error) {
+	zone := args[0]
+	records, err := cachedRecords(cmd.Context(), zone)
+	if err != nil {
+		return "", err
+	}
+	if len(records) == 0 {
+		return "", fmt.Errorf("zone '%s' has no records", zone)
+	}
+	items := make([]tui.PickItem, 0, len(records))
+	for _, r := range records {
+		items = append(items, tui.PickItem{
+			Value:  strconv.FormatInt(r.ID, 10),
+			Label:  fmt.Sprintf("%-6s %s", r.Type, recordName(r)),
+			Detail: fmt.Sprintf("%s  ttl %d  #%d", truncateRunes(r.Content, 50), r.TTL, r.ID),
+		})
+	}
+	return tui.Pick("Choose a record in "+zone, items)
+}
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/dorkitude/simple/internal/config"
+	"github.com/dorkitude/simple/internal/output"
+	"github.com/dorkitude/simple/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:     "profile",
+	Aliases: []string{"profiles"},
+	Short:   "Manage named credential profiles",
+	Long: `Profiles keep separate tokens, accounts, environments and defaults side by side.
+
+Select a profile per command with --profile or SIMPLE_PROFILE, or persist a
+default with 'simple profile use'.`,
+}
+
+type profileInfo struct {
+	Name           string `json:"name"`
+	Active         bool   `json:"active"`
+	Environment    string `json:"environment"`
+	AccountID      string `json:"account_id,omitempty"`
+	RequestTimeout string `json:"request_timeout,omitempty"`
+	TokenStorage   string `json:"token_storage"`
+	HasToken       bool   `json:"has_token"`
+}
+
+var profileColumns = []output.Column[profileInfo]{
+	{Name: "active", Header: "Active", Value: func(p profileInfo) string { return strconv.FormatBool(p.Active) }, Style: boolMark},
+	{Name: "name", Header: "Name", Value: func(p profileInfo) string { return p.Name }, Style: styleWith(ui.AccentStyle)},
+	{Name: "environment", Header: "Environment", Value: func(p profileInfo) string { return p.Environment }},
+	{Name: "account_id", Header: "Account", Value: func(p profileInfo) string { return p.AccountID }},
+	{Name: "token", Header: "Token", Value: func(p profileInfo) string {
+		if !p.HasToken {
+			return "none"
+		}
+		return p.TokenStorage
+	}},
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Long: `List all profiles and mark the one in effect.
+
+Examples:
+  simple profile list
+  simple profile list --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		names, err := config.ProfileNames(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to list profiles: %w", err)
+		}
+		current, source := cfg.ProfileName()
+
+		infos := make([]profileInfo, 0, len(names))
+		for _, name := range names {
+			p := cfg.Profiles[name]
+			info := profileInfo{
+				Name:         name,
+				Active:       name == current,
+				Environment:  p.EnvironmentName(),
+				TokenStorage: p.StorageMode(),
+			}
+			if p != nil {
+				info.AccountID = p.AccountID
+				info.RequestTimeout = p.RequestTimeout
+			}
+			if info.TokenStorage == config.StorageCommand {
+				info.HasToken = true
+			} else if path, err := config.TokenPathFor(name); err == nil {
+				if _, err := os.Stat(path); err == nil {
+					info.HasToken = true
+				}
+			}
+			infos = append(infos, info)
+		}
+
+		if len(infos) == 0 && renderer.Format.IsTable() {
+			fmt.Println(ui.Warn("No profiles yet"))
+			fmt.Println(ui.SubtleStyle.Render("Run '" + BinName() + " auth login' to create the default profile"))
+			return nil
+		}
+
+		err = output.List(renderer, infos, output.View[profileInfo]{
+			Title:   fmt.Sprintf("👤 %d profiles", len(infos)),
+			Columns: profileColumns,
+		})
+		if err != nil || !renderer.Format.IsTable() {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Active: %s (from %s)", current, source)))
+		return nil
+	},
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:   "add [name]",
+	Short: "Create a profile",
+	Long: `Create a named profile. Authenticate it afterwards with --profile.
+
+Examples:
+  simple profile add client-acme --account 12345
+  simple profile add staging --sandbox --timeout 60s
+  simple --profile client-acme auth login`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if err := config.ValidateProfileName(name); err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if cfg.HasProfile(name) {
+			return fmt.Errorf("profile '%s' already exists", name)
+		}
+
+		timeout, _ := cmd.Flags().GetString("timeout")
+		if timeout != "" {
+			if d, err := time.ParseDuration(timeout); err != nil || d <= 0 {
+				return fmt.Errorf("invalid --timeout %q — use a positive duration such as 20s", timeout)
+			}
+		}
+		use, _ := cmd.Flags().GetBool("use")
+
+		p := cfg.AddProfile(name)
+		p.AccountID = accountFlag
+		p.Environment = config.EnvProduction
+		if sandboxFlag {
+			p.Environment = config.EnvSandbox
+		}
+		p.RequestTimeout = timeout
+		if use {
+			cfg.ActiveProfile = name
+		}
+
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		if ok, err := printValue(p); ok {
+			return err
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("Profile '%s' created (%s)", name, p.EnvironmentName())))
+		if use {
+			fmt.Println(ui.SubtleStyle.Render("Now the active profile."))
+		}
+		fmt.Println(ui.SubtleStyle.Render("Authenticate it with: " + BinName() + " --profile " + name + " auth login"))
+		return nil
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "Set the active profile",
+	Long: `Persist the profile used when neither --profile nor SIMPLE_PROFILE is set.
+
+Examples:
+  simple profile use client-acme`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if err := requireProfile(cfg, name); err != nil {
+			return err
+		}
+
+		cfg.AddProfile(name)
+		cfg.ActiveProfile = name
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("Active profile is now '%s'", name)))
+		if env := os.Getenv("SIMPLE_PROFILE"); env != "" && env != name {
+			fmt.Println(ui.Warn("SIMPLE_PROFILE=" + env + " is set and still takes precedence in this shell"))
+		}
+		return nil
+	},
+}
+
+var profileRemoveCmd = &cobra.Command{
+	Use:     "remove [name]",
+	Aliases: []string{"rm"},
+	Short:   "Delete a profile and its token",
+	Long: `PERMANENTLY delete a profile's stored token and settings.
+
+Examples:
+  simple profile remove client-acme`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if err := config.ValidateProfileName(name); err != nil {
+			return err
+		}
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if err := requireProfile(cfg, name); err != nil {
+			return err
+		}
+		wasActive := cfg.ActiveProfile == name
+
+		if err := config.RemoveProfile(cfg, name); err != nil {
+			return fmt.Errorf("failed to remove profile: %w", err)
+		}
+		if err := config.Save(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("Profile '%s' removed.", name)))
+		if wasActive {
+			fmt.Println(ui.SubtleStyle.Render("Active profile reset to '" + config.DefaultProfile + "'."))
+		}
+		return nil
+	},
+}
+
+// requireProfile returns an error unless name is in config.json or has a
+// token file.
+func requireProfile(cfg *config.Config, name string) error {
+	names, err := config.ProfileNames(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to list profiles: %w", err)
+	}
+	for _, n := range names {
+		if n == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown profile '%s' — see '%s profile list'", name, BinName())
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+
+	profileCmd.AddCommand(profileListCmd)
+
+	profileCmd.AddCommand(profileAddCmd)
+	profileAddCmd.Flags().String("timeout", "", "Per-request timeout (e.g. 20s)")
+	profileAddCmd.Flags().Bool("use", false, "Make this the active profile")
+
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileRemoveCmd)
+}
@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/output"
+	"github.com/dorkitude/simple/internal/recipes"
+	"github.com/dorkitude/simple/internal/templates"
+	"github.com/dorkitude/simple/internal/tui"
+	"github.com/dorkitude/simple/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var recipeCmd = &cobra.Command{
+	Use:     "recipe",
+	Aliases: []string{"recipes"},
+	Short:   "Apply local record recipes with parameters",
+	Long: `Recipes are YAML files describing records to add to a zone, with typed
+parameters such as a verification token. Built-in recipes (google-workspace,
+m365, github-pages, vercel) ship with simple; your own go in the recipes
+directory under the config directory, and replace a built-in of the same name.
+
+A recipe looks like:
+
+  name: relay
+  description: Outbound mail relay
+  params:
+    - {name: host, type: hostname, required: true}
+    - {name: verification, description: Optional TXT token}
+  records:
+    - {type: MX, name: "@", content: "{{ .host }}", priority: 10}
+    - {type: TXT, name: _relay, content: "{{ .verification }}"}
+
+Names and contents are Go templates that see the zone as .zone and each
+parameter by name; "dashed" turns example.com into example-com. A record
+whose content renders empty is skipped. Parameter types are string, int,
+bool, hostname, ipv4 and ipv6.`,
+}
+
+var recipeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recipes",
+	Long: `List the built-in recipes and those in the recipes directory.
+
+Examples:
+  simple recipe list
+  simple recipe list --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, err := loadRecipes()
+		if err != nil {
+			return err
+		}
+		return output.List(renderer, list, output.View[recipes.Recipe]{
+			Title:   fmt.Sprintf("🧪 %d recipes", len(list)),
+			Empty:   "No recipes found",
+			Columns: recipeColumns,
+			Layout:  listLayout(cmd),
+		})
+	},
+}
+
+var recipeColumns = []output.Column[recipes.Recipe]{
+	{Name: "name", Header: "Name", Value: func(r recipes.Recipe) string { return r.Name }, Style: styleWith(ui.AccentStyle)},
+	{Name: "params", Header: "Params", Value: recipeParamNames, OmitEmpty: true},
+	{Name: "records", Header: "Records", Value: func(r recipes.Recipe) string { return strconv.Itoa(len(r.Records)) }},
+	{Name: "description", Header: "Description", Value: func(r recipes.Recipe) string { return r.Description }, Truncate: 60, OmitEmpty: true},
+	{Name: "source", Header: "Source", Wide: true, Value: func(r recipes.Recipe) string { return r.Source }},
+}
+
+// recipeParamNames lists the parameters, marking required ones with *.
+func recipeParamNames(r recipes.Recipe) string {
+	names := make([]string, 0, len(r.Params))
+	for _, p := range r.Params {
+		if p.Required {
+			names = append(names, p.Name+"*")
+		} else {
+			names = append(names, p.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+var recipeGetCmd = &cobra.Command{
+	Use:     "get [recipe]",
+	Aliases: []string{"show"},
+	Short:   "Show a recipe's parameters and records",
+	Long: `Show a recipe's parameters and the records it creates.
+
+Examples:
+  simple recipe get m365
+  simple recipe get m365 --json`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeRecipeArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickRecipe)
+		if err != nil {
+			return err
+		}
+		list, err := loadRecipes()
+		if err != nil {
+			return err
+		}
+		r, err := recipes.Find(list, args[0])
+		if err != nil {
+			return err
+		}
+
+		if ok, err := printValue(r); ok {
+			return err
+		}
+
+		fmt.Println(ui.Title("🧪 " + r.Name))
+		if r.Description != "" {
+			fmt.Println(r.Description)
+		}
+		fmt.Println(ui.SubtleStyle.Render("Source: " + r.Source))
+		fmt.Println()
+		if len(r.Params) > 0 {
+			fmt.Println("Parameters:")
+			for _, p := range r.Params {
+				line := fmt.Sprintf("  %-14s %-8s", p.Name, p.Type)
+				if p.Required {
+					line += " required"
+				} else if p.Default != "" {
+					line += " default " + p.Default
+				}
+				fmt.Println(line)
+				if p.Description != "" {
+					fmt.Println(ui.SubtleStyle.Render("    " + p.Description))
+				}
+			}
+			fmt.Println()
+		}
+		fmt.Println("Records:")
+		for _, rec := range r.Records {
+			line := fmt.Sprintf("  %-6s %-22s %s", strings.ToUpper(rec.Type), rec.Name, rec.Content)
+			if rec.Priority != 0 {
+				line += fmt.Sprintf("  pri %d", rec.Priority)
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+var recipeApplyCmd = &cobra.Command{
+	Use:   "apply [recipe] [zone]",
+	Short: "Render a recipe and add its records to a zone",
+	Long: `Render a recipe with --param values, compare the records with the zone, and
+add the missing ones after confirmation.
+
+Each record is listed as added (+), already present (=), or conflicting (!),
+for example a second SPF policy or a CNAME where the zone has other records.
+Nothing is applied while there are conflicts. --dry-run stops after the
+preview; --yes skips the confirmation, which is required when not at a
+terminal.
+
+Examples:
+  simple recipe apply m365 example.com --param verification=ms12345678 --dry-run
+  simple recipe apply github-pages example.com --param user=octocat
+  simple recipe apply google-workspace example.com --param verification=abc123 --yes`,
+	Args:              argsOrPick(2),
+	ValidArgsFunction: completeRecipeZoneArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickRecipe, pickZone)
+		if err != nil {
+			return err
+		}
+		name, zone := args[0], args[1]
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+		pairs, _ := cmd.Flags().GetStringArray("param")
+
+		list, err := loadRecipes()
+		if err != nil {
+			return err
+		}
+		r, err := recipes.Find(list, name)
+		if err != nil {
+			return err
+		}
+		values, err := recipes.ParseParams(pairs)
+		if err != nil {
+			return err
+		}
+		rendered, err := recipes.Render(r, zone, values)
+		if err != nil {
+			return fmt.Errorf("%w (see simple recipe get %s)", err, r.Name)
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+		current, err := listAllRecords(ctx, app, zone)
+		if err != nil {
+			return err
+		}
+		plan := templates.Compare(r.Name, zone, rendered, current)
+
+		structured := structuredOutput()
+		if !structured {
+			fmt.Println(ui.Title(fmt.Sprintf("🧪 %s on %s", r.Name, zone)))
+			fmt.Println(formatTemplatePlan(plan))
+		}
+		switch {
+		case len(plan.Conflicts) > 0:
+			if structured {
+				if _, err := printValue(plan); err != nil {
+					return err
+				}
+			}
+			if dryRun {
+				if !structured {
+					fmt.Println(ui.SubtleStyle.Render("Dry run; nothing was changed."))
+				}
+				return nil
+			}
+			return fmt.Errorf("recipe conflicts with %d records in %s; nothing was applied", len(plan.Conflicts), zone)
+		case dryRun || len(plan.Add) == 0:
+			if structured {
+				_, err := printValue(plan)
+				return err
+			}
+			if dryRun {
+				fmt.Println(ui.SubtleStyle.Render("Dry run; nothing was changed."))
+			} else {
+				fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("'%s' already has every record in the recipe; nothing to do.", zone)))
+			}
+			return nil
+		}
+
+		if !yes {
+			if !canPrompt() {
+				return fmt.Errorf("refusing to change %s without confirmation; pass --yes", zone)
+			}
+			ok, err := confirmPrompt(fmt.Sprintf("Add %d records to %s?", len(plan.Add), zone))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return context.Canceled
+			}
+		}
+
+		for i, rec := range plan.Add {
+			attrs := dnsimple.ZoneRecordAttributes{
+				Type:     rec.Type,
+				Name:     dnsimpleString(rec.Name),
+				Content:  rec.Content,
+				TTL:      rec.TTL,
+				Priority: rec.Priority,
+			}
+			if _, err := app.Client.Zones.CreateRecord(ctx, app.AccountID, zone, attrs); err != nil {
+				if i > 0 {
+					forgetCompletions()
+				}
+				return fmt.Errorf("failed to create %s record %s (%d of %d added): %w", rec.Type, recordName(rec), i, len(plan.Add), err)
+			}
+		}
+		forgetCompletions()
+
+		if ok, err := printValue(plan); ok {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Recipe '%s' applied to '%s' (%d records added)", r.Name, zone, len(plan.Add))))
+		return nil
+	},
+}
+
+func loadRecipes() ([]recipes.Recipe, error) {
+	dir, err := recipes.UserDir()
+	if err != nil {
+		return nil, err
+	}
+	return recipes.Load(dir)
+}
+
+func pickRecipe(cmd *cobra.Command, args []string) (string, error) {
+	list, err := loadRecipes()
+	if err != nil {
+		return "", err
+	}
+	items := make([]tui.PickItem, 0, len(list))
+	for _, r := range list {
+		items = append(items, tui.PickItem{Value: r.Name, Label: r.Name, Detail: r.Description})
+	}
+	return tui.Pick("Choose a recipe", items)
+}
+
+// completeRecipeArg completes the recipe name taken as the first argument.
+func completeRecipeArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return withPrefix(recipeChoices(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRecipeZoneArgs completes "[recipe] [zone]".
+func completeRecipeZoneArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return withPrefix(recipeChoices(), toComplete), cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		return completeZoneArg(cmd, nil, toComplete)
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func recipeChoices() []string {
+	list, _ := loadRecipes()
+	choices := make([]string, 0, len(list))
+	for _, r := range list {
+		choices = append(choices, r.Name+"\t"+r.Description)
+	}
+	return choices
+}
+
+// completeRecipeParams completes --param with the names of the recipe given
+// as the first argument.
+func completeRecipeParams(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	list, _ := loadRecipes()
+	r, err := recipes.Find(list, args[0])
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	choices := make([]string, 0, len(r.Params))
+	for _, p := range r.Params {
+		choices = append(choices, p.Name+"=\t"+p.Description)
+	}
+	return withPrefix(choices, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+func init() {
+	rootCmd.AddCommand(recipeCmd)
+
+	recipeCmd.AddCommand(recipeListCmd)
+	addLayoutFlags(recipeListCmd, output.ColumnNames(recipeColumns))
+
+	recipeCmd.AddCommand(recipeGetCmd)
+
+	recipeCmd.AddCommand(recipeApplyCmd)
+	recipeApplyCmd.Flags().StringArray("param", nil, "Recipe parameter as name=value (repeatable)")
+	recipeApplyCmd.Flags().Bool("dry-run", false, "Show the preview without applying")
+	recipeApplyCmd.Flags().BoolP("yes", "y", false, "Apply without asking for confirmation")
+	_ = recipeApplyCmd.RegisterFlagCompletionFunc("param", completeRecipeParams)
+}
@@ -1,11 +1,13 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/filter"
+	"github.com/dorkitude/simple/internal/output"
 	"github.com/dorkitude/simple/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -26,89 +28,123 @@ Examples:
   simple records list example.com
   simple records list example.com --type A
   simple records list example.com --name www
-  simple records list example.com --json`,
-	Args: cobra.ExactArgs(1),
+  simple records list example.com --json
+  simple records list example.com -o tsv
+  simple records list example.com --columns id,type,name,content,ttl --sort-by ttl,-name
+  simple records list example.com --group-by type --wide
+  simple records list example.com -o template='{{range .}}{{.id}} {{.type}} {{.content}}{{"\n"}}{{end}}'
+  simple records list example.com --where 'type in ("A","AAAA") && ttl < 300 && content =~ "^203\."'`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeZoneArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		app, err := getApp(ctx)
+		ctx := cmd.Context()
+		where, err := whereFilter(cmd, dnsimple.ZoneRecord{})
 		if err != nil {
 			return err
 		}
-
-		zone := args[0]
-		nameFilter, _ := cmd.Flags().GetString("name")
-		typeFilter, _ := cmd.Flags().GetString("type")
-		page, _ := cmd.Flags().GetInt("page")
-		perPage, _ := cmd.Flags().GetInt("per-page")
-
-		opts := &dnsimple.ZoneRecordListOptions{}
-		if nameFilter != "" {
-			opts.Name = dnsimpleString(nameFilter)
-		}
-		if typeFilter != "" {
-			opts.Type = dnsimpleString(typeFilter)
-		}
-		if page > 0 {
-			opts.Page = dnsimpleInt(page)
+		all, err := allPages(cmd)
+		if err != nil {
+			return err
 		}
-		if perPage > 0 {
-			opts.PerPage = dnsimpleInt(perPage)
+		args, err = pickArgs(cmd, args, pickZone)
+		if err != nil {
+			return err
 		}
 
-		resp, err := app.Client.Zones.ListRecords(ctx, app.AccountID, zone, opts)
+		app, err := getApp(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to list records: %w", err)
+			return err
 		}
 
-		if printJSON(resp.Data) {
-			return nil
+		zone := args[0]
+		nameFilter, _ := cmd.Flags().GetString("name")
+		typeFilter, _ := cmd.Flags().GetString("type")
+		records, err := listPages(cmd, all, func(lo dnsimple.ListOptions) ([]dnsimple.ZoneRecord, *dnsimple.Pagination, error) {
+			opts := &dnsimple.ZoneRecordListOptions{ListOptions: lo}
+			if nameFilter != "" {
+				opts.Name = dnsimpleString(nameFilter)
+			}
+			if typeFilter != "" {
+				opts.Type = dnsimpleString(typeFilter)
+			}
+			resp, err := app.Client.Zones.ListRecords(ctx, app.AccountID, zone, opts)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to list records: %w", err)
+			}
+			return resp.Data, resp.Pagination, nil
+		})
+		if err != nil {
+			return err
 		}
 
-		if len(resp.Data) == 0 {
-			fmt.Println(ui.Warn("No records found"))
-			return nil
+		items, err := filter.Select(where, records)
+		if err != nil {
+			return err
 		}
 
-		fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("📋 %d records for %s", len(resp.Data), zone)))
-		fmt.Println()
-
-		for _, r := range resp.Data {
-			name := r.Name
-			if name == "" {
-				name = "@"
-			}
+		return output.List(renderer, items, output.View[dnsimple.ZoneRecord]{
+			Title:   fmt.Sprintf("📋 %d records for %s", len(items), zone),
+			Empty:   "No records found",
+			Columns: recordListColumns,
+			Layout:  listLayout(cmd),
+		})
+	},
+}
 
-			sysMarker := ""
-			if r.SystemRecord {
-				sysMarker = ui.SubtleStyle.Render(" [sys]")
-			}
+var recordListColumns = []output.Column[dnsimple.ZoneRecord]{
+	{Name: "id", Header: "ID", Value: func(r dnsimple.ZoneRecord) string { return strconv.FormatInt(r.ID, 10) }, Style: styleWith(ui.SubtleStyle)},
+	{Name: "type", Header: "Type", Value: func(r dnsimple.ZoneRecord) string { return r.Type }, Style: styleWith(ui.RecordTypeStyle)},
+	{Name: "name", Header: "Name", Value: recordName, Style: styleWith(ui.AccentStyle)},
+	{Name: "ttl", Header: "TTL", Value: func(r dnsimple.ZoneRecord) string { return strconv.Itoa(r.TTL) }},
+	{Name: "content", Header: "Content", Value: func(r dnsimple.ZoneRecord) string { return r.Content }, Truncate: 50},
+	{Name: "priority", Header: "Priority", Value: recordPriority},
+	{Name: "system_record", Header: "System", Value: func(r dnsimple.ZoneRecord) string { return strconv.FormatBool(r.SystemRecord) }, Style: boolMark},
+	{Name: "regions", Header: "Regions", Wide: true, Value: func(r dnsimple.ZoneRecord) string { return strings.Join(r.Regions, ",") }},
+	{Name: "created_at", Header: "Created", Wide: true, Value: func(r dnsimple.ZoneRecord) string { return r.CreatedAt }},
+	{Name: "updated_at", Header: "Updated", Wide: true, Value: func(r dnsimple.ZoneRecord) string { return r.UpdatedAt }},
+}
 
-			priorityStr := ""
-			if r.Priority != 0 {
-				priorityStr = fmt.Sprintf(" (pri: %d)", r.Priority)
-			}
+var recordDetailColumns = []output.Column[dnsimple.ZoneRecord]{
+	{Name: "id", Header: "ID", Value: func(r dnsimple.ZoneRecord) string { return strconv.FormatInt(r.ID, 10) }},
+	{Name: "type", Header: "Type", Value: func(r dnsimple.ZoneRecord) string { return r.Type }},
+	{Name: "name", Header: "Name", Value: recordName},
+	{Name: "content", Header: "Content", Value: func(r dnsimple.ZoneRecord) string { return r.Content }},
+	{Name: "ttl", Header: "TTL", Value: func(r dnsimple.ZoneRecord) string { return strconv.Itoa(r.TTL) }},
+	{Name: "priority", Header: "Priority", OmitEmpty: true, Value: recordPriority},
+	{Name: "regions", Header: "Regions", OmitEmpty: true, Value: func(r dnsimple.ZoneRecord) string { return strings.Join(r.Regions, ",") }},
+	{Name: "system_record", Header: "System", Value: func(r dnsimple.ZoneRecord) string { return strconv.FormatBool(r.SystemRecord) }},
+	{Name: "created_at", Header: "Created", Value: func(r dnsimple.ZoneRecord) string { return r.CreatedAt }},
+	{Name: "updated_at", Header: "Updated", Value: func(r dnsimple.ZoneRecord) string { return r.UpdatedAt }},
+}
 
-			fmt.Printf("  %s %-20s %-6d %s%s%s\n",
-				ui.RecordTypeStyle.Render(r.Type),
-				ui.AccentStyle.Render(name),
-				r.TTL,
-				truncate(r.Content, 50),
-				priorityStr,
-				sysMarker,
-			)
-		}
+// recordName shows the apex as "@".
+func recordName(r dnsimple.ZoneRecord) string {
+	if r.Name == "" {
+		return "@"
+	}
+	return r.Name
+}
 
-		return nil
-	},
+func recordPriority(r dnsimple.ZoneRecord) string {
+	if r.Priority == 0 {
+		return ""
+	}
+	return strconv.Itoa(r.Priority)
 }
 
 var recordsGetCmd = &cobra.Command{
-	Use:   "get [zone] [record-id]",
-	Short: "Get record details",
-	Long:  `Display detailed information about a specific DNS record.`,
-	Args:  cobra.ExactArgs(2),
+	Use:               "get [zone] [record-id]",
+	Short:             "Get record details",
+	Long:              `Display detailed information about a specific DNS record.`,
+	Args:              argsOrPick(2),
+	ValidArgsFunction: completeZoneRecordArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		args, err := pickArgs(cmd, args, pickZone, pickRecord)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
 		app, err := getApp(ctx)
 		if err != nil {
 			return err
@@ -125,34 +161,11 @@ var recordsGetCmd = &cobra.Command{
 			return fmt.Errorf("failed to get record: %w", err)
 		}
 
-		if printJSON(resp.Data) {
-			return nil
-		}
-
-		r := resp.Data
-		name := r.Name
-		if name == "" {
-			name = "@"
-		}
-
-		fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("📋 %s %s.%s", r.Type, name, zone)))
-		fmt.Println()
-		fmt.Printf("  %-14s %d\n", "ID:", r.ID)
-		fmt.Printf("  %-14s %s\n", "Type:", r.Type)
-		fmt.Printf("  %-14s %s\n", "Name:", name)
-		fmt.Printf("  %-14s %s\n", "Content:", r.Content)
-		fmt.Printf("  %-14s %d\n", "TTL:", r.TTL)
-		if r.Priority != 0 {
-			fmt.Printf("  %-14s %d\n", "Priority:", r.Priority)
-		}
-		if len(r.Regions) > 0 {
-			fmt.Printf("  %-14s %v\n", "Regions:", r.Regions)
-		}
-		fmt.Printf("  %-14s %v\n", "System:", r.SystemRecord)
-		fmt.Printf("  %-14s %s\n", "Created:", r.CreatedAt)
-		fmt.Printf("  %-14s %s\n", "Updated:", r.UpdatedAt)
-
-		return nil
+		r := *resp.Data
+		return output.Item(renderer, r, output.View[dnsimple.ZoneRecord]{
+			Title:   fmt.Sprintf("📋 %s %s.%s", r.Type, recordName(r), zone),
+			Columns: recordDetailColumns,
+		})
 	},
 }
 
@@ -166,9 +179,15 @@ Examples:
   simple records create example.com --type CNAME --name blog --content example.com
   simple records create example.com --type MX --name "" --content mail.example.com --priority 10
   simple records create example.com --type TXT --name @ --content "v=spf1 include:_spf.google.com ~all"`,
-	Args: cobra.ExactArgs(1),
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeZoneArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		args, err := pickArgs(cmd, args, pickZone)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
 		app, err := getApp(ctx)
 		if err != nil {
 			return err
@@ -201,9 +220,10 @@ Examples:
 		if err != nil {
 			return fmt.Errorf("failed to create record: %w", err)
 		}
+		forgetCompletions()
 
-		if printJSON(resp.Data) {
-			return nil
+		if ok, err := printValue(resp.Data); ok {
+			return err
 		}
 
 		r := resp.Data
@@ -226,9 +246,15 @@ var recordsUpdateCmd = &cobra.Command{
 Examples:
   simple records update example.com 12345 --content 5.6.7.8
   simple records update example.com 12345 --name www2 --ttl 600`,
-	Args: cobra.ExactArgs(2),
+	Args:              argsOrPick(2),
+	ValidArgsFunction: completeZoneRecordArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		args, err := pickArgs(cmd, args, pickZone, pickRecord)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
 		app, err := getApp(ctx)
 		if err != nil {
 			return err
@@ -263,9 +289,10 @@ Examples:
 		if err != nil {
 			return fmt.Errorf("failed to update record: %w", err)
 		}
+		forgetCompletions()
 
-		if printJSON(resp.Data) {
-			return nil
+		if ok, err := printValue(resp.Data); ok {
+			return err
 		}
 
 		r := resp.Data
@@ -287,9 +314,15 @@ var recordsDeleteCmd = &cobra.Command{
 
 Examples:
   simple records delete example.com 12345`,
-	Args: cobra.ExactArgs(2),
+	Args:              argsOrPick(2),
+	ValidArgsFunction: completeZoneRecordArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		args, err := pickArgs(cmd, args, pickZone, pickRecord)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
 		app, err := getApp(ctx)
 		if err != nil {
 			return err
@@ -305,6 +338,7 @@ Examples:
 		if err != nil {
 			return fmt.Errorf("failed to delete record: %w", err)
 		}
+		forgetCompletions()
 
 		fmt.Println(ui.Success(fmt.Sprintf("Record %d deleted from zone '%s'", recordID, zone)))
 		return nil
@@ -318,9 +352,15 @@ var recordsDistributionCmd = &cobra.Command{
 
 Examples:
   simple records distribution example.com 12345`,
-	Args: cobra.ExactArgs(2),
+	Args:              argsOrPick(2),
+	ValidArgsFunction: completeZoneRecordArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		args, err := pickArgs(cmd, args, pickZone, pickRecord)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
 		app, err := getApp(ctx)
 		if err != nil {
 			return err
@@ -337,8 +377,8 @@ Examples:
 			return fmt.Errorf("failed to check distribution: %w", err)
 		}
 
-		if printJSON(resp.Data) {
-			return nil
+		if ok, err := printValue(resp.Data); ok {
+			return err
 		}
 
 		if resp.Data.Distributed {
@@ -357,8 +397,11 @@ func init() {
 	recordsCmd.AddCommand(recordsListCmd)
 	recordsListCmd.Flags().String("name", "", "Filter by record name")
 	recordsListCmd.Flags().String("type", "", "Filter by record type (A, AAAA, CNAME, MX, etc.)")
-	recordsListCmd.Flags().Int("page", 0, "Page number")
-	recordsListCmd.Flags().Int("per-page", 0, "Results per page")
+	recordsListCmd.Flags().Int("page", 0, "Page number (not with --where, --sort-by or --group-by)")
+	recordsListCmd.Flags().Int("per-page", 0, "Results per page (not with --where, --sort-by or --group-by)")
+	addLayoutFlags(recordsListCmd, output.ColumnNames(recordListColumns))
+	addWhereFlag(recordsListCmd)
+	_ = recordsListCmd.RegisterFlagCompletionFunc("type", completeRecordTypes)
 
 	recordsCmd.AddCommand(recordsGetCmd)
 
@@ -368,6 +411,7 @@ func init() {
 	recordsCreateCmd.Flags().StringP("content", "c", "", "Record content/value")
 	recordsCreateCmd.Flags().Int("ttl", 0, "Time to live in seconds")
 	recordsCreateCmd.Flags().Int("priority", 0, "Record priority (for MX, SRV)")
+	_ = recordsCreateCmd.RegisterFlagCompletionFunc("type", completeRecordTypes)
 
 	recordsCmd.AddCommand(recordsUpdateCmd)
 	recordsUpdateCmd.Flags().StringP("name", "n", "", "New record name")
@@ -0,0 +1,687 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/client"
+	"github.com/dorkitude/simple/internal/filter"
+	"github.com/dorkitude/simple/internal/output"
+	"github.com/dorkitude/simple/internal/registrar"
+	"github.com/dorkitude/simple/internal/tui"
+	"github.com/dorkitude/simple/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var registrarCmd = &cobra.Command{
+	Use:   "registrar",
+	Short: "Check, register, and transfer domain names",
+	Long: `Check domain availability and prices, and register, renew, and transfer
+domains at the DNSimple registrar.
+
+Commands that cost money print a cost preview first and go ahead only when
+the domain name is typed back, or given with --confirm.`,
+}
+
+var registrarCheckCmd = &cobra.Command{
+	Use:   "check <domain...>",
+	Short: "Check availability and prices of domain names",
+	Long: `Report whether each name can be registered, whether it is premium, and
+its registration, renewal and transfer prices. Names are checked
+concurrently.
+
+A bare label such as "acme" is tried with every TLD in --tlds.
+
+Examples:
+  simple registrar check acme.com acme.io
+  simple registrar check acme rocketco --tlds com,io,dev
+  simple registrar check acme --where 'available && registration_price < 20' --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tlds, _ := cmd.Flags().GetStringSlice("tlds")
+		names, err := registrar.Candidates(args, tlds)
+		if err != nil {
+			return err
+		}
+		where, err := whereFilter(cmd, registrar.Result{})
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		results, err := registrar.Check(ctx, registrar.NewLookup(app.Client, app.AccountID), names)
+		if err != nil {
+			return fmt.Errorf("failed to check domains: %w", err)
+		}
+
+		items, err := filter.Select(where, results)
+		if err != nil {
+			return err
+		}
+
+		available := 0
+		for _, r := range items {
+			if r.Available {
+				available++
+			}
+		}
+		return output.List(renderer, items, output.View[registrar.Result]{
+			Title:   fmt.Sprintf("🔎 %d of %d names available", available, len(items)),
+			Empty:   "No names matched",
+			Columns: checkColumns,
+			Layout:  listLayout(cmd),
+		})
+	},
+}
+
+var checkColumns = []output.Column[registrar.Result]{
+	{Name: "domain", Header: "Domain", Value: func(r registrar.Result) string { return r.Domain }, Style: styleWith(ui.AccentStyle)},
+	{Name: "available", Header: "Available", Value: func(r registrar.Result) string { return strconv.FormatBool(r.Available) }, Style: boolMark},
+	{Name: "premium", Header: "Premium", Value: func(r registrar.Result) string { return strconv.FormatBool(r.Premium) }, Style: boolMark},
+	{Name: "registration_price", Header: "Register", Value: func(r registrar.Result) string { return formatPrice(r.RegistrationPrice) }},
+	{Name: "renewal_price", Header: "Renew", Value: func(r registrar.Result) string { return formatPrice(r.RenewalPrice) }},
+	{Name: "transfer_price", Header: "Transfer", Value: func(r registrar.Result) string { return formatPrice(r.TransferPrice) }},
+	{Name: "error", Header: "Error", Value: func(r registrar.Result) string { return r.Error }, OmitEmpty: true, Truncate: 48},
+}
+
+// formatPrice shows a price with cents, or nothing when it is unknown.
+func formatPrice(p float64) string {
+	if p == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(p, 'f', 2, 64)
+}
+
+var registrarRegisterCmd = &cobra.Command{
+	Use:   "register <domain>",
+	Short: "Register a domain name",
+	Long: `Register a domain for one year with a registrant contact from the
+account. Some TLDs require extended attributes; pass them with --attr, and
+the command lists any that are missing.
+
+Examples:
+  simple registrar register acme.io --contact 1234
+  simple registrar register acme.ca --contact 1234 --attr x-ca-legal-type=CCT --attr x-ca-lang=en
+  simple registrar register acme.dev --contact 1234 --auto-renew --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		domain, err := registrar.Name(args[0])
+		if err != nil {
+			return err
+		}
+		autoRenew, _ := cmd.Flags().GetBool("auto-renew")
+		privacy, _ := cmd.Flags().GetBool("whois-privacy")
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		check, err := app.Client.Registrar.CheckDomain(ctx, app.AccountID, domain)
+		if err != nil {
+			return fmt.Errorf("failed to check domain: %w", err)
+		}
+		if !check.Data.Available {
+			return fmt.Errorf("'%s' is not available for registration", domain)
+		}
+		order, err := prepareOrder(cmd, app, registrar.ActionRegister, domain, 1)
+		if err != nil {
+			return err
+		}
+		order.details = append(order.details,
+			[2]string{"Auto-renew", autoRenewNote(autoRenew)},
+			[2]string{"WHOIS privacy", privacyNote(privacy)})
+
+		approval, err := orderApproval(cmd, order)
+		if err != nil {
+			return err
+		}
+		var resp *dnsimple.DomainRegistrationResponse
+		placed, err := registrar.Order(order.quote, approval, func() (err error) {
+			resp, err = app.Client.Registrar.RegisterDomain(ctx, app.AccountID, domain, &dnsimple.RegisterDomainInput{
+				RegistrantID:       int(order.contact.ID),
+				EnableWhoisPrivacy: privacy,
+				EnableAutoRenewal:  autoRenew,
+				ExtendedAttributes: order.attrs,
+				PremiumPrice:       order.quote.PremiumPrice(),
+			})
+			return err
+		})
+		if !placed {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("failed to register domain: %w", err)
+		}
+		forgetCompletions()
+
+		if ok, err := printValue(resp.Data); ok {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Registration of '%s' is %s (ID: %d).", domain, resp.Data.State, resp.Data.ID)))
+		return nil
+	},
+}
+
+var registrarRenewCmd = &cobra.Command{
+	Use:   "renew [domain]",
+	Short: "Renew a domain registration",
+	Long: `Renew a domain in the account for one or more years.
+
+Examples:
+  simple registrar renew example.com
+  simple registrar renew example.com --period 3 --dry-run`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeDomainArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickDomain)
+		if err != nil {
+			return err
+		}
+		domain := args[0]
+		period, _ := cmd.Flags().GetInt("period")
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		order, err := prepareOrder(cmd, app, registrar.ActionRenew, domain, period)
+		if err != nil {
+			return err
+		}
+		approval, err := orderApproval(cmd, order)
+		if err != nil {
+			return err
+		}
+		var resp *dnsimple.DomainRenewalResponse
+		placed, err := registrar.Order(order.quote, approval, func() (err error) {
+			resp, err = app.Client.Registrar.RenewDomain(ctx, app.AccountID, domain, &dnsimple.RenewDomainInput{
+				Period:       period,
+				PremiumPrice: order.quote.PremiumPrice(),
+			})
+			return err
+		})
+		if !placed {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("failed to renew domain: %w", err)
+		}
+
+		if ok, err := printValue(resp.Data); ok {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Renewal of '%s' for %s is %s (ID: %d).", domain, years(resp.Data.Period), resp.Data.State, resp.Data.ID)))
+		return nil
+	},
+}
+
+var registrarTransferCmd = &cobra.Command{
+	Use:   "transfer <domain>",
+	Short: "Transfer a domain in from another registrar",
+	Long: `Start transferring a domain to DNSimple. Most TLDs need the auth code
+from the current registrar. A transfer includes a year of renewal.
+
+The transfer runs in the background; follow it with transfer-status using
+the transfer ID this command prints.
+
+Examples:
+  simple registrar transfer example.com --contact 1234 --auth-code 'x1y2z3'
+  simple registrar transfer example.com --contact 1234 --auth-code 'x1y2z3' --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		domain, err := registrar.Name(args[0])
+		if err != nil {
+			return err
+		}
+		authCode, _ := cmd.Flags().GetString("auth-code")
+		autoRenew, _ := cmd.Flags().GetBool("auto-renew")
+		privacy, _ := cmd.Flags().GetBool("whois-privacy")
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		order, err := prepareOrder(cmd, app, registrar.ActionTransfer, domain, 1)
+		if err != nil {
+			return err
+		}
+		authNote := "given"
+		if authCode == "" {
+			authNote = "none (some TLDs require one)"
+		}
+		order.details = append(order.details,
+			[2]string{"Auth code", authNote},
+			[2]string{"Auto-renew", autoRenewNote(autoRenew)},
+			[2]string{"WHOIS privacy", privacyNote(privacy)})
+
+		approval, err := orderApproval(cmd, order)
+		if err != nil {
+			return err
+		}
+		var resp *dnsimple.DomainTransferResponse
+		placed, err := registrar.Order(order.quote, approval, func() (err error) {
+			resp, err = app.Client.Registrar.TransferDomain(ctx, app.AccountID, domain, &dnsimple.TransferDomainInput{
+				RegistrantID:       int(order.contact.ID),
+				AuthCode:           authCode,
+				EnableWhoisPrivacy: privacy,
+				EnableAutoRenewal:  autoRenew,
+				ExtendedAttributes: order.attrs,
+				PremiumPrice:       order.quote.PremiumPrice(),
+			})
+			return err
+		})
+		if !placed {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("failed to transfer domain: %w", err)
+		}
+		forgetCompletions()
+
+		if ok, err := printValue(resp.Data); ok {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Transfer of '%s' is %s (ID: %d).", domain, resp.Data.State, resp.Data.ID)))
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Follow it with: simple registrar transfer-status %s %d", domain, resp.Data.ID)))
+		return nil
+	},
+}
+
+var transferColumns = []output.Column[dnsimple.DomainTransfer]{
+	{Name: "id", Header: "ID", Value: func(t dnsimple.DomainTransfer) string { return strconv.FormatInt(t.ID, 10) }},
+	{Name: "state", Header: "State", Value: func(t dnsimple.DomainTransfer) string { return t.State }, Style: styleWith(ui.AccentStyle)},
+	{Name: "status_description", Header: "Status", Value: func(t dnsimple.DomainTransfer) string { return t.StatusDescription }, OmitEmpty: true},
+	{Name: "domain_id", Header: "Domain ID", Value: func(t dnsimple.DomainTransfer) string { return strconv.FormatInt(t.DomainID, 10) }},
+	{Name: "registrant_id", Header: "Registrant", Value: func(t dnsimple.DomainTransfer) string { return strconv.FormatInt(t.RegistrantID, 10) }},
+	{Name: "auto_renew", Header: "Auto-Renew", Value: func(t dnsimple.DomainTransfer) string { return strconv.FormatBool(t.AutoRenew) }, Style: boolMark},
+	{Name: "whois_privacy", Header: "WHOIS Privacy", Value: func(t dnsimple.DomainTransfer) string { return strconv.FormatBool(t.WhoisPrivacy) }, Style: boolMark},
+	{Name: "created_at", Header: "Created", Value: func(t dnsimple.DomainTransfer) string { return t.CreatedAt }},
+	{Name: "updated_at", Header: "Updated", Value: func(t dnsimple.DomainTransfer) string { return t.UpdatedAt }},
+}
+
+var registrarTransferStatusCmd = &cobra.Command{
+	Use:   "transfer-status <domain> <transfer-id>",
+	Short: "Show the state of a domain transfer",
+	Long: `Show the state of an inbound domain transfer started with
+"simple registrar transfer".
+
+Examples:
+  simple registrar transfer-status example.com 361`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		transferID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid transfer ID: %w", err)
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Registrar.GetDomainTransfer(ctx, app.AccountID, args[0], transferID)
+		if err != nil {
+			return fmt.Errorf("failed to get domain transfer: %w", err)
+		}
+
+		return output.Item(renderer, *resp.Data, output.View[dnsimple.DomainTransfer]{
+			Title:   fmt.Sprintf("🚚 Transfer %d of %s", resp.Data.ID, args[0]),
+			Columns: transferColumns,
+		})
+	},
+}
+
+var registrarTransferCancelCmd = &cobra.Command{
+	Use:   "transfer-cancel <domain> <transfer-id>",
+	Short: "Cancel a domain transfer in progress",
+	Long: `Cancel an inbound domain transfer that has not completed.
+
+Examples:
+  simple registrar transfer-cancel example.com 361
+  simple registrar transfer-cancel example.com 361 --yes`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		domain := args[0]
+		transferID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid transfer ID: %w", err)
+		}
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !yes {
+			if !canPrompt() {
+				return fmt.Errorf("refusing to cancel the transfer of %s without confirmation; pass --yes", domain)
+			}
+			ok, err := confirmPrompt(fmt.Sprintf("Cancel transfer %d of %s?", transferID, domain))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return context.Canceled
+			}
+		}
+
+		resp, err := app.Client.Registrar.CancelDomainTransfer(ctx, app.AccountID, domain, transferID)
+		if err != nil {
+			return fmt.Errorf("failed to cancel domain transfer: %w", err)
+		}
+
+		if ok, err := printValue(resp.Data); ok {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Transfer %d of '%s' is %s.", transferID, domain, resp.Data.State)))
+		return nil
+	},
+}
+
+var registrarAuthorizeTransferOutCmd = &cobra.Command{
+	Use:   "authorize-transfer-out [domain]",
+	Short: "Prepare a domain to transfer to another registrar",
+	Long: `Unlock a domain and send its auth code to the registrant contact, so the
+new registrar can request the transfer.
+
+Examples:
+  simple registrar authorize-transfer-out example.com`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeDomainArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickDomain)
+		if err != nil {
+			return err
+		}
+		domain := args[0]
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !yes {
+			if !canPrompt() {
+				return fmt.Errorf("refusing to authorize a transfer of %s without confirmation; pass --yes", domain)
+			}
+			ok, err := confirmPrompt(fmt.Sprintf("Unlock %s and email its auth code to the registrant?", domain))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return context.Canceled
+			}
+		}
+
+		if _, err := app.Client.Registrar.TransferDomainOut(ctx, app.AccountID, domain); err != nil {
+			return fmt.Errorf("failed to authorize transfer out: %w", err)
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("Transfer out of '%s' authorized. The auth code is on its way to the registrant.", domain)))
+		return nil
+	},
+}
+
+// order is a billable registrar action waiting for confirmation.
+type order struct {
+	quote   registrar.Quote
+	contact *dnsimple.Contact
+	attrs   map[string]string
+	// details are extra label/value lines for the cost preview.
+	details [][2]string
+}
+
+// prepareOrder prices an action and, for registrations and transfers,
+// resolves the registrant contact and checks the TLD's extended
+// attributes.
+func prepareOrder(cmd *cobra.Command, app *client.App, action, domain string, period int) (*order, error) {
+	ctx := cmd.Context()
+	prices, err := app.Client.Registrar.GetDomainPrices(ctx, app.AccountID, domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain prices: %w", err)
+	}
+	quote, err := registrar.NewQuote(action, period, *prices.Data)
+	if err != nil {
+		return nil, err
+	}
+	o := &order{quote: quote}
+	if action == registrar.ActionRenew {
+		return o, nil
+	}
+
+	if o.contact, err = registrantContact(cmd, app); err != nil {
+		return nil, err
+	}
+	pairs, _ := cmd.Flags().GetStringArray("attr")
+	if o.attrs, err = registrar.ParseAttributes(pairs); err != nil {
+		return nil, err
+	}
+	tld := registrar.TLD(domain)
+	defs, err := app.Client.Tlds.GetTldExtendedAttributes(ctx, tld)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get extended attributes for .%s: %w", tld, err)
+	}
+	if err := registrar.CheckAttributes(tld, defs.Data, o.attrs); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// registrantContact returns the contact named by --contact, or lets the
+// user pick one.
+func registrantContact(cmd *cobra.Command, app *client.App) (*dnsimple.Contact, error) {
+	ctx := cmd.Context()
+	id, _ := cmd.Flags().GetInt64("contact")
+	if id == 0 {
+		if !canPrompt() {
+			return nil, fmt.Errorf("--contact is required")
+		}
+		picked, err := pickContact(cmd, app)
+		if err != nil {
+			return nil, err
+		}
+		id = picked
+	}
+	resp, err := app.Client.Contacts.GetContact(ctx, app.AccountID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact: %w", err)
+	}
+	return resp.Data, nil
+}
+
+func pickContact(cmd *cobra.Command, app *client.App) (int64, error) {
+	ctx := cmd.Context()
+	contacts, err := fetchAll(func(opts dnsimple.ListOptions) ([]dnsimple.Contact, *dnsimple.Pagination, error) {
+		resp, err := app.Client.Contacts.ListContacts(ctx, app.AccountID, &opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list contacts: %w", err)
+		}
+		return resp.Data, resp.Pagination, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(contacts) == 0 {
+		return 0, fmt.Errorf("the account has no contacts; add one in the DNSimple web app first")
+	}
+	items := make([]tui.PickItem, 0, len(contacts))
+	for _, c := range contacts {
+		items = append(items, tui.PickItem{
+			Value:  strconv.FormatInt(c.ID, 10),
+			Label:  contactLabel(c),
+			Detail: fmt.Sprintf("%s  #%d", c.Email, c.ID),
+		})
+	}
+	picked, err := tui.Pick("Choose the registrant contact", items)
+	if errors.Is(err, tui.ErrPickCancelled) {
+		return 0, context.Canceled
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(picked, 10, 64)
+}
+
+// contactLabel names a contact by its label, or its person and
+// organization.
+func contactLabel(c dnsimple.Contact) string {
+	if c.Label != "" {
+		return c.Label
+	}
+	name := strings.TrimSpace(c.FirstName + " " + c.LastName)
+	if c.Organization != "" {
+		name += " (" + c.Organization + ")"
+	}
+	return name
+}
+
+// orderApproval prints the cost preview and returns how the order is
+// approved: from --dry-run and --confirm, or by typing the domain back.
+func orderApproval(cmd *cobra.Command, o *order) (registrar.Approval, error) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	confirm, _ := cmd.Flags().GetString("confirm")
+	approval := registrar.Approval{
+		DryRun:      dryRun,
+		Confirm:     confirm,
+		Interactive: canPrompt(),
+		Ask:         typedAnswer,
+	}
+
+	structured := structuredOutput()
+	if structured && dryRun {
+		_, err := printValue(o.quote)
+		return approval, err
+	}
+	if !structured {
+		printQuote(o)
+		if dryRun {
+			fmt.Println(ui.SubtleStyle.Render("Dry run; nothing was ordered."))
+		}
+	}
+	return approval, nil
+}
+
+func printQuote(o *order) {
+	q := o.quote
+	verb := map[string]string{
+		registrar.ActionRegister: "Register",
+		registrar.ActionRenew:    "Renew",
+		registrar.ActionTransfer: "Transfer",
+	}[q.Action]
+	fmt.Println(ui.Title(fmt.Sprintf("💳 %s %s", verb, q.Domain)))
+	price := money(q.Price) + " per year"
+	if q.Premium {
+		price += " (premium)"
+	}
+	output.PrintKeyValue("Period", years(q.Period))
+	output.PrintKeyValue("Price", price)
+	output.PrintKeyValue("Total", ui.AccentStyle.Render(money(q.Total)))
+	if o.contact != nil {
+		output.PrintKeyValue("Registrant", fmt.Sprintf("%s <%s> #%d", contactLabel(*o.contact), o.contact.Email, o.contact.ID))
+	}
+	names := make([]string, 0, len(o.attrs))
+	for name := range o.attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		output.PrintKeyValue("Attribute", name+"="+o.attrs[name])
+	}
+	for _, d := range o.details {
+		output.PrintKeyValue(d[0], d[1])
+	}
+	fmt.Println()
+}
+
+func money(p float64) string {
+	return "$" + strconv.FormatFloat(p, 'f', 2, 64)
+}
+
+func years(n int) string {
+	if n == 1 {
+		return "1 year"
+	}
+	return fmt.Sprintf("%d years", n)
+}
+
+// autoRenewNote describes what is sent for auto-renewal. The API only
+// receives the setting when --auto-renew is given; without it the
+// account's default applies.
+func autoRenewNote(b bool) string {
+	if b {
+		return "on"
+	}
+	return "API default (pass --auto-renew to turn it on)"
+}
+
+func privacyNote(b bool) string {
+	if b {
+		return "on (may cost extra)"
+	}
+	return "off"
+}
+
+// addOrderFlags registers the flags shared by billable commands.
+func addOrderFlags(cmd *cobra.Command) {
+	cmd.Flags().String("confirm", "", "Confirm the order without a prompt by giving the domain name")
+	cmd.Flags().Bool("dry-run", false, "Show the cost preview without ordering")
+}
+
+// addRegistrantFlags registers the flags for registrations and transfers.
+func addRegistrantFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64("contact", 0, "ID of the registrant contact (picked interactively if omitted)")
+	cmd.Flags().StringArray("attr", nil, "Extended attribute the TLD requires, as name=value (repeatable)")
+	cmd.Flags().Bool("auto-renew", false, "Turn on automatic renewal (without it the API default applies)")
+	cmd.Flags().Bool("whois-privacy", false, "Enable WHOIS privacy")
+}
+
+func init() {
+	rootCmd.AddCommand(registrarCmd)
+
+	registrarCmd.AddCommand(registrarCheckCmd)
+	registrarCheckCmd.Flags().StringSlice("tlds", registrar.DefaultTLDs, "TLDs to try for a bare label")
+	addLayoutFlags(registrarCheckCmd, output.ColumnNames(checkColumns))
+	addWhereFlag(registrarCheckCmd)
+
+	registrarCmd.AddCommand(registrarRegisterCmd)
+	addRegistrantFlags(registrarRegisterCmd)
+	addOrderFlags(registrarRegisterCmd)
+
+	registrarCmd.AddCommand(registrarRenewCmd)
+	registrarRenewCmd.Flags().Int("period", 1, "Years to renew for")
+	addOrderFlags(registrarRenewCmd)
+
+	registrarCmd.AddCommand(registrarTransferCmd)
+	registrarTransferCmd.Flags().String("auth-code", "", "Auth code from the current registrar")
+	addRegistrantFlags(registrarTransferCmd)
+	addOrderFlags(registrarTransferCmd)
+
+	registrarCmd.AddCommand(registrarTransferStatusCmd)
+
+	registrarCmd.AddCommand(registrarTransferCancelCmd)
+	registrarTransferCancelCmd.Flags().BoolP("yes", "y", false, "Cancel without asking for confirmation")
+
+	registrarCmd.AddCommand(registrarAuthorizeTransferOutCmd)
+	registrarAuthorizeTransferOutCmd.Flags().BoolP("yes", "y", false, "Authorize without asking for confirmation")
+}
@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 
+	"github.com/dorkitude/simple/internal/config"
+	"github.com/dorkitude/simple/internal/output"
 	"github.com/dorkitude/simple/internal/tui"
 	"github.com/dorkitude/simple/internal/ui"
 	"github.com/spf13/cobra"
@@ -12,11 +17,66 @@ import (
 
 var (
 	jsonOutput  bool
+	outputFlag  string
+	profileFlag string
 	accountFlag string
 	sandboxFlag bool
 	noColorFlag bool
+	plainFlag   bool
+	noInputFlag bool
 )
 
+// renderer writes command results in the format chosen with --output.
+var renderer = output.New(output.Format{Kind: output.KindTable})
+
+// outputFormat resolves --output, treating --json as an alias for
+// --output json.
+func outputFormat() (output.Format, error) {
+	format, err := output.ParseFormat(outputFlag)
+	if err != nil {
+		return output.Format{}, err
+	}
+	if jsonOutput {
+		if outputFlag != "" && format.Kind != output.KindJSON {
+			return output.Format{}, fmt.Errorf("--json conflicts with --output %s", format)
+		}
+		format = output.Format{Kind: output.KindJSON}
+	}
+	return format, nil
+}
+
+// configureUI applies --no-color and --plain. Help output calls it too,
+// since help runs before PersistentPreRunE.
+func configureUI() {
+	ui.Configure(ui.Options{NoColor: noColorFlag, Plain: plainFlag})
+}
+
+// rootLong is the root help text, built when shown so it honors the
+// display flags.
+func rootLong() string {
+	return ui.Title("🌐 simple") + `
+A beautiful CLI for managing DNS with DNSimple.
+
+` + ui.SubtleStyle.Render("Commands:") + `
+  auth            Authenticate with DNSimple
+  profile         Manage named credential profiles
+  whoami          Show current identity
+  accounts        List and choose DNSimple accounts
+  domains         Manage domains
+  zones           Manage DNS zones
+  records         Manage DNS records
+  watch           Stream record changes as they happen
+  webhooks        Manage webhooks and receive their events
+  dnssec          Enable, disable and check DNSSEC signing
+  ds              Manage delegation signer records
+  email-forwards  Manage email forwards
+  templates       Manage record templates and apply them to domains
+  recipe          Apply local record recipes with parameters
+  registrar       Check, register, renew and transfer domains
+  completion      Generate shell completion scripts
+`
+}
+
 // BinName returns the name this binary was invoked as.
 func BinName() string {
 	return filepath.Base(os.Args[0])
@@ -25,16 +85,26 @@ func BinName() string {
 var rootCmd = &cobra.Command{
 	Use:   "simple",
 	Short: "A CLI for DNSimple DNS management",
-	Long: ui.TitleStyle.Render("🌐 simple") + `
-A beautiful CLI for managing DNS with DNSimple.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		configureUI()
 
-` + ui.SubtleStyle.Render("Commands:") + `
-  auth        Authenticate with DNSimple
-  whoami      Show current identity
-  domains     Manage domains
-  zones       Manage DNS zones
-  records     Manage DNS records
-`,
+		format, err := outputFormat()
+		if err != nil {
+			return err
+		}
+		renderer = output.New(format)
+
+		config.SetPassphrasePrompt(func() (string, error) {
+			return readSecret(cmd.Context(), "Token passphrase: ")
+		})
+		if profileFlag != "" {
+			if err := config.ValidateProfileName(profileFlag); err != nil {
+				return err
+			}
+			config.SetProfile(profileFlag)
+		}
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return tui.Run()
 	},
@@ -45,16 +115,36 @@ func Execute() {
 	// Adapt the root command name to whatever binary name was used
 	rootCmd.Use = BinName()
 
-	if err := rootCmd.Execute(); err != nil {
+	// Ctrl-C cancels the command context so in-flight API calls stop cleanly.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			fmt.Fprintln(os.Stderr, ui.Warn("Cancelled."))
+			os.Exit(130)
+		}
 		fmt.Fprintln(os.Stderr, ui.Err(err.Error()))
 		os.Exit(1)
 	}
 }
 
 func init() {
-	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output as JSON (alias for --output json)")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "", "Output format: "+output.FormatHelp)
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Credential profile to use (overrides SIMPLE_PROFILE)")
 	rootCmd.PersistentFlags().StringVar(&accountFlag, "account", "", "DNSimple account ID (overrides cached)")
 	rootCmd.PersistentFlags().BoolVar(&sandboxFlag, "sandbox", false, "Use DNSimple sandbox API")
-	rootCmd.PersistentFlags().BoolVar(&noColorFlag, "no-color", false, "Disable colored output")
-	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.PersistentFlags().BoolVar(&noColorFlag, "no-color", false, "Disable colored output (also NO_COLOR, TERM=dumb, or when piped)")
+	rootCmd.PersistentFlags().BoolVar(&plainFlag, "plain", false, "Plain text output: no color and no emoji")
+	rootCmd.PersistentFlags().BoolVar(&noInputFlag, "no-input", false, "Never prompt; fail when a required argument is missing")
+	rootCmd.Long = rootLong()
+	defaultHelp := rootCmd.HelpFunc()
+	rootCmd.SetHelpFunc(func(c *cobra.Command, args []string) {
+		configureUI()
+		if c == rootCmd {
+			c.Long = rootLong()
+		}
+		defaultHelp(c, args)
+	})
 }
@@ -0,0 +1,616 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/client"
+	"github.com/dorkitude/simple/internal/filter"
+	"github.com/dorkitude/simple/internal/output"
+	"github.com/dorkitude/simple/internal/templates"
+	"github.com/dorkitude/simple/internal/tui"
+	"github.com/dorkitude/simple/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:     "templates",
+	Aliases: []string{"template"},
+	Short:   "Manage record templates and apply them to domains",
+	Long: `List, view, create, update, and delete record templates, edit their records,
+and apply them to domains.
+
+Templates are referred to by ID or short name (sid). In record content,
+{{domain}} is replaced with the domain the template is applied to.`,
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List templates",
+	Long: `List the account's record templates.
+
+Examples:
+  simple templates list
+  simple templates list --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		where, err := whereFilter(cmd, dnsimple.Template{})
+		if err != nil {
+			return err
+		}
+
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		all, err := listAllTemplates(ctx, app)
+		if err != nil {
+			return err
+		}
+
+		items, err := filter.Select(where, all)
+		if err != nil {
+			return err
+		}
+
+		return output.List(renderer, items, output.View[dnsimple.Template]{
+			Title:   fmt.Sprintf("📐 %d templates", len(items)),
+			Empty:   "No templates found",
+			Columns: templateColumns,
+			Layout:  listLayout(cmd),
+		})
+	},
+}
+
+var templateColumns = []output.Column[dnsimple.Template]{
+	{Name: "id", Header: "ID", Value: func(t dnsimple.Template) string { return strconv.FormatInt(t.ID, 10) }, Style: styleWith(ui.SubtleStyle)},
+	{Name: "sid", Header: "SID", Value: func(t dnsimple.Template) string { return t.SID }, Style: styleWith(ui.AccentStyle)},
+	{Name: "name", Header: "Name", Value: func(t dnsimple.Template) string { return t.Name }},
+	{Name: "description", Header: "Description", Value: func(t dnsimple.Template) string { return t.Description }, Truncate: 50, OmitEmpty: true},
+	{Name: "created_at", Header: "Created", Wide: true, Value: func(t dnsimple.Template) string { return t.CreatedAt }},
+	{Name: "updated_at", Header: "Updated", Wide: true, Value: func(t dnsimple.Template) string { return t.UpdatedAt }},
+}
+
+var templatesGetCmd = &cobra.Command{
+	Use:               "get [template]",
+	Short:             "Get template details",
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeTemplateArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickTemplate)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Templates.GetTemplate(ctx, app.AccountID, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get template: %w", err)
+		}
+
+		return output.Item(renderer, *resp.Data, output.View[dnsimple.Template]{
+			Title:   "📐 " + resp.Data.Name,
+			Columns: templateColumns,
+		})
+	},
+}
+
+var templatesCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a template",
+	Long: `Create an empty record template. Add records with "simple templates records create".
+
+Examples:
+  simple templates create --name "Brand web" --sid brand-web --description "Apex, www and DMARC"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		sid, _ := cmd.Flags().GetString("sid")
+		description, _ := cmd.Flags().GetString("description")
+		if name == "" || sid == "" {
+			return fmt.Errorf("--name and --sid are required")
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Templates.CreateTemplate(ctx, app.AccountID, dnsimple.Template{
+			Name:        name,
+			SID:         sid,
+			Description: description,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create template: %w", err)
+		}
+		forgetCompletions()
+
+		if ok, err := printValue(resp.Data); ok {
+			return err
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("Template '%s' created! (ID: %d)", resp.Data.SID, resp.Data.ID)))
+		return nil
+	},
+}
+
+var templatesUpdateCmd = &cobra.Command{
+	Use:   "update [template]",
+	Short: "Update a template",
+	Long: `Change a template's name, sid or description. Only the flags given are changed.
+
+Examples:
+  simple templates update brand-web --description "Apex, www, DMARC and SPF"`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeTemplateArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickTemplate)
+		if err != nil {
+			return err
+		}
+
+		var attrs dnsimple.Template
+		attrs.Name, _ = cmd.Flags().GetString("name")
+		attrs.SID, _ = cmd.Flags().GetString("sid")
+		attrs.Description, _ = cmd.Flags().GetString("description")
+		if attrs == (dnsimple.Template{}) {
+			return fmt.Errorf("nothing to update; give --name, --sid or --description")
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Templates.UpdateTemplate(ctx, app.AccountID, args[0], attrs)
+		if err != nil {
+			return fmt.Errorf("failed to update template: %w", err)
+		}
+		forgetCompletions()
+
+		if ok, err := printValue(resp.Data); ok {
+			return err
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("Template '%s' updated", resp.Data.SID)))
+		return nil
+	},
+}
+
+var templatesDeleteCmd = &cobra.Command{
+	Use:   "delete [template]",
+	Short: "Delete a template",
+	Long: `PERMANENTLY delete a template and its records. Records it already created in
+zones are kept.
+
+Examples:
+  simple templates delete brand-web`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeTemplateArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickTemplate)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = app.Client.Templates.DeleteTemplate(ctx, app.AccountID, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to delete template: %w", err)
+		}
+		forgetCompletions()
+
+		fmt.Println(ui.Success(fmt.Sprintf("Template '%s' deleted.", args[0])))
+		return nil
+	},
+}
+
+var templateRecordsCmd = &cobra.Command{
+	Use:   "records",
+	Short: "Manage a template's records",
+	Long:  `List, add, and delete the records a template creates.`,
+}
+
+var templateRecordsListCmd = &cobra.Command{
+	Use:   "list [template]",
+	Short: "List a template's records",
+	Long: `List the records a template creates.
+
+Examples:
+  simple templates records list brand-web
+  simple templates records list brand-web --json`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeTemplateArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickTemplate)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		where, err := whereFilter(cmd, dnsimple.TemplateRecord{})
+		if err != nil {
+			return err
+		}
+
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		all, err := listAllTemplateRecords(ctx, app, args[0])
+		if err != nil {
+			return err
+		}
+
+		items, err := filter.Select(where, all)
+		if err != nil {
+			return err
+		}
+
+		return output.List(renderer, items, output.View[dnsimple.TemplateRecord]{
+			Title:   fmt.Sprintf("📐 %d records in template %s", len(items), args[0]),
+			Empty:   "No records in this template",
+			Columns: templateRecordColumns,
+			Layout:  listLayout(cmd),
+		})
+	},
+}
+
+var templateRecordColumns = []output.Column[dnsimple.TemplateRecord]{
+	{Name: "id", Header: "ID", Value: func(r dnsimple.TemplateRecord) string { return strconv.FormatInt(r.ID, 10) }, Style: styleWith(ui.SubtleStyle)},
+	{Name: "type", Header: "Type", Value: func(r dnsimple.TemplateRecord) string { return r.Type }, Style: styleWith(ui.RecordTypeStyle)},
+	{Name: "name", Header: "Name", Value: func(r dnsimple.TemplateRecord) string { return recordName(dnsimple.ZoneRecord{Name: r.Name}) }, Style: styleWith(ui.AccentStyle)},
+	{Name: "ttl", Header: "TTL", Value: func(r dnsimple.TemplateRecord) string { return strconv.Itoa(r.TTL) }},
+	{Name: "content", Header: "Content", Value: func(r dnsimple.TemplateRecord) string { return r.Content }, Truncate: 50},
+	{Name: "priority", Header: "Priority", Value: func(r dnsimple.TemplateRecord) string {
+		return recordPriority(dnsimple.ZoneRecord{Priority: r.Priority})
+	}},
+	{Name: "created_at", Header: "Created", Wide: true, Value: func(r dnsimple.TemplateRecord) string { return r.CreatedAt }},
+}
+
+var templateRecordsCreateCmd = &cobra.Command{
+	Use:   "create [template]",
+	Short: "Add a record to a template",
+	Long: `Add a record to a template. {{domain}} in the content is replaced with the
+domain the template is applied to.
+
+Examples:
+  simple templates records create brand-web --type CNAME --name www --content "{{domain}}"
+  simple templates records create brand-web --type TXT --name _dmarc --content "v=DMARC1; p=quarantine; rua=mailto:dmarc@{{domain}}"
+  simple templates records create google-mail --type MX --name "" --content smtp.google.com --priority 1`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeTemplateArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickTemplate)
+		if err != nil {
+			return err
+		}
+
+		recordType, _ := cmd.Flags().GetString("type")
+		name, _ := cmd.Flags().GetString("name")
+		content, _ := cmd.Flags().GetString("content")
+		ttl, _ := cmd.Flags().GetInt("ttl")
+		priority, _ := cmd.Flags().GetInt("priority")
+		if recordType == "" || content == "" {
+			return fmt.Errorf("--type and --content are required")
+		}
+		if name == "@" {
+			name = ""
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Templates.CreateTemplateRecord(ctx, app.AccountID, args[0], dnsimple.TemplateRecord{
+			Type:     recordType,
+			Name:     name,
+			Content:  content,
+			TTL:      ttl,
+			Priority: priority,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create template record: %w", err)
+		}
+
+		if ok, err := printValue(resp.Data); ok {
+			return err
+		}
+
+		r := resp.Data
+		fmt.Println(ui.Success(fmt.Sprintf("Added %s record '%s' → %s to template '%s' (ID: %d)",
+			r.Type, recordName(dnsimple.ZoneRecord{Name: r.Name}), r.Content, args[0], r.ID)))
+		return nil
+	},
+}
+
+var templateRecordsDeleteCmd = &cobra.Command{
+	Use:   "delete [template] [record-id]",
+	Short: "Delete a record from a template",
+	Long: `PERMANENTLY delete a record from a template. Records the template already
+created in zones are kept.
+
+Examples:
+  simple templates records delete brand-web 301`,
+	Args:              argsOrPick(2),
+	ValidArgsFunction: completeTemplateArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickTemplate, pickTemplateRecord)
+		if err != nil {
+			return err
+		}
+
+		recordID, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid record ID: %w", err)
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = app.Client.Templates.DeleteTemplateRecord(ctx, app.AccountID, args[0], recordID)
+		if err != nil {
+			return fmt.Errorf("failed to delete template record: %w", err)
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("Record %d deleted from template '%s'.", recordID, args[0])))
+		return nil
+	},
+}
+
+var templatesApplyCmd = &cobra.Command{
+	Use:   "apply [template] [domain]",
+	Short: "Apply a template to a domain",
+	Long: `Create a template's records in a domain's zone.
+
+The records are compared with the zone first. Each one is listed as added
+(+), already present (=), or conflicting (!), for example a CNAME where the
+zone has other records. Nothing is applied while there are conflicts. Use
+--dry-run to see the preview without applying.
+
+Examples:
+  simple templates apply brand-web example.com --dry-run
+  simple templates apply brand-web example.com`,
+	Args:              argsOrPick(2),
+	ValidArgsFunction: completeTemplateDomainArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickTemplate, pickDomain)
+		if err != nil {
+			return err
+		}
+		template, domain := args[0], args[1]
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		records, err := listAllTemplateRecords(ctx, app, template)
+		if err != nil {
+			return err
+		}
+		zone, err := listAllRecords(ctx, app, domain)
+		if err != nil {
+			return err
+		}
+		plan := templates.Preview(template, domain, records, zone)
+
+		apply := !dryRun && len(plan.Conflicts) == 0 && len(plan.Add) > 0
+		if apply {
+			if _, err := app.Client.Templates.ApplyTemplate(ctx, app.AccountID, template, domain); err != nil {
+				return fmt.Errorf("failed to apply template: %w", err)
+			}
+			forgetCompletions()
+		}
+
+		if ok, err := printValue(plan); ok {
+			if err == nil && len(plan.Conflicts) > 0 && !dryRun {
+				err = fmt.Errorf("template conflicts with %d records in %s; nothing was applied", len(plan.Conflicts), domain)
+			}
+			return err
+		}
+
+		fmt.Println(ui.Title(fmt.Sprintf("📐 %s on %s", template, domain)))
+		fmt.Println(formatTemplatePlan(plan))
+		switch {
+		case len(plan.Conflicts) > 0 && !dryRun:
+			return fmt.Errorf("template conflicts with %d records in %s; nothing was applied", len(plan.Conflicts), domain)
+		case dryRun:
+			fmt.Println(ui.SubtleStyle.Render("Dry run; nothing was changed."))
+		case !apply:
+			fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("'%s' already has every record in the template; nothing to do.", domain)))
+		default:
+			fmt.Println(ui.Success(fmt.Sprintf("Template '%s' applied to '%s' (%d records added)", template, domain, len(plan.Add))))
+		}
+		return nil
+	},
+}
+
+// formatTemplatePlan lists each record of a template or recipe plan with +
+// (added), = (already in the zone) or ! (conflict, with the reason).
+func formatTemplatePlan(p templates.Plan) string {
+	line := func(mark string, r dnsimple.ZoneRecord) string {
+		s := fmt.Sprintf("%s %-6s %s  %s", mark, r.Type, recordName(r), r.Content)
+		if r.Priority != 0 {
+			s += fmt.Sprintf("  pri %d", r.Priority)
+		}
+		return s
+	}
+	var out string
+	for _, r := range p.Add {
+		out += ui.SuccessStyle.Render(line("+", r)) + "\n"
+	}
+	for _, r := range p.Existing {
+		out += ui.SubtleStyle.Render(line("=", r)) + "\n"
+	}
+	for _, c := range p.Conflicts {
+		out += ui.ErrorStyle.Render(line("!", c.Record)) + ui.SubtleStyle.Render("  ("+c.Reason+")") + "\n"
+	}
+	if out == "" {
+		return ui.SubtleStyle.Render("No records to add.")
+	}
+	return out[:len(out)-1]
+}
+
+// pickTemplateRecord chooses a record in the template picked (or given)
+// before it.
+func pickTemplateRecord(cmd *cobra.Command, args []string) (string, error) {
+	template := args[0]
+	ctx := cmd.Context()
+	app, err := getApp(ctx)
+	if err != nil {
+		return "", err
+	}
+	records, err := listAllTemplateRecords(ctx, app, template)
+	if err != nil {
+		return "", err
+	}
+	if len(records) == 0 {
+		return "", fmt.Errorf("template '%s' has no records", template)
+	}
+	items := make([]tui.PickItem, 0, len(records))
+	for _, r := range records {
+		items = append(items, tui.PickItem{
+			Value:  strconv.FormatInt(r.ID, 10),
+			Label:  fmt.Sprintf("%-6s %s", r.Type, recordName(dnsimple.ZoneRecord{Name: r.Name})),
+			Detail: fmt.Sprintf("%s  #%d", truncateRunes(r.Content, 50), r.ID),
+		})
+	}
+	return tui.Pick("Choose a record in "+template, items)
+}
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+
+	templatesCmd.AddCommand(templatesListCmd)
+	addLayoutFlags(templatesListCmd, output.ColumnNames(templateColumns))
+	addWhereFlag(templatesListCmd)
+
+	templatesCmd.AddCommand(templatesGetCmd)
+
+	templatesCmd.AddCommand(templatesCreateCmd)
+	templatesCreateCmd.Flags().String("name", "", "Template name")
+	templatesCreateCmd.Flags().String("sid", "", "Short name used to refer to the template, e.g. brand-web")
+	templatesCreateCmd.Flags().String("description", "", "Template description")
+
+	templatesCmd.AddCommand(templatesUpdateCmd)
+	templatesUpdateCmd.Flags().String("name", "", "New template name")
+	templatesUpdateCmd.Flags().String("sid", "", "New short name")
+	templatesUpdateCmd.Flags().String("description", "", "New description")
+
+	templatesCmd.AddCommand(templatesDeleteCmd)
+
+	templatesCmd.AddCommand(templateRecordsCmd)
+	templateRecordsCmd.AddCommand(templateRecordsListCmd)
+	addLayoutFlags(templateRecordsListCmd, output.ColumnNames(templateRecordColumns))
+	addWhereFlag(templateRecordsListCmd)
+
+	templateRecordsCmd.AddCommand(templateRecordsCreateCmd)
+	templateRecordsCreateCmd.Flags().StringP("type", "t", "", "Record type (A, AAAA, CNAME, MX, TXT, etc.)")
+	templateRecordsCreateCmd.Flags().StringP("name", "n", "", "Record name (@ or empty for apex)")
+	templateRecordsCreateCmd.Flags().StringP("content", "c", "", "Record content; {{domain}} becomes the domain")
+	templateRecordsCreateCmd.Flags().Int("ttl", 0, "Time to live in seconds")
+	templateRecordsCreateCmd.Flags().Int("priority", 0, "Record priority (for MX, SRV)")
+	_ = templateRecordsCreateCmd.RegisterFlagCompletionFunc("type", completeRecordTypes)
+
+	templateRecordsCmd.AddCommand(templateRecordsDeleteCmd)
+
+	templatesCmd.AddCommand(templatesApplyCmd)
+	templatesApplyCmd.Flags().Bool("dry-run", false, "Show the preview without applying")
+}
+
+func pickTemplate(cmd *cobra.Command, args []string) (string, error) {
+	list, err := cachedTemplates(cmd.Context())
+	if err != nil {
+		return "", err
+	}
+	if len(list) == 0 {
+		return "", fmt.Errorf("no templates found")
+	}
+	items := make([]tui.PickItem, 0, len(list))
+	for _, t := range list {
+		items = append(items, tui.PickItem{Value: t.SID, Label: t.SID, Detail: t.Name})
+	}
+	return tui.Pick("Choose a template", items)
+}
+
+// completeTemplateArg completes the template sid taken as the first argument.
+func completeTemplateArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return withPrefix(templateChoices(cmd), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTemplateDomainArgs completes "[template] [domain]".
+func completeTemplateDomainArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return withPrefix(templateChoices(cmd), toComplete), cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		return completeDomainArg(cmd, nil, toComplete)
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func templateChoices(cmd *cobra.Command) []string {
+	ctx, cancel := completionContext(cmd)
+	defer cancel()
+	list, _ := cachedTemplates(ctx)
+	choices := make([]string, 0, len(list))
+	for _, t := range list {
+		choices = append(choices, t.SID+"\t"+t.Name)
+	}
+	return choices
+}
+
+func cachedTemplates(ctx context.Context) ([]dnsimple.Template, error) {
+	return cachedList(ctx, "templates", listAllTemplates)
+}
+
+// listAllTemplates fetches every page of the account's templates.
+func listAllTemplates(ctx context.Context, app *client.App) ([]dnsimple.Template, error) {
+	return fetchAll(func(opts dnsimple.ListOptions) ([]dnsimple.Template, *dnsimple.Pagination, error) {
+		resp, err := app.Client.Templates.ListTemplates(ctx, app.AccountID, &opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list templates: %w", err)
+		}
+		return resp.Data, resp.Pagination, nil
+	})
+}
+
+// listAllTemplateRecords fetches every page of a template's records.
+func listAllTemplateRecords(ctx context.Context, app *client.App, template string) ([]dnsimple.TemplateRecord, error) {
+	return fetchAll(func(opts dnsimple.ListOptions) ([]dnsimple.TemplateRecord, *dnsimple.Pagination, error) {
+		resp, err := app.Client.Templates.ListTemplateRecords(ctx, app.AccountID, template, &opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list template records: %w", err)
+		}
+		return resp.Data, resp.Pagination, nil
+	})
+}
@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/client"
+	"github.com/dorkitude/simple/internal/output"
+	"github.com/dorkitude/simple/internal/ui"
+	"github.com/dorkitude/simple/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+// minWatchInterval keeps a watch well inside the API rate limit.
+const minWatchInterval = 5 * time.Second
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [zone...]",
+	Short: "Stream record changes as they happen",
+	Long: `Poll zones and print a line whenever a record is added, changed or removed,
+or a zone is activated or deactivated.
+
+The first poll sets the baseline; only later changes are reported. With
+-o ndjson (or --json) each change is one JSON object per line, ready to pipe
+into alerting. --exec runs a shell command per change with the change as JSON
+on stdin and in SIMPLE_CHANGE, plus SIMPLE_CHANGE_KIND, SIMPLE_ZONE,
+SIMPLE_RECORD_ID, SIMPLE_RECORD_TYPE, SIMPLE_RECORD_NAME and
+SIMPLE_RECORD_CONTENT.
+
+Examples:
+  simple watch example.com
+  simple watch example.com example.org --interval 1m
+  simple watch --all -o ndjson | tee -a dns-changes.log
+  simple watch --all --exec 'notify-send "DNS: $SIMPLE_CHANGE_KIND in $SIMPLE_ZONE"'`,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return withPrefix(zoneChoices(cmd), toComplete), cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		hook, _ := cmd.Flags().GetString("exec")
+
+		if all && len(args) > 0 {
+			return fmt.Errorf("give zone names or --all, not both")
+		}
+		if !all && len(args) == 0 {
+			return fmt.Errorf("name at least one zone, or use --all")
+		}
+		if interval < minWatchInterval {
+			return fmt.Errorf("--interval must be at least %s", minWatchInterval)
+		}
+		var stream bool
+		switch renderer.Format.Kind {
+		case output.KindTable:
+		case output.KindNDJSON, output.KindJSON:
+			stream = true
+		default:
+			return fmt.Errorf("watch prints table or ndjson output, not %s", renderer.Format)
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := &zoneWatcher{app: app, zones: args, all: all, snapshots: map[string]watch.Snapshot{}}
+		if _, err := w.poll(ctx); err != nil {
+			return err
+		}
+		if !stream {
+			fmt.Fprintln(os.Stderr, ui.SubtleStyle.Render(fmt.Sprintf("Watching %s every %s. Ctrl-C to stop.", w.describe(), interval)))
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			changes, err := w.poll(ctx)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err != nil {
+				fmt.Fprintln(os.Stderr, ui.Warn(err.Error()))
+			}
+			for _, c := range changes {
+				if stream {
+					if err := json.NewEncoder(os.Stdout).Encode(c); err != nil {
+						return err
+					}
+				} else {
+					fmt.Println(formatChange(c))
+				}
+				if hook != "" {
+					if err := runWatchHook(ctx, hook, c); err != nil {
+						fmt.Fprintln(os.Stderr, ui.Warn(err.Error()))
+					}
+				}
+			}
+		}
+	},
+}
+
+// zoneWatcher keeps the last snapshot of each watched zone.
+type zoneWatcher struct {
+	app       *client.App
+	zones     []string
+	all       bool
+	snapshots map[string]watch.Snapshot
+	baseline  bool
+}
+
+// poll takes a fresh snapshot of every zone and returns the changes since
+// the previous poll. The first poll only records the baseline, and fails
+// outright so a typo in a zone name is caught at once; later failures keep
+// the old snapshot and are reported together.
+func (w *zoneWatcher) poll(ctx context.Context) ([]watch.Change, error) {
+	now := time.Now().UTC()
+	first := !w.baseline
+
+	zones, err := w.currentZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []watch.Change
+	var failed []string
+	seen := map[string]bool{}
+	for _, zone := range zones {
+		seen[zone.Name] = true
+		records, err := listAllRecords(ctx, w.app, zone.Name)
+		if err != nil {
+			if first {
+				return nil, fmt.Errorf("%s: %w", zone.Name, err)
+			}
+			failed = append(failed, zone.Name)
+			continue
+		}
+		next := watch.NewSnapshot(zone, records)
+		prev, known := w.snapshots[zone.Name]
+		w.snapshots[zone.Name] = next
+		switch {
+		case first:
+		case !known:
+			changes = append(changes, watch.Change{Time: now, Kind: watch.ZoneAdded, Zone: zone.Name})
+		default:
+			changes = append(changes, watch.Diff(prev, next, now)...)
+		}
+	}
+	if w.all {
+		for name := range w.snapshots {
+			if !seen[name] {
+				delete(w.snapshots, name)
+				changes = append(changes, watch.Change{Time: now, Kind: watch.ZoneRemoved, Zone: name})
+			}
+		}
+	}
+	w.baseline = true
+
+	if len(failed) > 0 {
+		return changes, fmt.Errorf("could not poll %s; will retry", strings.Join(failed, ", "))
+	}
+	return changes, nil
+}
+
+func (w *zoneWatcher) currentZones(ctx context.Context) ([]dnsimple.Zone, error) {
+	if w.all {
+		return listAllZones(ctx, w.app)
+	}
+	zones := make([]dnsimple.Zone, 0, len(w.zones))
+	for _, name := range w.zones {
+		resp, err := w.app.Client.Zones.GetZone(ctx, w.app.AccountID, name)
+		if err != nil {
+			if !w.baseline {
+				return nil, fmt.Errorf("failed to get zone '%s': %w", name, err)
+			}
+			// Keep watching the zones that still answer.
+			if prev, ok := w.snapshots[name]; ok {
+				zones = append(zones, dnsimple.Zone{Name: name, Active: prev.Active})
+			}
+			continue
+		}
+		zones = append(zones, *resp.Data)
+	}
+	return zones, nil
+}
+
+func (w *zoneWatcher) describe() string {
+	if w.all {
+		return fmt.Sprintf("all %d zones", len(w.snapshots))
+	}
+	if len(w.zones) == 1 {
+		return w.zones[0]
+	}
+	return fmt.Sprintf("%d zones", len(w.zones))
+}
+
+// formatChange renders a change as one human-readable line.
+func formatChange(c watch.Change) string {
+	stamp := ui.SubtleStyle.Render(c.Time.Local().Format("15:04:05"))
+	zone := ui.AccentStyle.Render(c.Zone)
+	switch c.Kind {
+	case watch.ZoneActivated:
+		return fmt.Sprintf("%s %s  %s", stamp, zone, ui.SuccessStyle.Render("zone activated"))
+	case watch.ZoneDeactivated:
+		return fmt.Sprintf("%s %s  %s", stamp, zone, ui.WarningStyle.Render("zone deactivated"))
+	case watch.ZoneAdded:
+		return fmt.Sprintf("%s %s  %s", stamp, zone, ui.SuccessStyle.Render("zone added"))
+	case watch.ZoneRemoved:
+		return fmt.Sprintf("%s %s  %s", stamp, zone, ui.WarningStyle.Render("zone removed"))
+	}
+
+	r := *c.Record
+	what := fmt.Sprintf("%s %s", ui.RecordTypeStyle.Render(r.Type), recordName(r))
+	switch c.Kind {
+	case watch.RecordAdded:
+		return fmt.Sprintf("%s %s  %s %s  %s  ttl %d", stamp, zone, ui.SuccessStyle.Render("+"), what, r.Content, r.TTL)
+	case watch.RecordRemoved:
+		return fmt.Sprintf("%s %s  %s %s  %s", stamp, zone, ui.ErrorStyle.Render("-"), what, r.Content)
+	}
+	parts := make([]string, 0, len(c.Fields))
+	for _, f := range c.Fields {
+		parts = append(parts, c.FieldChange(f))
+	}
+	return fmt.Sprintf("%s %s  %s %s  %s", stamp, zone, ui.WarningStyle.Render("~"), what, strings.Join(parts, ", "))
+}
+
+// runWatchHook runs the --exec command for one change.
+func runWatchHook(ctx context.Context, command string, c watch.Change) error {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	env := []string{
+		"SIMPLE_CHANGE=" + string(payload),
+		"SIMPLE_CHANGE_KIND=" + string(c.Kind),
+		"SIMPLE_ZONE=" + c.Zone,
+	}
+	if c.Record != nil {
+		env = append(env,
+			"SIMPLE_RECORD_ID="+strconv.FormatInt(c.Record.ID, 10),
+			"SIMPLE_RECORD_TYPE="+c.Record.Type,
+			"SIMPLE_RECORD_NAME="+recordName(*c.Record),
+			"SIMPLE_RECORD_CONTENT="+c.Record.Content,
+		)
+	}
+	if err := runHook(ctx, command, payload, env); err != nil {
+		return fmt.Errorf("--exec failed for %s in %s: %w", c.Kind, c.Zone, err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().Bool("all", false, "Watch every zone in the account, including ones added later")
+	watchCmd.Flags().Duration("interval", 30*time.Second, "Time between polls (minimum 5s)")
+	watchCmd.Flags().String("exec", "", "Shell command to run for each change")
+}
@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/filter"
+	"github.com/dorkitude/simple/internal/output"
+	"github.com/dorkitude/simple/internal/ui"
+	"github.com/dorkitude/simple/internal/webhooks"
+	"github.com/spf13/cobra"
+)
+
+var webhooksCmd = &cobra.Command{
+	Use:     "webhooks",
+	Aliases: []string{"webhook"},
+	Short:   "Manage webhooks and receive their events",
+	Long:    `List, view, create, and delete the account's webhooks, or run a local receiver for their events.`,
+}
+
+var webhooksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List webhooks",
+	Long: `List the URLs DNSimple posts account events to.
+
+Examples:
+  simple webhooks list
+  simple webhooks list --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		where, err := whereFilter(cmd, dnsimple.Webhook{})
+		if err != nil {
+			return err
+		}
+
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Webhooks.ListWebhooks(ctx, app.AccountID, nil)
+		if err != nil {
+			return fmt.Errorf("failed to list webhooks: %w", err)
+		}
+
+		items, err := filter.Select(where, resp.Data)
+		if err != nil {
+			return err
+		}
+
+		return output.List(renderer, items, output.View[dnsimple.Webhook]{
+			Title:   fmt.Sprintf("🪝 %d webhooks", len(items)),
+			Empty:   "No webhooks found",
+			Columns: webhookColumns,
+			Layout:  listLayout(cmd),
+		})
+	},
+}
+
+var webhookColumns = []output.Column[dnsimple.Webhook]{
+	{Name: "id", Header: "ID", Value: func(w dnsimple.Webhook) string { return strconv.FormatInt(w.ID, 10) }},
+	{Name: "url", Header: "URL", Value: func(w dnsimple.Webhook) string { return w.URL }, Style: styleWith(ui.AccentStyle)},
+}
+
+var webhooksGetCmd = &cobra.Command{
+	Use:   "get [webhook-id]",
+	Short: "Get webhook details",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		webhookID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid webhook ID: %w", err)
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Webhooks.GetWebhook(ctx, app.AccountID, webhookID)
+		if err != nil {
+			return fmt.Errorf("failed to get webhook: %w", err)
+		}
+
+		return output.Item(renderer, *resp.Data, output.View[dnsimple.Webhook]{
+			Title:   fmt.Sprintf("🪝 Webhook %d", resp.Data.ID),
+			Columns: webhookColumns,
+		})
+	},
+}
+
+var webhooksCreateCmd = &cobra.Command{
+	Use:   "create [url]",
+	Short: "Add a webhook",
+	Long: `Ask DNSimple to POST every event in the account to a URL.
+
+Examples:
+  simple webhooks create https://example.com/dnsimple-events`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkHTTPURL(args[0]); err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		resp, err := app.Client.Webhooks.CreateWebhook(ctx, app.AccountID, dnsimple.Webhook{URL: args[0]})
+		if err != nil {
+			return fmt.Errorf("failed to create webhook: %w", err)
+		}
+
+		if ok, err := printValue(resp.Data); ok {
+			return err
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("Webhook created! (ID: %d)", resp.Data.ID)))
+		return nil
+	},
+}
+
+var webhooksDeleteCmd = &cobra.Command{
+	Use:   "delete [webhook-id]",
+	Short: "Delete a webhook",
+	Long: `PERMANENTLY delete a webhook. DNSimple stops posting events to its URL.
+
+Examples:
+  simple webhooks delete 42`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		webhookID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid webhook ID: %w", err)
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = app.Client.Webhooks.DeleteWebhook(ctx, app.AccountID, webhookID)
+		if err != nil {
+			return fmt.Errorf("failed to delete webhook: %w", err)
+		}
+
+		fmt.Println(ui.Success(fmt.Sprintf("Webhook %d deleted.", webhookID)))
+		return nil
+	},
+}
+
+var webhooksListenCmd = &cobra.Command{
+	Use:   "listen",
+	Short: "Run a local receiver for webhook deliveries",
+	Long: `Accept DNSimple webhook POSTs, print each event and append it to a JSONL file.
+
+Each delivery is checked for a well-formed event name, request identifier,
+API version and data object; anything else is answered with 400. Repeated
+deliveries of the same request are acknowledged but handled once.
+
+DNSimple needs to reach the receiver, so expose it through a tunnel or run it
+on a host with a public address. Deliveries are not signed, so use an
+unguessable --path when the port is reachable by others.
+
+--forward-to POSTs each payload on to another URL. --exec runs a shell command
+per event with the payload on stdin and in SIMPLE_EVENT, plus
+SIMPLE_EVENT_NAME and SIMPLE_REQUEST_ID. With -o ndjson (or --json) events are
+printed as JSON lines instead of a summary.
+
+Examples:
+  simple webhooks listen
+  simple webhooks listen --addr :8080 --path /hooks/3f9c2a
+  simple webhooks listen --forward-to http://localhost:3000/dnsimple
+  simple webhooks listen --exec 'jq -r .name >> names.txt' --log ""`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		path, _ := cmd.Flags().GetString("path")
+		logPath, _ := cmd.Flags().GetString("log")
+		forwardTo, _ := cmd.Flags().GetString("forward-to")
+		hook, _ := cmd.Flags().GetString("exec")
+
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("--path must start with /")
+		}
+		if forwardTo != "" {
+			if err := checkHTTPURL(forwardTo); err != nil {
+				return fmt.Errorf("--forward-to: %w", err)
+			}
+		}
+		var stream bool
+		switch renderer.Format.Kind {
+		case output.KindTable:
+		case output.KindNDJSON, output.KindJSON:
+			stream = true
+		default:
+			return fmt.Errorf("webhooks listen prints table or ndjson output, not %s", renderer.Format)
+		}
+
+		rv := &webhooks.Receiver{
+			ForwardTo: forwardTo,
+			OnError: func(err error) {
+				fmt.Fprintln(os.Stderr, ui.Warn(err.Error()))
+			},
+			OnEvent: func(ctx context.Context, e webhooks.Event) {
+				if stream {
+					var line bytes.Buffer
+					if json.Compact(&line, e.GetPayload()) == nil {
+						fmt.Println(line.String())
+					}
+				} else {
+					fmt.Println(formatWebhookEvent(e))
+				}
+				if hook == "" {
+					return
+				}
+				env := []string{
+					"SIMPLE_EVENT=" + string(e.GetPayload()),
+					"SIMPLE_EVENT_NAME=" + e.Name,
+					"SIMPLE_REQUEST_ID=" + e.RequestID,
+				}
+				if err := runHook(ctx, hook, e.GetPayload(), env); err != nil {
+					fmt.Fprintln(os.Stderr, ui.Warn(fmt.Sprintf("--exec failed for %s: %v", e.Name, err)))
+				}
+			},
+		}
+		if logPath != "" {
+			f, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+			if err != nil {
+				return fmt.Errorf("failed to open event log: %w", err)
+			}
+			defer f.Close()
+			rv.Log = f
+		}
+
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", addr, err)
+		}
+		mux := http.NewServeMux()
+		mux.Handle(path, rv)
+		srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+		fmt.Fprintln(os.Stderr, ui.SubtleStyle.Render(fmt.Sprintf("Listening for webhooks on http://%s%s. Ctrl-C to stop.", ln.Addr(), path)))
+		if logPath != "" {
+			fmt.Fprintln(os.Stderr, ui.SubtleStyle.Render("Appending events to "+logPath))
+		}
+
+		ctx := cmd.Context()
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = srv.Shutdown(shutdownCtx)
+		}()
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("webhook receiver stopped: %w", err)
+		}
+		return nil
+	},
+}
+
+// checkHTTPURL rejects anything but an absolute http(s) URL.
+func checkHTTPURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q is not an http(s) URL", raw)
+	}
+	return nil
+}
+
+// formatWebhookEvent renders an event as one human-readable line.
+func formatWebhookEvent(e webhooks.Event) string {
+	line := fmt.Sprintf("%s %s",
+		ui.SubtleStyle.Render(e.ReceivedAt.Local().Format("15:04:05")),
+		webhookNameStyle(e.Name))
+	if subject := webhooks.Subject(e.Event); subject != "" {
+		line += "  " + subject
+	}
+	if e.Actor != nil && e.Actor.Pretty != "" {
+		line += ui.SubtleStyle.Render("  by " + e.Actor.Pretty)
+	}
+	return line
+}
+
+func webhookNameStyle(name string) string {
+	switch {
+	case strings.HasSuffix(name, ".delete"), strings.HasSuffix(name, ".disable"), strings.HasSuffix(name, "_remove"):
+		return ui.WarningStyle.Render(name)
+	case strings.HasSuffix(name, ".create"), strings.HasSuffix(name, ".register"), strings.HasSuffix(name, ".renew"):
+		return ui.SuccessStyle.Render(name)
+	}
+	return ui.AccentStyle.Render(name)
+}
+
+func init() {
+	rootCmd.AddCommand(webhooksCmd)
+
+	webhooksCmd.AddCommand(webhooksListCmd)
+	addLayoutFlags(webhooksListCmd, output.ColumnNames(webhookColumns))
+	addWhereFlag(webhooksListCmd)
+
+	webhooksCmd.AddCommand(webhooksGetCmd)
+	webhooksCmd.AddCommand(webhooksCreateCmd)
+	webhooksCmd.AddCommand(webhooksDeleteCmd)
+
+	webhooksCmd.AddCommand(webhooksListenCmd)
+	webhooksListenCmd.Flags().String("addr", "127.0.0.1:8080", "Address to listen on, e.g. :8080 for all interfaces")
+	webhooksListenCmd.Flags().String("path", "/", "URL path that accepts deliveries")
+	webhooksListenCmd.Flags().String("log", "webhook-events.jsonl", "JSONL file to append events to (empty to disable)")
+	webhooksListenCmd.Flags().String("forward-to", "", "URL to POST each payload to")
+	webhooksListenCmd.Flags().String("exec", "", "Shell command to run for each event")
+}
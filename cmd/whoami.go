@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/dorkitude/simple/internal/ui"
@@ -13,7 +12,7 @@ var whoamiCmd = &cobra.Command{
 	Short: "Show current identity",
 	Long:  `Display information about the currently authenticated user or account.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		ctx := cmd.Context()
 
 		app, err := getApp(ctx)
 		if err != nil {
@@ -25,11 +24,11 @@ var whoamiCmd = &cobra.Command{
 			return fmt.Errorf("whoami failed: %w", err)
 		}
 
-		if printJSON(resp.Data) {
-			return nil
+		if ok, err := printValue(resp.Data); ok {
+			return err
 		}
 
-		fmt.Println(ui.TitleStyle.Render("🌐 DNSimple Identity"))
+		fmt.Println(ui.Title("🌐 DNSimple Identity"))
 		fmt.Println()
 
 		if resp.Data.Account != nil {
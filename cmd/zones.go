@@ -3,8 +3,13 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/filter"
+	"github.com/dorkitude/simple/internal/nameservers"
+	"github.com/dorkitude/simple/internal/output"
 	"github.com/dorkitude/simple/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -13,7 +18,7 @@ var zonesCmd = &cobra.Command{
 	Use:     "zones",
 	Aliases: []string{"zone"},
 	Short:   "Manage DNS zones",
-	Long:    `List, view, activate, deactivate, and inspect DNS zones.`,
+	Long:    `List, view, activate, deactivate, and inspect DNS zones, and manage their name servers.`,
 }
 
 var zonesListCmd = &cobra.Command{
@@ -24,74 +29,97 @@ var zonesListCmd = &cobra.Command{
 Examples:
   simple zones list
   simple zones list --filter example
-  simple zones list --json`,
+  simple zones list --json
+  simple zones list -o yaml
+  simple zones list --sort-by -active,name
+  simple zones list --where '!active || secondary'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
-		app, err := getApp(ctx)
+		ctx := cmd.Context()
+		where, err := whereFilter(cmd, dnsimple.Zone{})
 		if err != nil {
 			return err
 		}
 
-		filter, _ := cmd.Flags().GetString("filter")
-		page, _ := cmd.Flags().GetInt("page")
-		perPage, _ := cmd.Flags().GetInt("per-page")
-
-		opts := &dnsimple.ZoneListOptions{}
-		if filter != "" {
-			opts.NameLike = dnsimpleString(filter)
-		}
-		if page > 0 {
-			opts.Page = dnsimpleInt(page)
-		}
-		if perPage > 0 {
-			opts.PerPage = dnsimpleInt(perPage)
+		all, err := allPages(cmd)
+		if err != nil {
+			return err
 		}
 
-		resp, err := app.Client.Zones.ListZones(ctx, app.AccountID, opts)
+		app, err := getApp(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to list zones: %w", err)
+			return err
 		}
 
-		if printJSON(resp.Data) {
-			return nil
+		nameFilter, _ := cmd.Flags().GetString("filter")
+		zones, err := listPages(cmd, all, func(lo dnsimple.ListOptions) ([]dnsimple.Zone, *dnsimple.Pagination, error) {
+			opts := &dnsimple.ZoneListOptions{ListOptions: lo}
+			if nameFilter != "" {
+				opts.NameLike = dnsimpleString(nameFilter)
+			}
+			resp, err := app.Client.Zones.ListZones(ctx, app.AccountID, opts)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to list zones: %w", err)
+			}
+			return resp.Data, resp.Pagination, nil
+		})
+		if err != nil {
+			return err
 		}
 
-		if len(resp.Data) == 0 {
-			fmt.Println(ui.Warn("No zones found"))
-			return nil
+		items, err := filter.Select(where, zones)
+		if err != nil {
+			return err
 		}
 
-		fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("🗂️  %d zones", len(resp.Data))))
-		fmt.Println()
-
-		for _, z := range resp.Data {
-			activeMarker := ui.SuccessStyle.Render("●")
-			if !z.Active {
-				activeMarker = ui.SubtleStyle.Render("○")
-			}
+		return output.List(renderer, items, output.View[dnsimple.Zone]{
+			Title:   fmt.Sprintf("🗂️  %d zones", len(items)),
+			Empty:   "No zones found",
+			Columns: zoneListColumns,
+			Layout:  listLayout(cmd),
+		})
+	},
+}
 
-			extra := ""
-			if z.Reverse {
-				extra = ui.SubtleStyle.Render(" (reverse)")
-			}
-			if z.Secondary {
-				extra += ui.SubtleStyle.Render(" (secondary)")
-			}
+var zoneListColumns = []output.Column[dnsimple.Zone]{
+	{Name: "id", Header: "ID", Wide: true, Value: func(z dnsimple.Zone) string { return strconv.FormatInt(z.ID, 10) }},
+	{Name: "active", Header: "Active", Value: func(z dnsimple.Zone) string { return strconv.FormatBool(z.Active) }, Style: zoneActiveMark},
+	{Name: "name", Header: "Name", Value: func(z dnsimple.Zone) string { return z.Name }, Style: styleWith(ui.AccentStyle)},
+	{Name: "reverse", Header: "Reverse", Value: func(z dnsimple.Zone) string { return strconv.FormatBool(z.Reverse) }, Style: boolMark},
+	{Name: "secondary", Header: "Secondary", Value: func(z dnsimple.Zone) string { return strconv.FormatBool(z.Secondary) }, Style: boolMark},
+	{Name: "created_at", Header: "Created", Wide: true, Value: func(z dnsimple.Zone) string { return z.CreatedAt }},
+	{Name: "updated_at", Header: "Updated", Wide: true, Value: func(z dnsimple.Zone) string { return z.UpdatedAt }},
+}
 
-			fmt.Printf("  %s %-30s%s\n", activeMarker, ui.AccentStyle.Render(z.Name), extra)
-		}
+var zoneDetailColumns = []output.Column[dnsimple.Zone]{
+	{Name: "id", Header: "ID", Value: func(z dnsimple.Zone) string { return strconv.FormatInt(z.ID, 10) }},
+	{Name: "name", Header: "Name", Value: func(z dnsimple.Zone) string { return z.Name }},
+	{Name: "active", Header: "Active", Value: func(z dnsimple.Zone) string { return strconv.FormatBool(z.Active) }},
+	{Name: "reverse", Header: "Reverse", Value: func(z dnsimple.Zone) string { return strconv.FormatBool(z.Reverse) }},
+	{Name: "secondary", Header: "Secondary", Value: func(z dnsimple.Zone) string { return strconv.FormatBool(z.Secondary) }},
+	{Name: "created_at", Header: "Created", Value: func(z dnsimple.Zone) string { return z.CreatedAt }},
+	{Name: "updated_at", Header: "Updated", Value: func(z dnsimple.Zone) string { return z.UpdatedAt }},
+}
 
-		return nil
-	},
+func zoneActiveMark(cell string) string {
+	if cell == "true" {
+		return ui.SuccessStyle.Render("●")
+	}
+	return ui.SubtleStyle.Render("○")
 }
 
 var zonesGetCmd = &cobra.Command{
-	Use:   "get [zone]",
-	Short: "Get zone details",
-	Long:  `Display detailed information about a specific zone.`,
-	Args:  cobra.ExactArgs(1),
+	Use:               "get [zone]",
+	Short:             "Get zone details",
+	Long:              `Display detailed information about a specific zone.`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeZoneArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		args, err := pickArgs(cmd, args, pickZone)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
 		app, err := getApp(ctx)
 		if err != nil {
 			return err
@@ -102,22 +130,10 @@ var zonesGetCmd = &cobra.Command{
 			return fmt.Errorf("failed to get zone: %w", err)
 		}
 
-		if printJSON(resp.Data) {
-			return nil
-		}
-
-		z := resp.Data
-		fmt.Println(ui.TitleStyle.Render("🗂️  " + z.Name))
-		fmt.Println()
-		fmt.Printf("  %-14s %d\n", "ID:", z.ID)
-		fmt.Printf("  %-14s %s\n", "Name:", z.Name)
-		fmt.Printf("  %-14s %v\n", "Active:", z.Active)
-		fmt.Printf("  %-14s %v\n", "Reverse:", z.Reverse)
-		fmt.Printf("  %-14s %v\n", "Secondary:", z.Secondary)
-		fmt.Printf("  %-14s %s\n", "Created:", z.CreatedAt)
-		fmt.Printf("  %-14s %s\n", "Updated:", z.UpdatedAt)
-
-		return nil
+		return output.Item(renderer, *resp.Data, output.View[dnsimple.Zone]{
+			Title:   "🗂️  " + resp.Data.Name,
+			Columns: zoneDetailColumns,
+		})
 	},
 }
 
@@ -128,9 +144,15 @@ var zonesFileCmd = &cobra.Command{
 
 Examples:
   simple zones file example.com`,
-	Args: cobra.ExactArgs(1),
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeZoneArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		args, err := pickArgs(cmd, args, pickZone)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
 		app, err := getApp(ctx)
 		if err != nil {
 			return err
@@ -141,11 +163,11 @@ Examples:
 			return fmt.Errorf("failed to get zone file: %w", err)
 		}
 
-		if printJSON(resp.Data) {
-			return nil
+		if ok, err := printValue(resp.Data); ok {
+			return err
 		}
 
-		fmt.Println(ui.TitleStyle.Render("📄 Zone file: " + args[0]))
+		fmt.Println(ui.Title("📄 Zone file: " + args[0]))
 		fmt.Println()
 		fmt.Println(resp.Data.Zone)
 
@@ -160,9 +182,15 @@ var zonesDistributionCmd = &cobra.Command{
 
 Examples:
   simple zones distribution example.com`,
-	Args: cobra.ExactArgs(1),
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeZoneArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		args, err := pickArgs(cmd, args, pickZone)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
 		app, err := getApp(ctx)
 		if err != nil {
 			return err
@@ -173,8 +201,8 @@ Examples:
 			return fmt.Errorf("failed to check distribution: %w", err)
 		}
 
-		if printJSON(resp.Data) {
-			return nil
+		if ok, err := printValue(resp.Data); ok {
+			return err
 		}
 
 		if resp.Data.Distributed {
@@ -188,12 +216,18 @@ Examples:
 }
 
 var zonesActivateCmd = &cobra.Command{
-	Use:   "activate [zone]",
-	Short: "Activate DNS for a zone",
-	Long:  `Activate DNS services for a zone.`,
-	Args:  cobra.ExactArgs(1),
+	Use:               "activate [zone]",
+	Short:             "Activate DNS for a zone",
+	Long:              `Activate DNS services for a zone.`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeZoneArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		args, err := pickArgs(cmd, args, pickZone)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
 		app, err := getApp(ctx)
 		if err != nil {
 			return err
@@ -203,6 +237,7 @@ var zonesActivateCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to activate zone: %w", err)
 		}
+		forgetCompletions()
 
 		fmt.Println(ui.Success(fmt.Sprintf("DNS activated for zone '%s'", args[0])))
 		return nil
@@ -210,12 +245,18 @@ var zonesActivateCmd = &cobra.Command{
 }
 
 var zonesDeactivateCmd = &cobra.Command{
-	Use:   "deactivate [zone]",
-	Short: "Deactivate DNS for a zone",
-	Long:  `Deactivate DNS services for a zone.`,
-	Args:  cobra.ExactArgs(1),
+	Use:               "deactivate [zone]",
+	Short:             "Deactivate DNS for a zone",
+	Long:              `Deactivate DNS services for a zone.`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeZoneArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx := context.Background()
+		args, err := pickArgs(cmd, args, pickZone)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
 		app, err := getApp(ctx)
 		if err != nil {
 			return err
@@ -225,23 +266,188 @@ var zonesDeactivateCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("failed to deactivate zone: %w", err)
 		}
+		forgetCompletions()
 
 		fmt.Println(ui.Success(fmt.Sprintf("DNS deactivated for zone '%s'", args[0])))
 		return nil
 	},
 }
 
+var zonesNSCmd = &cobra.Command{
+	Use:   "ns [zone]",
+	Short: "Show a zone's name servers",
+	Long: `Show the NS records at the apex of a zone.
+
+Examples:
+  simple zones ns example.com
+  simple zones ns set example.com ns1.example.net ns2.example.net`,
+	Args:              argsOrPick(1),
+	ValidArgsFunction: completeZoneArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		args, err := pickArgs(cmd, args, pickZone)
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		records, err := nameservers.List(ctx, app.Client, app.AccountID, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to list name servers: %w", err)
+		}
+
+		return output.List(renderer, records, output.View[dnsimple.ZoneRecord]{
+			Title:   fmt.Sprintf("🧭 %d name servers for %s", len(records), args[0]),
+			Empty:   "No NS records at the zone apex",
+			Columns: nsColumns,
+			Layout:  listLayout(cmd),
+		})
+	},
+}
+
+var nsColumns = []output.Column[dnsimple.ZoneRecord]{
+	{Name: "id", Header: "ID", Value: func(r dnsimple.ZoneRecord) string { return strconv.FormatInt(r.ID, 10) }},
+	{Name: "name_server", Header: "Name Server", Value: func(r dnsimple.ZoneRecord) string { return r.Content }, Style: styleWith(ui.AccentStyle)},
+	{Name: "ttl", Header: "TTL", Value: func(r dnsimple.ZoneRecord) string { return strconv.Itoa(r.TTL) }},
+	{Name: "system_record", Header: "System", Value: func(r dnsimple.ZoneRecord) string { return strconv.FormatBool(r.SystemRecord) }, Style: boolMark},
+}
+
+var zonesNSSetCmd = &cobra.Command{
+	Use:   "set [zone] [name-server...]",
+	Short: "Replace a zone's name servers",
+	Long: `Replace the NS records at the apex of a zone with the given name servers.
+
+The names are checked before anything is sent, and the change is shown as a
+before/after diff and confirmed before it is applied. Use --dry-run to see the
+diff without applying it, and --yes to skip the confirmation (required when
+not running in a terminal).
+
+Examples:
+  simple zones ns set example.com ns1.dnsimple.com ns2.dnsimple-edge.net ns3.dnsimple.com ns4.dnsimple-edge.org
+  simple zones ns set example.com ns1.dr.example.net ns2.dr.example.net --dry-run
+  simple zones ns set example.com ns1.dr.example.net ns2.dr.example.net --yes`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeZoneArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		zone := args[0]
+		names, err := nameservers.Normalize(args[1:])
+		if err != nil {
+			return err
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		ctx := cmd.Context()
+		app, err := getApp(ctx)
+		if err != nil {
+			return err
+		}
+
+		current, err := nameservers.List(ctx, app.Client, app.AccountID, zone)
+		if err != nil {
+			return fmt.Errorf("failed to list name servers: %w", err)
+		}
+		change := nameservers.NewChange(zone, nameservers.FromRecords(current), names)
+
+		structured := structuredOutput()
+		if !structured {
+			fmt.Println(formatNSChange(change))
+		}
+		if change.Empty() || dryRun {
+			if structured {
+				_, err := printValue(change)
+				return err
+			}
+			if dryRun {
+				fmt.Println(ui.SubtleStyle.Render("Dry run; nothing was changed."))
+			} else {
+				fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Name servers for '%s' already match; nothing to do.", zone)))
+			}
+			return nil
+		}
+
+		if !yes {
+			if !canPrompt() {
+				return fmt.Errorf("refusing to change %s without confirmation; pass --yes", zone)
+			}
+			ok, err := confirmPrompt(fmt.Sprintf("Replace the name servers of %s?", zone))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return context.Canceled
+			}
+		}
+
+		if _, err := nameservers.Update(ctx, app.Client, app.AccountID, zone, names); err != nil {
+			return fmt.Errorf("failed to update name servers: %w", err)
+		}
+		forgetCompletions()
+
+		if ok, err := printValue(change); ok {
+			return err
+		}
+		fmt.Println(ui.Success(fmt.Sprintf("Name servers updated for zone '%s'", zone)))
+		return nil
+	},
+}
+
+// formatNSChange lists the old NS set followed by the new one, marking
+// removed names with - and added ones with +.
+func formatNSChange(c nameservers.Change) string {
+	removed := map[string]bool{}
+	for _, n := range c.Removed {
+		removed[n] = true
+	}
+	added := map[string]bool{}
+	for _, n := range c.Added {
+		added[n] = true
+	}
+	lines := []string{ui.SubtleStyle.Render("Before:")}
+	for _, n := range c.Before {
+		if removed[n] {
+			lines = append(lines, ui.ErrorStyle.Render("  - "+n))
+		} else {
+			lines = append(lines, "    "+n)
+		}
+	}
+	if len(c.Before) == 0 {
+		lines = append(lines, ui.SubtleStyle.Render("    (none)"))
+	}
+	lines = append(lines, ui.SubtleStyle.Render("After:"))
+	for _, n := range c.After {
+		if added[n] {
+			lines = append(lines, ui.SuccessStyle.Render("  + "+n))
+		} else {
+			lines = append(lines, "    "+n)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 func init() {
 	rootCmd.AddCommand(zonesCmd)
 
 	zonesCmd.AddCommand(zonesListCmd)
 	zonesListCmd.Flags().StringP("filter", "f", "", "Filter zones by name")
-	zonesListCmd.Flags().Int("page", 0, "Page number")
-	zonesListCmd.Flags().Int("per-page", 0, "Results per page")
+	zonesListCmd.Flags().Int("page", 0, "Page number (not with --where, --sort-by or --group-by)")
+	zonesListCmd.Flags().Int("per-page", 0, "Results per page (not with --where, --sort-by or --group-by)")
+	addLayoutFlags(zonesListCmd, output.ColumnNames(zoneListColumns))
+	addWhereFlag(zonesListCmd)
 
 	zonesCmd.AddCommand(zonesGetCmd)
 	zonesCmd.AddCommand(zonesFileCmd)
 	zonesCmd.AddCommand(zonesDistributionCmd)
 	zonesCmd.AddCommand(zonesActivateCmd)
 	zonesCmd.AddCommand(zonesDeactivateCmd)
+
+	zonesCmd.AddCommand(zonesNSCmd)
+	addLayoutFlags(zonesNSCmd, output.ColumnNames(nsColumns))
+	zonesNSCmd.AddCommand(zonesNSSetCmd)
+	zonesNSSetCmd.Flags().Bool("dry-run", false, "Show the change without applying it")
+	zonesNSSetCmd.Flags().BoolP("yes", "y", false, "Apply without asking for confirmation")
 }
@@ -0,0 +1,123 @@
+// Package cache keeps short-lived JSON snapshots of API listings on disk,
+// so shell completion can answer without an API call on every tab press.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dorkitude/simple/internal/config"
+)
+
+// DefaultTTL is how long an entry stays fresh.
+const DefaultTTL = 2 * time.Minute
+
+// Store reads and writes entries under Dir. Entries older than TTL are
+// treated as missing.
+type Store struct {
+	Dir string
+	TTL time.Duration
+
+	now func() time.Time
+}
+
+type entry struct {
+	Key     string          `json:"key"`
+	SavedAt time.Time       `json:"saved_at"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Default returns the store in the config directory's cache folder.
+func Default() (*Store, error) {
+	dir, err := config.ResolveConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Dir: filepath.Join(dir, "cache"), TTL: DefaultTTL}, nil
+}
+
+// Get decodes a fresh entry for key into v and reports whether one existed.
+func (s *Store) Get(key string, v interface{}) bool {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return false
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil || e.Key != key {
+		return false
+	}
+	if s.clock().Sub(e.SavedAt) > s.ttl() {
+		return false
+	}
+	return json.Unmarshal(e.Data, v) == nil
+}
+
+// Put stores v under key.
+func (s *Store) Put(key string, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	data, err := json.Marshal(entry{Key: key, SavedAt: s.clock(), Data: raw})
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	// Write then rename, so a completion running in parallel never reads a
+	// half-written file.
+	tmp, err := os.CreateTemp(s.Dir, ".entry-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// Clear removes every entry.
+func (s *Store) Clear() error {
+	if err := os.RemoveAll(s.Dir); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
+
+// path hashes the key, so keys may hold zone names and other characters
+// that are awkward in file names.
+func (s *Store) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.Dir, hex.EncodeToString(sum[:12])+".json")
+}
+
+func (s *Store) clock() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+func (s *Store) ttl() time.Duration {
+	if s.TTL > 0 {
+		return s.TTL
+	}
+	return DefaultTTL
+}
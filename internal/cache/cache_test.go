@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStoreExpiresEntries(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := &Store{Dir: t.TempDir(), TTL: time.Minute, now: func() time.Time { return now }}
+
+	var got []string
+	if s.Get("zones", &got) {
+		t.Fatal("Get on an empty store reported a hit")
+	}
+	if err := s.Put("zones", []string{"example.com", "example.org"}); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Get("zones", &got) || len(got) != 2 || got[1] != "example.org" {
+		t.Fatalf("Get = %v", got)
+	}
+	if s.Get("records:example.com", &got) {
+		t.Error("Get returned an entry for a different key")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if s.Get("zones", &got) {
+		t.Error("Get returned an expired entry")
+	}
+
+	if err := s.Clear(); err != nil {
+		t.Fatal(err)
+	}
+}
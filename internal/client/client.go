@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/dnsimple/dnsimple-go/dnsimple"
 	"github.com/dorkitude/simple/internal/config"
@@ -33,24 +34,79 @@ func NewFromFlags(ctx context.Context, accountOverride string, sandbox bool) (*A
 	return newFromToken(ctx, token, accountOverride, sandbox)
 }
 
-func newFromToken(ctx context.Context, token, accountOverride string, sandbox bool) (*App, error) {
-	tc := dnsimple.StaticTokenHTTPClient(ctx, token)
-	c := dnsimple.NewClient(tc)
-	c.SetUserAgent("dnsimplectl")
+// NewFromToken creates an App for token rather than the stored credentials,
+// e.g. for a token that was just entered and is not saved yet.
+func NewFromToken(ctx context.Context, token string, sandbox bool) (*App, error) {
+	return newFromToken(ctx, token, "", sandbox)
+}
 
-	if sandbox {
+// MultipleAccountsError is returned when a user token can reach several
+// accounts and none was chosen.
+type MultipleAccountsError struct {
+	Accounts []dnsimple.Account
+}
+
+func (e *MultipleAccountsError) Error() string {
+	names := make([]string, 0, len(e.Accounts))
+	for _, a := range e.Accounts {
+		names = append(names, fmt.Sprintf("%d (%s)", a.ID, a.Email))
+	}
+	return fmt.Sprintf("this token can access %d accounts: %s — choose one with 'simple accounts use <id|email>', --account or DNSIMPLE_ACCOUNT",
+		len(e.Accounts), strings.Join(names, ", "))
+}
+
+// NewAPIClient returns a DNSimple client for the stored token without
+// resolving an account, for calls such as listing accounts.
+func NewAPIClient(sandbox bool) (*dnsimple.Client, error) {
+	token, err := config.LoadToken()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+	return apiClient(token, cfg, sandbox), nil
+}
+
+// ListAccounts returns the accounts the stored token can access.
+func ListAccounts(ctx context.Context, sandbox bool) ([]dnsimple.Account, error) {
+	c, err := NewAPIClient(sandbox)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Accounts.ListAccounts(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	return resp.Data, nil
+}
+
+func apiClient(token string, cfg *config.Config, sandbox bool) *dnsimple.Client {
+	c := dnsimple.NewClient(newHTTPClient(token, cfg.Timeout()))
+	c.SetUserAgent("dnsimplectl")
+	profile, _ := cfg.Current()
+	if sandbox || profile.IsSandbox() {
 		c.BaseURL = "https://api.sandbox.dnsimple.com"
 	}
+	return c
+}
 
-	// Resolve account ID
-	accountID := accountOverride
-	if accountID == "" {
-		cfg, _ := config.Load()
-		if cfg != nil && cfg.AccountID != "" {
-			accountID = cfg.AccountID
-		}
+func newFromToken(ctx context.Context, token, accountOverride string, sandbox bool) (*App, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+	profile, err := cfg.Current()
+	if err != nil {
+		return nil, err
 	}
 
+	c := apiClient(token, cfg, sandbox)
+
+	// Resolve account ID: --account, DNSIMPLE_ACCOUNT, then the profile
+	accountID, _ := cfg.AccountID(accountOverride)
+
 	if accountID == "" {
 		// Look it up via Whoami
 		whoami, err := c.Identity.Whoami(ctx)
@@ -60,31 +116,29 @@ func newFromToken(ctx context.Context, token, accountOverride string, sandbox bo
 		if whoami.Data.Account != nil {
 			accountID = strconv.FormatInt(whoami.Data.Account.ID, 10)
 		} else if whoami.Data.User != nil {
-			// User token — need to list accounts and pick one
+			// User token — only an unambiguous account can be picked for the user
 			accts, err := c.Accounts.ListAccounts(ctx, nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed to list accounts: %w", err)
 			}
-			if len(accts.Data) == 0 {
+			switch len(accts.Data) {
+			case 0:
 				return nil, fmt.Errorf("no accounts found for this user")
-			}
-			if len(accts.Data) == 1 {
-				accountID = strconv.FormatInt(accts.Data[0].ID, 10)
-			} else {
-				// For now, use the first account. TUI can let user pick later.
+			case 1:
 				accountID = strconv.FormatInt(accts.Data[0].ID, 10)
+			default:
+				return nil, &MultipleAccountsError{Accounts: accts.Data}
 			}
 		} else {
 			return nil, fmt.Errorf("whoami returned neither account nor user")
 		}
 
-		// Cache it
-		cfg, _ := config.Load()
-		if cfg == nil {
-			cfg = &config.Config{}
+		// Cache it on the profile in effect, unless the token came from
+		// the environment and may not belong to this profile.
+		if config.TokenSource() != config.SourceEnv {
+			profile.AccountID = accountID
+			_ = config.Save(cfg)
 		}
-		cfg.AccountID = accountID
-		_ = config.Save(cfg)
 	}
 
 	return &App{Client: c, AccountID: accountID}, nil
@@ -92,8 +146,8 @@ func newFromToken(ctx context.Context, token, accountOverride string, sandbox bo
 
 // ValidateToken checks if a token is valid by calling Whoami.
 func ValidateToken(ctx context.Context, token string, sandbox bool) (*dnsimple.WhoamiData, error) {
-	tc := dnsimple.StaticTokenHTTPClient(ctx, token)
-	c := dnsimple.NewClient(tc)
+	cfg, _ := config.Load()
+	c := dnsimple.NewClient(newHTTPClient(token, cfg.Timeout()))
 	if sandbox {
 		c.BaseURL = "https://api.sandbox.dnsimple.com"
 	}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// redirectTransport sends every request to a test server instead of the
+// DNSimple API.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// fakeUserAPI answers whoami for a user token that can reach accounts.
+func fakeUserAPI(t *testing.T, accounts ...int64) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/whoami", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"user": map[string]any{"id": 1, "email": "ops@example.com"}},
+		})
+	})
+	mux.HandleFunc("GET /v2/accounts", func(w http.ResponseWriter, r *http.Request) {
+		data := make([]map[string]any, 0, len(accounts))
+		for _, id := range accounts {
+			data = append(data, map[string]any{"id": id, "email": "ops@example.com"})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	target, _ := url.Parse(srv.URL)
+	prev := sharedTransport
+	sharedTransport = redirectTransport{target: target}
+	t.Cleanup(func() { sharedTransport = prev })
+
+	t.Setenv("DNSIMPLE_CONFIG_DIR", t.TempDir())
+	t.Setenv("DNSIMPLE_ACCOUNT", "")
+	t.Setenv("DNSIMPLE_TOKEN", "")
+	t.Setenv("SIMPLE_PROFILE", "")
+}
+
+func TestNewFromTokenWithSeveralAccounts(t *testing.T) {
+	fakeUserAPI(t, 1010, 2020)
+
+	_, err := newFromToken(context.Background(), "user-token", "", false)
+	var multi *MultipleAccountsError
+	if !errors.As(err, &multi) {
+		t.Fatalf("err = %v, want MultipleAccountsError", err)
+	}
+	if len(multi.Accounts) != 2 || multi.Accounts[1].ID != 2020 {
+		t.Errorf("accounts = %+v", multi.Accounts)
+	}
+
+	app, err := newFromToken(context.Background(), "user-token", "2020", false)
+	if err != nil || app.AccountID != "2020" {
+		t.Errorf("with --account: %+v, %v", app, err)
+	}
+}
+
+func TestNewFromTokenWithOneAccount(t *testing.T) {
+	fakeUserAPI(t, 1010)
+
+	app, err := newFromToken(context.Background(), "user-token", "", false)
+	if err != nil || app.AccountID != "1010" {
+		t.Fatalf("app = %+v, %v", app, err)
+	}
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+const (
+	DefaultAuthorizeURL        = "https://dnsimple.com/oauth/authorize"
+	DefaultSandboxAuthorizeURL = "https://sandbox.dnsimple.com/oauth/authorize"
+	DefaultTokenBaseURL        = "https://api.dnsimple.com"
+	DefaultSandboxTokenBaseURL = "https://api.sandbox.dnsimple.com"
+
+	oauthCallbackPath = "/callback"
+)
+
+// OAuthConfig describes one authorization-code login.
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+
+	// AuthorizeURL is the full authorize endpoint the user is sent to.
+	AuthorizeURL string
+	// TokenBaseURL is the API base the code is exchanged against; the
+	// request goes to <TokenBaseURL>/v2/oauth/access_token.
+	TokenBaseURL string
+
+	// ListenAddr is where the callback server listens. Defaults to an
+	// ephemeral port on 127.0.0.1; the redirect URI sent is
+	// http://<ListenAddr>/callback, so pin the port when the application
+	// requires an exact redirect URI match.
+	ListenAddr string
+	Timeout    time.Duration
+}
+
+// OAuthLogin runs the authorization-code flow: it starts a callback server on
+// localhost, hands the authorize URL to open, waits for the redirect, checks
+// state and exchanges the code for an access token.
+func OAuthLogin(ctx context.Context, cfg OAuthConfig, open func(authorizeURL string)) (*dnsimple.AccessToken, error) {
+	if cfg.ClientID == "" {
+		return nil, fmt.Errorf("client ID is required")
+	}
+	if cfg.ClientSecret == "" {
+		return nil, fmt.Errorf("client secret is required")
+	}
+	if cfg.ListenAddr == "" {
+		cfg.ListenAddr = "127.0.0.1:0"
+	}
+
+	state, err := randomState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	ln, err := net.Listen("tcp", cfg.ListenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start callback listener: %w", err)
+	}
+	redirectURI := "http://" + ln.Addr().String() + oauthCallbackPath
+
+	authorizeURL, err := buildAuthorizeURL(cfg.AuthorizeURL, cfg.ClientID, redirectURI, state)
+	if err != nil {
+		ln.Close()
+		return nil, err
+	}
+
+	type callback struct {
+		code string
+		err  error
+	}
+	results := make(chan callback, 1)
+	deliver := func(c callback) {
+		select {
+		case results <- c:
+		default:
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(oauthCallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		// A request without our state is not the redirect we are waiting
+		// for, so turn it away and keep listening.
+		if subtle.ConstantTimeCompare([]byte(q.Get("state")), []byte(state)) != 1 {
+			writeCallbackPage(w, http.StatusBadRequest, "State mismatch. This is not the login in progress.")
+			return
+		}
+		if e := q.Get("error"); e != "" {
+			writeCallbackPage(w, http.StatusBadRequest, "Authorization failed: "+e)
+			deliver(callback{err: fmt.Errorf("authorization failed: %s %s", e, q.Get("error_description"))})
+			return
+		}
+		code := q.Get("code")
+		if code == "" {
+			writeCallbackPage(w, http.StatusBadRequest, "No authorization code in the callback.")
+			deliver(callback{err: fmt.Errorf("oauth callback had no code")})
+			return
+		}
+		writeCallbackPage(w, http.StatusOK, "Authorized. You can close this window and return to the terminal.")
+		deliver(callback{code: code})
+	})
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	if open != nil {
+		open(authorizeURL)
+	}
+
+	var got callback
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case got = <-results:
+	}
+	if got.err != nil {
+		return nil, got.err
+	}
+
+	return exchangeCode(cfg, got.code, redirectURI, state)
+}
+
+func exchangeCode(cfg OAuthConfig, code, redirectURI, state string) (*dnsimple.AccessToken, error) {
+	c := dnsimple.NewClient(&http.Client{Transport: sharedTransport, Timeout: cfg.Timeout})
+	c.SetUserAgent("dnsimplectl")
+	if cfg.TokenBaseURL != "" {
+		c.BaseURL = cfg.TokenBaseURL
+	}
+
+	token, err := c.Oauth.ExchangeAuthorizationForToken(&dnsimple.ExchangeAuthorizationRequest{
+		Code:         code,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURI:  redirectURI,
+		State:        state,
+		GrantType:    dnsimple.AuthorizationCodeGrant,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	if token.Token == "" {
+		return nil, errors.New("token exchange returned no access token")
+	}
+	return token, nil
+}
+
+func buildAuthorizeURL(base, clientID, redirectURI, state string) (string, error) {
+	if base == "" {
+		base = DefaultAuthorizeURL
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorize URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("client_id", clientID)
+	q.Set("response_type", "code")
+	q.Set("redirect_uri", redirectURI)
+	q.Set("state", state)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func writeCallbackPage(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<!doctype html><title>simple</title><p>%s</p>\n", html.EscapeString(message))
+}
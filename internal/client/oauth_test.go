@@ -0,0 +1,178 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeOAuth stands in for DNSimple's authorize page and token endpoint. The
+// authorize page approves immediately by redirecting to redirect_uri.
+type fakeOAuth struct {
+	*httptest.Server
+	code      string
+	exchanged map[string]string
+}
+
+func newFakeOAuth(t *testing.T) *fakeOAuth {
+	t.Helper()
+	f := &fakeOAuth{code: "code-123"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("client_id") != "cid" || q.Get("response_type") != "code" {
+			http.Error(w, "bad authorize request", http.StatusBadRequest)
+			return
+		}
+		back, err := url.Parse(q.Get("redirect_uri"))
+		if err != nil {
+			http.Error(w, "bad redirect_uri", http.StatusBadRequest)
+			return
+		}
+		bq := back.Query()
+		bq.Set("code", f.code)
+		bq.Set("state", q.Get("state"))
+		back.RawQuery = bq.Encode()
+		http.Redirect(w, r, back.String(), http.StatusFound)
+	})
+	mux.HandleFunc("POST /v2/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&f.exchanged); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if f.exchanged["code"] != f.code || f.exchanged["client_secret"] != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "oauth-token",
+			"token_type":   "bearer",
+			"account_id":   1010,
+		})
+	})
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeOAuth) config() OAuthConfig {
+	return OAuthConfig{
+		ClientID:     "cid",
+		ClientSecret: "secret",
+		AuthorizeURL: f.URL + "/oauth/authorize",
+		TokenBaseURL: f.URL,
+		Timeout:      5 * time.Second,
+	}
+}
+
+// visit plays the browser: it follows the authorize URL through to the
+// callback server.
+func visit(t *testing.T) func(string) {
+	return func(u string) {
+		go func() {
+			resp, err := http.Get(u)
+			if err != nil {
+				t.Errorf("visit %s: %v", u, err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+}
+
+func TestOAuthLogin(t *testing.T) {
+	f := newFakeOAuth(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	token, err := OAuthLogin(ctx, f.config(), visit(t))
+	if err != nil {
+		t.Fatalf("OAuthLogin: %v", err)
+	}
+	if token.Token != "oauth-token" || token.AccountID != 1010 {
+		t.Fatalf("got token %+v", token)
+	}
+	if f.exchanged["grant_type"] != "authorization_code" || f.exchanged["state"] == "" {
+		t.Errorf("exchange request = %v", f.exchanged)
+	}
+	if !strings.HasPrefix(f.exchanged["redirect_uri"], "http://127.0.0.1:") {
+		t.Errorf("redirect_uri = %q, want localhost callback", f.exchanged["redirect_uri"])
+	}
+}
+
+func TestOAuthLoginIgnoresStateMismatch(t *testing.T) {
+	f := newFakeOAuth(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// A stray callback with the wrong state is turned away; the real one
+	// that follows still completes the login.
+	forgeThenVisit := func(u string) {
+		parsed, _ := url.Parse(u)
+		back, _ := url.Parse(parsed.Query().Get("redirect_uri"))
+		q := back.Query()
+		q.Set("code", "forged-code")
+		q.Set("state", "forged")
+		back.RawQuery = q.Encode()
+		resp, err := http.Get(back.String())
+		if err != nil {
+			t.Errorf("forged callback: %v", err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("forged callback = %s, want 400", resp.Status)
+		}
+		visit(t)(u)
+	}
+
+	token, err := OAuthLogin(ctx, f.config(), forgeThenVisit)
+	if err != nil {
+		t.Fatalf("OAuthLogin: %v", err)
+	}
+	if token.Token != "oauth-token" || f.exchanged["code"] != f.code {
+		t.Errorf("token %+v, exchanged %v", token, f.exchanged)
+	}
+}
+
+func TestOAuthLoginListenAddr(t *testing.T) {
+	f := newFakeOAuth(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	cfg := f.config()
+	cfg.ListenAddr = addr
+	if _, err := OAuthLogin(ctx, cfg, visit(t)); err != nil {
+		t.Fatalf("OAuthLogin: %v", err)
+	}
+	if want := "http://" + addr + "/callback"; f.exchanged["redirect_uri"] != want {
+		t.Errorf("redirect_uri = %q, want %q", f.exchanged["redirect_uri"], want)
+	}
+}
+
+func TestOAuthLoginExchangeError(t *testing.T) {
+	f := newFakeOAuth(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cfg := f.config()
+	cfg.ClientSecret = "wrong"
+	if _, err := OAuthLogin(ctx, cfg, visit(t)); err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Fatalf("want invalid_grant error, got %v", err)
+	}
+}
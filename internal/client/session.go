@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+// sharedTransport is reused by every client this package builds so API calls
+// share a pool of keep-alive connections instead of dialing per request.
+var sharedTransport http.RoundTripper = newSharedTransport()
+
+func newSharedTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = 32
+	t.MaxIdleConnsPerHost = 16
+	t.IdleConnTimeout = 90 * time.Second
+	return t
+}
+
+// tokenTransport adds the bearer token to each request.
+type tokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(r)
+}
+
+func newHTTPClient(token string, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: &tokenTransport{token: token, base: sharedTransport},
+		Timeout:   timeout,
+	}
+}
+
+// Session caches one authenticated App and hands it to every caller until it
+// is invalidated. It is safe for concurrent use; concurrent callers that find
+// the cache empty wait for a single build rather than each building their own.
+// The lock is not held during the build, so a waiting caller whose context
+// is cancelled returns at once instead of blocking on the build.
+type Session struct {
+	mu       sync.Mutex
+	app      *App
+	gen      int
+	inflight *sessionBuild
+	build    func(ctx context.Context) (*App, error)
+}
+
+// sessionBuild is a build in progress; done closes when it finishes.
+type sessionBuild struct {
+	done chan struct{}
+	app  *App
+	err  error
+}
+
+// NewSession returns a Session that uses build to construct its App.
+func NewSession(build func(ctx context.Context) (*App, error)) *Session {
+	return &Session{build: build}
+}
+
+// App returns the cached App, building it on first use or after Invalidate.
+// Build errors are not cached.
+func (s *Session) App(ctx context.Context) (*App, error) {
+	for {
+		s.mu.Lock()
+		if s.app != nil {
+			app := s.app
+			s.mu.Unlock()
+			return app, nil
+		}
+		if call := s.inflight; call != nil {
+			s.mu.Unlock()
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			if call.err == nil {
+				return call.app, nil
+			}
+			// A build that failed only because its own caller gave up says
+			// nothing about this caller; build again.
+			if errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded) {
+				continue
+			}
+			return nil, call.err
+		}
+
+		call := &sessionBuild{done: make(chan struct{})}
+		s.inflight = call
+		gen := s.gen
+		s.mu.Unlock()
+
+		call.app, call.err = s.build(ctx)
+
+		s.mu.Lock()
+		if s.inflight == call {
+			s.inflight = nil
+		}
+		// An Invalidate during the build means its credentials may be stale.
+		if call.err == nil && s.gen == gen {
+			s.app = call.app
+		}
+		s.mu.Unlock()
+		close(call.done)
+		return call.app, call.err
+	}
+}
+
+// Invalidate drops the cached App so the next call rereads credentials.
+// Callers already waiting on a build still get its result.
+func (s *Session) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.app = nil
+	s.inflight = nil
+	s.gen++
+}
+
+var defaultSession = NewSession(New)
+
+// accountlessSession caches a client that is not bound to an account, for
+// calls such as whoami and listing accounts that must work before one is
+// chosen. Its App has no AccountID.
+var accountlessSession = NewSession(func(ctx context.Context) (*App, error) {
+	c, err := NewAPIClient(false)
+	if err != nil {
+		return nil, err
+	}
+	return &App{Client: c}, nil
+})
+
+// Current returns the process-wide App built from stored credentials.
+func Current(ctx context.Context) (*App, error) {
+	return defaultSession.App(ctx)
+}
+
+// CurrentAPIClient returns the process-wide client for the stored token
+// without resolving an account.
+func CurrentAPIClient(ctx context.Context) (*dnsimple.Client, error) {
+	app, err := accountlessSession.App(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return app.Client, nil
+}
+
+// InvalidateCurrent drops the process-wide App and client. Call it after the
+// token, account or config directory changes.
+func InvalidateCurrent() {
+	defaultSession.Invalidate()
+	accountlessSession.Invalidate()
+}
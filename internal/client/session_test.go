@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dorkitude/simple/internal/config"
+)
+
+func TestSessionBuildsOnceForConcurrentCallers(t *testing.T) {
+	var builds atomic.Int32
+	release := make(chan struct{})
+	s := NewSession(func(ctx context.Context) (*App, error) {
+		builds.Add(1)
+		<-release
+		return &App{AccountID: "1010"}, nil
+	})
+
+	var wg sync.WaitGroup
+	apps := make([]*App, 10)
+	for i := range apps {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			app, err := s.App(context.Background())
+			if err != nil {
+				t.Error(err)
+			}
+			apps[i] = app
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := builds.Load(); n != 1 {
+		t.Fatalf("built %d times, want 1", n)
+	}
+	for i, app := range apps {
+		if app != apps[0] {
+			t.Fatalf("caller %d got a different App", i)
+		}
+	}
+}
+
+func TestSessionInvalidateRebuilds(t *testing.T) {
+	var builds atomic.Int32
+	s := NewSession(func(ctx context.Context) (*App, error) {
+		builds.Add(1)
+		return &App{}, nil
+	})
+	first, _ := s.App(context.Background())
+	if again, _ := s.App(context.Background()); again != first || builds.Load() != 1 {
+		t.Fatalf("second call rebuilt: %d builds", builds.Load())
+	}
+	s.Invalidate()
+	if next, _ := s.App(context.Background()); next == first || builds.Load() != 2 {
+		t.Fatalf("Invalidate did not force a rebuild: %d builds", builds.Load())
+	}
+}
+
+func TestSessionDoesNotCacheBuildErrors(t *testing.T) {
+	fail := true
+	s := NewSession(func(ctx context.Context) (*App, error) {
+		if fail {
+			return nil, errors.New("no token")
+		}
+		return &App{}, nil
+	})
+	if _, err := s.App(context.Background()); err == nil {
+		t.Fatal("want the build error")
+	}
+	fail = false
+	if app, err := s.App(context.Background()); err != nil || app == nil {
+		t.Fatalf("error was cached: %v", err)
+	}
+}
+
+func TestSessionCancelledWaiterDoesNotBlock(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s := NewSession(func(ctx context.Context) (*App, error) {
+		close(started)
+		<-release
+		return &App{}, nil
+	})
+	go s.App(context.Background())
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := s.App(ctx)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("cancelled waiter got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cancelled waiter blocked on another caller's build")
+	}
+	close(release)
+}
+
+func TestSessionInvalidateDuringBuildDropsResult(t *testing.T) {
+	release := make(chan struct{})
+	var builds atomic.Int32
+	s := NewSession(func(ctx context.Context) (*App, error) {
+		if builds.Add(1) == 1 {
+			<-release
+		}
+		return &App{}, nil
+	})
+	done := make(chan *App)
+	go func() {
+		app, _ := s.App(context.Background())
+		done <- app
+	}()
+	for builds.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	s.Invalidate()
+	close(release)
+	stale := <-done
+	if fresh, _ := s.App(context.Background()); fresh == stale {
+		t.Fatal("App built before Invalidate was cached")
+	}
+}
+
+func TestCurrentAPIClientRunsTokenCommandOnce(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("token_command test uses sh")
+	}
+	dir := t.TempDir()
+	t.Setenv("DNSIMPLE_CONFIG_DIR", dir)
+	t.Setenv("DNSIMPLE_TOKEN", "")
+	t.Setenv("SIMPLE_PROFILE", "")
+	runs := filepath.Join(dir, "runs")
+	cfg := &config.Config{Profiles: map[string]*config.Profile{
+		config.DefaultProfile: {TokenCommand: "echo run >> '" + runs + "'; echo cmd-token"},
+	}}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	InvalidateCurrent()
+	t.Cleanup(InvalidateCurrent)
+
+	countRuns := func() int {
+		data, _ := os.ReadFile(runs)
+		return strings.Count(string(data), "run")
+	}
+	first, err := CurrentAPIClient(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := CurrentAPIClient(context.Background()); again != first || countRuns() != 1 {
+		t.Fatalf("token_command ran %d times for two calls", countRuns())
+	}
+	InvalidateCurrent()
+	if _, err := CurrentAPIClient(context.Background()); err != nil || countRuns() != 2 {
+		t.Errorf("after InvalidateCurrent: %d runs, %v", countRuns(), err)
+	}
+}
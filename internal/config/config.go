@@ -1,25 +1,73 @@
 package config
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 const (
 	configDirEnv   = "DNSIMPLE_CONFIG_DIR"
-	tokenFileName  = "token"
 	configFileName = "config.json"
+
+	// TokenEnv and AccountEnv supply credentials without touching disk,
+	// e.g. on CI runners. They win over anything stored in a profile.
+	TokenEnv   = "DNSIMPLE_TOKEN"
+	AccountEnv = "DNSIMPLE_ACCOUNT"
+
+	// Token sources reported by TokenSource.
+	SourceEnv     = TokenEnv
+	SourceCommand = "token_command"
+	SourceFile    = "file"
+
+	// DefaultRequestTimeout bounds a single API request when the config
+	// does not set request_timeout.
+	DefaultRequestTimeout = 30 * time.Second
 )
 
 var configDirOverride string
 
 // Config holds the persisted configuration for dnsimplectl.
 type Config struct {
-	AccountID string `json:"account_id,omitempty"`
-	Sandbox   bool   `json:"sandbox,omitempty"`
+	// ActiveProfile is used when neither --profile nor SIMPLE_PROFILE picks
+	// one. Empty means DefaultProfile.
+	ActiveProfile string              `json:"active_profile,omitempty"`
+	Profiles      map[string]*Profile `json:"profiles,omitempty"`
+
+	// RequestTimeout bounds each API request, as a Go duration such as "20s".
+	// A profile's own request_timeout takes precedence.
+	RequestTimeout string `json:"request_timeout,omitempty"`
+
+	// Fields from the single-account layout. Load moves them into the
+	// default profile.
+	LegacyAccountID string `json:"account_id,omitempty"`
+	LegacySandbox   bool   `json:"sandbox,omitempty"`
+}
+
+// Timeout returns the request timeout for the profile in effect, falling
+// back to the global setting and then DefaultRequestTimeout.
+func (c *Config) Timeout() time.Duration {
+	if c == nil {
+		return DefaultRequestTimeout
+	}
+	var profileTimeout string
+	if p, err := c.Current(); err == nil {
+		profileTimeout = p.RequestTimeout
+	}
+	for _, v := range []string{profileTimeout, c.RequestTimeout} {
+		if v == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultRequestTimeout
 }
 
 // SetConfigDir overrides the config directory for the current process.
@@ -92,39 +140,145 @@ func ConfigDir() (string, error) {
 	return configDir()
 }
 
-// TokenPath returns the path to the token file.
+// TokenPath returns the path to the token file of the profile in effect.
 func TokenPath() (string, error) {
+	return TokenPathFor(CurrentProfileName())
+}
+
+// TokenPathFor returns the path to the token file of the named profile.
+func TokenPathFor(profile string) (string, error) {
+	if err := ValidateProfileName(profile); err != nil {
+		return "", err
+	}
 	dir, err := configDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(dir, tokenFileName), nil
+	if err := migrateLegacyToken(dir); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, tokensDirName), 0700); err != nil {
+		return "", err
+	}
+	return TokenFile(dir, profile), nil
+}
+
+// TokenFile returns where the named profile's token lives inside dir,
+// without touching the filesystem.
+func TokenFile(dir, profile string) string {
+	return filepath.Join(dir, tokensDirName, profile)
+}
+
+// TokenSource reports where LoadToken gets the token: SourceEnv,
+// SourceCommand or SourceFile, in that order of precedence.
+func TokenSource() string {
+	if strings.TrimSpace(os.Getenv(TokenEnv)) != "" {
+		return SourceEnv
+	}
+	if cfg, err := Load(); err == nil {
+		if p, err := cfg.Current(); err == nil && p.TokenCommand != "" {
+			return SourceCommand
+		}
+	}
+	return SourceFile
 }
 
-// LoadToken reads the API token from disk.
+// AccountID returns the account to use and where it came from: override
+// (normally --account), DNSIMPLE_ACCOUNT, or the profile's cached ID.
+// Both are empty if none is set.
+func (c *Config) AccountID(override string) (string, string) {
+	if override != "" {
+		return override, "--account"
+	}
+	if env := strings.TrimSpace(os.Getenv(AccountEnv)); env != "" {
+		return env, AccountEnv
+	}
+	if p, err := c.Current(); err == nil && p.AccountID != "" {
+		return p.AccountID, "config"
+	}
+	return "", ""
+}
+
+// LoadToken returns the API token of the profile in effect. DNSIMPLE_TOKEN
+// wins if set; otherwise it runs the profile's token_command if one is set,
+// and decrypts an encrypted token file with the session passphrase.
 func LoadToken() (string, error) {
-	path, err := TokenPath()
+	if env := strings.TrimSpace(os.Getenv(TokenEnv)); env != "" {
+		return env, nil
+	}
+	cfg, err := Load()
+	if err != nil {
+		cfg = &Config{}
+	}
+	profile, _ := cfg.ProfileName()
+	p, err := cfg.Current()
 	if err != nil {
 		return "", err
 	}
+	if p.TokenCommand != "" {
+		return RunTokenCommand(context.Background(), p.TokenCommand)
+	}
+
+	path, err := TokenPathFor(profile)
+	if err != nil {
+		return "", err
+	}
+	login := "simple auth login"
+	if profile != DefaultProfile {
+		login = "simple --profile " + profile + " auth login"
+	}
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return "", fmt.Errorf("not authenticated — run 'simple auth login' first")
+		return "", fmt.Errorf("not authenticated — run '%s' first", login)
+	}
+	if isSealed(data) {
+		pass, err := sessionPassphrase()
+		if err != nil {
+			return "", err
+		}
+		token, err := openToken(data, pass)
+		if errors.Is(err, ErrBadPassphrase) {
+			SetPassphrase("")
+		}
+		return token, err
 	}
 	token := string(data)
 	if token == "" {
-		return "", fmt.Errorf("token file is empty — run 'simple auth login'")
+		return "", fmt.Errorf("token file is empty — run '%s'", login)
 	}
 	return token, nil
 }
 
-// SaveToken writes the API token to disk with 0600 permissions.
+// SaveToken writes the API token to disk with 0600 permissions, encrypted
+// with the session passphrase if the profile uses encrypted storage.
 func SaveToken(token string) error {
+	cfg, err := Load()
+	if err != nil {
+		cfg = &Config{}
+	}
+	p, err := cfg.Current()
+	if err != nil {
+		return err
+	}
+	if p.StorageMode() == StorageCommand {
+		return fmt.Errorf("profile reads its token from token_command; remove token_command to store a token")
+	}
+
 	path, err := TokenPath()
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, []byte(token), 0600)
+	data := []byte(token)
+	if p.StorageMode() == StorageEncrypted {
+		pass, err := sessionPassphrase()
+		if err != nil {
+			return err
+		}
+		if data, err = sealToken(token, pass); err != nil {
+			return fmt.Errorf("failed to encrypt token: %w", err)
+		}
+	}
+	return os.WriteFile(path, data, 0600)
 }
 
 // RemoveToken deletes the stored token.
@@ -139,8 +293,18 @@ func RemoveToken() error {
 	return os.Remove(path)
 }
 
-// HasToken returns true if a token file exists.
+// HasToken returns true if DNSIMPLE_TOKEN is set, the profile has a
+// token_command, or a token file exists.
 func HasToken() bool {
+	if TokenSource() != SourceFile {
+		return true
+	}
+	return HasTokenFile()
+}
+
+// HasTokenFile returns true if the profile in effect has a stored token
+// file, regardless of DNSIMPLE_TOKEN or token_command.
+func HasTokenFile() bool {
 	path, err := TokenPath()
 	if err != nil {
 		return false
@@ -149,6 +313,17 @@ func HasToken() bool {
 	return err == nil
 }
 
+// TokenEncrypted reports whether the stored token file of the profile in
+// effect is encrypted, i.e. whether loading it needs a passphrase.
+func TokenEncrypted() bool {
+	path, err := TokenPath()
+	if err != nil {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	return err == nil && isSealed(data)
+}
+
 // ConfigPath returns the path to config.json.
 func ConfigPath() (string, error) {
 	dir, err := configDir()
@@ -175,6 +350,7 @@ func Load() (*Config, error) {
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return nil, err
 	}
+	cfg.migrateLegacy()
 	return &cfg, nil
 }
 
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvironmentOverridesStoredCredentials(t *testing.T) {
+	dir := useConfigDir(t)
+	if err := os.MkdirAll(filepath.Join(dir, tokensDirName), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(TokenFile(dir, DefaultProfile), []byte("file-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config{Profiles: map[string]*Profile{DefaultProfile: {AccountID: "1010"}}}
+	if err := Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if token, err := LoadToken(); err != nil || token != "file-token" {
+		t.Fatalf("stored token = %q, %v", token, err)
+	}
+	if id, source := cfg.AccountID(""); id != "1010" || source != "config" {
+		t.Fatalf("stored account = %q from %q", id, source)
+	}
+
+	t.Setenv(TokenEnv, " env-token ")
+	t.Setenv(AccountEnv, "2020")
+	if token, err := LoadToken(); err != nil || token != "env-token" {
+		t.Errorf("LoadToken with %s = %q, %v", TokenEnv, token, err)
+	}
+	if TokenSource() != SourceEnv {
+		t.Errorf("TokenSource = %q", TokenSource())
+	}
+	if id, source := cfg.AccountID(""); id != "2020" || source != AccountEnv {
+		t.Errorf("AccountID with %s = %q from %q", AccountEnv, id, source)
+	}
+	if id, source := cfg.AccountID("3030"); id != "3030" || source != "--account" {
+		t.Errorf("--account did not win over %s: %q from %q", AccountEnv, id, source)
+	}
+
+	// The environment token wins even over a token_command.
+	cfg.Profiles[DefaultProfile].TokenCommand = "echo command-token"
+	if err := Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if token, _ := LoadToken(); token != "env-token" {
+		t.Errorf("token_command won over %s: %q", TokenEnv, token)
+	}
+}
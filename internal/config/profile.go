@@ -0,0 +1,234 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	profileEnv       = "SIMPLE_PROFILE"
+	tokensDirName    = "tokens"
+	legacyTokenName  = "token"
+	profileNameChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_."
+
+	// DefaultProfile is used when nothing else selects a profile. The
+	// single-token layout migrates into it.
+	DefaultProfile = "default"
+
+	EnvProduction = "production"
+	EnvSandbox    = "sandbox"
+)
+
+var profileOverride string
+
+// Profile is one named set of credentials and defaults: its own token file,
+// account, API environment and request timeout.
+type Profile struct {
+	AccountID      string `json:"account_id,omitempty"`
+	Environment    string `json:"environment,omitempty"`
+	RequestTimeout string `json:"request_timeout,omitempty"`
+
+	// TokenStorage is StorageEncrypted to keep the token file encrypted
+	// with a passphrase. Empty means plaintext.
+	TokenStorage string `json:"token_storage,omitempty"`
+	// TokenCommand, when set, is run through the shell and its stdout used
+	// as the token instead of reading the token file.
+	TokenCommand string `json:"token_command,omitempty"`
+}
+
+// IsSandbox reports whether the profile targets the DNSimple sandbox API.
+func (p *Profile) IsSandbox() bool {
+	return p != nil && p.Environment == EnvSandbox
+}
+
+// EnvironmentName returns "sandbox" or "production".
+func (p *Profile) EnvironmentName() string {
+	if p.IsSandbox() {
+		return EnvSandbox
+	}
+	return EnvProduction
+}
+
+// SetProfile selects a profile for the current process. It takes precedence
+// over SIMPLE_PROFILE and the persisted active profile.
+func SetProfile(name string) {
+	profileOverride = strings.TrimSpace(name)
+}
+
+// ValidateProfileName rejects names that are empty or unsafe as file names.
+func ValidateProfileName(name string) error {
+	if name == "" {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+	if strings.HasPrefix(name, ".") {
+		return fmt.Errorf("profile name cannot start with '.'")
+	}
+	for _, r := range name {
+		if !strings.ContainsRune(profileNameChars, r) {
+			return fmt.Errorf("invalid profile name %q — use letters, digits, '-', '_' or '.'", name)
+		}
+	}
+	return nil
+}
+
+// ProfileName returns the profile in effect and where the choice came from:
+// "--profile", "SIMPLE_PROFILE", "config" or "default".
+func (c *Config) ProfileName() (string, string) {
+	if profileOverride != "" {
+		return profileOverride, "--profile"
+	}
+	if env := strings.TrimSpace(os.Getenv(profileEnv)); env != "" {
+		return env, profileEnv
+	}
+	if c != nil && c.ActiveProfile != "" {
+		return c.ActiveProfile, "config"
+	}
+	return DefaultProfile, "default"
+}
+
+// Current returns the profile in effect. See Profile for when it fails.
+func (c *Config) Current() (*Profile, error) {
+	name, _ := c.ProfileName()
+	return c.Profile(name)
+}
+
+// Profile returns the named profile. The default profile, and profiles that
+// so far only have a token file, get an empty entry on first use; any other
+// name is an error, so a mistyped --profile or SIMPLE_PROFILE is not saved
+// as a new profile.
+func (c *Config) Profile(name string) (*Profile, error) {
+	if p, ok := c.Profiles[name]; ok && p != nil {
+		return p, nil
+	}
+	if name != DefaultProfile && !tokenFileExists(name) {
+		return nil, fmt.Errorf("unknown profile '%s' — create it with 'simple profile add %s'", name, name)
+	}
+	return c.AddProfile(name), nil
+}
+
+// AddProfile adds an empty entry for the named profile, or returns the
+// existing one.
+func (c *Config) AddProfile(name string) *Profile {
+	if c.Profiles == nil {
+		c.Profiles = map[string]*Profile{}
+	}
+	p, ok := c.Profiles[name]
+	if !ok || p == nil {
+		p = &Profile{}
+		c.Profiles[name] = p
+	}
+	return p
+}
+
+func tokenFileExists(name string) bool {
+	if ValidateProfileName(name) != nil {
+		return false
+	}
+	dir, err := resolveConfigDir()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(TokenFile(dir, name))
+	return err == nil
+}
+
+// HasProfile reports whether the named profile is configured.
+func (c *Config) HasProfile(name string) bool {
+	_, ok := c.Profiles[name]
+	return ok
+}
+
+func (c *Config) migrateLegacy() {
+	if c.LegacyAccountID == "" && !c.LegacySandbox {
+		return
+	}
+	p := c.AddProfile(DefaultProfile)
+	if p.AccountID == "" {
+		p.AccountID = c.LegacyAccountID
+	}
+	if c.LegacySandbox && p.Environment == "" {
+		p.Environment = EnvSandbox
+	}
+	c.LegacyAccountID = ""
+	c.LegacySandbox = false
+}
+
+// migrateLegacyToken moves a single-layout token file into the default
+// profile's slot, leaving an existing default token untouched.
+func migrateLegacyToken(dir string) error {
+	legacy := filepath.Join(dir, legacyTokenName)
+	if _, err := os.Stat(legacy); err != nil {
+		return nil
+	}
+	target := TokenFile(dir, DefaultProfile)
+	if _, err := os.Stat(target); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	return os.Rename(legacy, target)
+}
+
+// CurrentProfileName returns the profile in effect for this process.
+func CurrentProfileName() string {
+	cfg, err := Load()
+	if err != nil {
+		cfg = nil
+	}
+	name, _ := cfg.ProfileName()
+	return name
+}
+
+// ProfileNames returns every known profile, sorted: those in config.json plus
+// any that only have a token file on disk.
+func ProfileNames(cfg *Config) ([]string, error) {
+	seen := map[string]bool{}
+	for name := range cfg.Profiles {
+		seen[name] = true
+	}
+
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := migrateLegacyToken(dir); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, tokensDirName))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !e.IsDir() && ValidateProfileName(e.Name()) == nil {
+			seen[e.Name()] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// RemoveProfile deletes the named profile's token file and config entry.
+// The caller saves cfg afterwards.
+func RemoveProfile(cfg *Config, name string) error {
+	path, err := TokenPathFor(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	delete(cfg.Profiles, name)
+	if cfg.ActiveProfile == name {
+		cfg.ActiveProfile = ""
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useConfigDir points the package at an empty config directory and clears
+// any profile selection for the duration of the test.
+func useConfigDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv(configDirEnv, dir)
+	t.Setenv(profileEnv, "")
+	t.Setenv(TokenEnv, "")
+	t.Setenv(AccountEnv, "")
+	SetProfile("")
+	t.Cleanup(func() { SetProfile("") })
+	return dir
+}
+
+func TestLoadMigratesLegacySettings(t *testing.T) {
+	dir := useConfigDir(t)
+	legacy := `{"account_id": "1010", "sandbox": true, "request_timeout": "20s"}`
+	if err := os.WriteFile(filepath.Join(dir, configFileName), []byte(legacy), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := cfg.Profiles[DefaultProfile]
+	if p == nil || p.AccountID != "1010" || !p.IsSandbox() {
+		t.Fatalf("default profile = %+v", p)
+	}
+	if cfg.LegacyAccountID != "" || cfg.LegacySandbox {
+		t.Errorf("legacy fields kept: %+v", cfg)
+	}
+
+	if err := Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, configFileName))
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := raw["account_id"]; ok {
+		t.Errorf("saved config still has top-level account_id: %s", data)
+	}
+}
+
+func TestMigrateLegacyKeepsDefaultProfileSettings(t *testing.T) {
+	cfg := &Config{
+		LegacyAccountID: "1010",
+		LegacySandbox:   true,
+		Profiles:        map[string]*Profile{DefaultProfile: {AccountID: "2020", Environment: EnvProduction}},
+	}
+	cfg.migrateLegacy()
+	if p := cfg.Profiles[DefaultProfile]; p.AccountID != "2020" || p.IsSandbox() {
+		t.Errorf("migration overwrote the default profile: %+v", p)
+	}
+
+	empty := &Config{}
+	empty.migrateLegacy()
+	if empty.Profiles != nil {
+		t.Errorf("migration created profiles from nothing: %+v", empty.Profiles)
+	}
+}
+
+func TestMigrateLegacyToken(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, legacyTokenName)
+	if err := os.WriteFile(legacy, []byte("old-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrateLegacyToken(dir); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(TokenFile(dir, DefaultProfile)); err != nil || string(data) != "old-token" {
+		t.Fatalf("default token = %q, %v", data, err)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Error("legacy token file left behind")
+	}
+
+	// A second legacy file never replaces a default token that exists.
+	if err := os.WriteFile(legacy, []byte("stale-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrateLegacyToken(dir); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(TokenFile(dir, DefaultProfile)); string(data) != "old-token" {
+		t.Errorf("default token overwritten with %q", data)
+	}
+
+	if err := migrateLegacyToken(t.TempDir()); err != nil {
+		t.Errorf("migration without a legacy file = %v", err)
+	}
+}
+
+func TestProfileRejectsUnknownNames(t *testing.T) {
+	dir := useConfigDir(t)
+	cfg := &Config{}
+
+	t.Setenv(profileEnv, "stagign")
+	if _, err := cfg.Current(); err == nil || !strings.Contains(err.Error(), "unknown profile 'stagign'") {
+		t.Fatalf("Current with a mistyped profile = %v", err)
+	}
+	if len(cfg.Profiles) != 0 {
+		t.Errorf("unknown profile was added: %v", cfg.Profiles)
+	}
+
+	if _, err := cfg.Profile(DefaultProfile); err != nil {
+		t.Errorf("default profile = %v", err)
+	}
+
+	// A profile that only has a token file on disk is known.
+	if err := os.MkdirAll(filepath.Join(dir, tokensDirName), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(TokenFile(dir, "ci"), []byte("token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cfg.Profile("ci"); err != nil {
+		t.Errorf("token-only profile = %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	passphraseEnv = "SIMPLE_TOKEN_PASSPHRASE"
+
+	// Token storage modes for Profile.TokenStorage. A profile with a
+	// token_command uses StorageCommand regardless of token_storage.
+	StoragePlaintext = "plaintext"
+	StorageEncrypted = "encrypted"
+	StorageCommand   = "command"
+
+	sealedFormat = "simple-sealed-token/v1"
+
+	// scrypt parameters recommended for interactive logins.
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 16
+
+	// Limits on the scrypt parameters read back from a token file, so a
+	// crafted file cannot make key derivation use unbounded memory or time.
+	maxScryptN = 1 << 20
+	maxScryptR = 32
+	maxScryptP = 16
+
+	tokenCommandTimeout = 30 * time.Second
+)
+
+var (
+	// ErrPassphraseRequired is returned when the token is encrypted and no
+	// passphrase was given for this session.
+	ErrPassphraseRequired = errors.New("token is encrypted — set " + passphraseEnv + " or enter the passphrase")
+
+	// ErrBadPassphrase is returned when the passphrase does not open the
+	// stored token.
+	ErrBadPassphrase = errors.New("wrong passphrase for encrypted token")
+)
+
+var (
+	passphraseMu     sync.Mutex
+	passphrase       string
+	passphrasePrompt func() (string, error)
+)
+
+// SetPassphrase remembers the token passphrase for the rest of the process.
+func SetPassphrase(p string) {
+	passphraseMu.Lock()
+	defer passphraseMu.Unlock()
+	passphrase = p
+}
+
+// SetPassphrasePrompt installs the function LoadToken calls when it needs a
+// passphrase that neither SetPassphrase nor SIMPLE_TOKEN_PASSPHRASE supplied.
+// Its answer is remembered for the rest of the process.
+func SetPassphrasePrompt(prompt func() (string, error)) {
+	passphraseMu.Lock()
+	defer passphraseMu.Unlock()
+	passphrasePrompt = prompt
+}
+
+// sessionPassphrase returns the passphrase given earlier in this process, from
+// the environment, or from the prompt, in that order.
+func sessionPassphrase() (string, error) {
+	passphraseMu.Lock()
+	defer passphraseMu.Unlock()
+	if passphrase != "" {
+		return passphrase, nil
+	}
+	if env := os.Getenv(passphraseEnv); env != "" {
+		return env, nil
+	}
+	if passphrasePrompt == nil {
+		return "", ErrPassphraseRequired
+	}
+	p, err := passphrasePrompt()
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", ErrPassphraseRequired
+	}
+	passphrase = p
+	return p, nil
+}
+
+// HasPassphrase reports whether a passphrase is available without prompting.
+func HasPassphrase() bool {
+	passphraseMu.Lock()
+	defer passphraseMu.Unlock()
+	return passphrase != "" || os.Getenv(passphraseEnv) != ""
+}
+
+// StorageMode returns how the profile's token is stored: StorageCommand,
+// StorageEncrypted or StoragePlaintext.
+func (p *Profile) StorageMode() string {
+	switch {
+	case p == nil:
+		return StoragePlaintext
+	case p.TokenCommand != "":
+		return StorageCommand
+	case p.TokenStorage == StorageEncrypted:
+		return StorageEncrypted
+	default:
+		return StoragePlaintext
+	}
+}
+
+// sealedToken is the on-disk form of an encrypted token: AES-256-GCM under a
+// key derived from the passphrase with scrypt.
+type sealedToken struct {
+	Format     string `json:"format"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func isSealed(data []byte) bool {
+	var s struct {
+		Format string `json:"format"`
+	}
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) &&
+		json.Unmarshal(data, &s) == nil &&
+		s.Format == sealedFormat
+}
+
+func sealToken(token, pass string) ([]byte, error) {
+	s := sealedToken{Format: sealedFormat, N: scryptN, R: scryptR, P: scryptP}
+	s.Salt = make([]byte, saltLen)
+	if _, err := rand.Read(s.Salt); err != nil {
+		return nil, err
+	}
+	aead, err := tokenAEAD(pass, s)
+	if err != nil {
+		return nil, err
+	}
+	s.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(s.Nonce); err != nil {
+		return nil, err
+	}
+	s.Ciphertext = aead.Seal(nil, s.Nonce, []byte(token), []byte(sealedFormat))
+	return json.MarshalIndent(s, "", "  ")
+}
+
+func openToken(data []byte, pass string) (string, error) {
+	var s sealedToken
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", fmt.Errorf("malformed encrypted token: %w", err)
+	}
+	if s.N < 2 || s.N > maxScryptN || s.R < 1 || s.R > maxScryptR || s.P < 1 || s.P > maxScryptP {
+		return "", fmt.Errorf("malformed encrypted token: scrypt parameters n=%d r=%d p=%d out of range", s.N, s.R, s.P)
+	}
+	aead, err := tokenAEAD(pass, s)
+	if err != nil {
+		return "", err
+	}
+	if len(s.Nonce) != aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted token: bad nonce")
+	}
+	plain, err := aead.Open(nil, s.Nonce, s.Ciphertext, []byte(sealedFormat))
+	if err != nil {
+		return "", ErrBadPassphrase
+	}
+	return string(plain), nil
+}
+
+func tokenAEAD(pass string, s sealedToken) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(pass), s.Salt, s.N, s.R, s.P, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// RunTokenCommand runs command through the shell and returns its trimmed
+// stdout as the token.
+func RunTokenCommand(ctx context.Context, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	c.Stdin = os.Stdin
+
+	if err := c.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("token_command %q failed: %w: %s", command, err, msg)
+		}
+		return "", fmt.Errorf("token_command %q failed: %w", command, err)
+	}
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("token_command %q printed nothing", command)
+	}
+	return token, nil
+}
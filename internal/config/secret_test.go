@@ -0,0 +1,106 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSealedTokenRoundTrip(t *testing.T) {
+	data, err := sealToken("dnsimple-token", "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isSealed(data) {
+		t.Fatalf("sealed token not recognized: %s", data)
+	}
+	if strings.Contains(string(data), "dnsimple-token") {
+		t.Fatal("sealed token contains the plaintext")
+	}
+	token, err := openToken(data, "correct horse")
+	if err != nil || token != "dnsimple-token" {
+		t.Fatalf("openToken = %q, %v", token, err)
+	}
+
+	if _, err := openToken(data, "battery staple"); !errors.Is(err, ErrBadPassphrase) {
+		t.Errorf("wrong passphrase = %v, want ErrBadPassphrase", err)
+	}
+	if isSealed([]byte("plain-token")) {
+		t.Error("plaintext token reported as sealed")
+	}
+}
+
+func TestOpenTokenRejectsTampering(t *testing.T) {
+	data, err := sealToken("dnsimple-token", "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tamper := func(change func(*sealedToken)) []byte {
+		var s sealedToken
+		if err := json.Unmarshal(data, &s); err != nil {
+			t.Fatal(err)
+		}
+		change(&s)
+		out, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+		wantMsg string
+	}{
+		{"ciphertext", tamper(func(s *sealedToken) { s.Ciphertext[0] ^= 1 }), ErrBadPassphrase, ""},
+		{"nonce", tamper(func(s *sealedToken) { s.Nonce[0] ^= 1 }), ErrBadPassphrase, ""},
+		{"salt", tamper(func(s *sealedToken) { s.Salt[0] ^= 1 }), ErrBadPassphrase, ""},
+		{"short nonce", tamper(func(s *sealedToken) { s.Nonce = s.Nonce[:4] }), nil, "bad nonce"},
+		{"huge n", tamper(func(s *sealedToken) { s.N = 1 << 30 }), nil, "out of range"},
+		{"huge r", tamper(func(s *sealedToken) { s.R = maxScryptR + 1 }), nil, "out of range"},
+		{"huge p", tamper(func(s *sealedToken) { s.P = maxScryptP + 1 }), nil, "out of range"},
+		{"zero n", tamper(func(s *sealedToken) { s.N = 0 }), nil, "out of range"},
+		{"not json", []byte("{"), nil, "malformed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := openToken(tt.data, "pass")
+			if err == nil {
+				t.Fatalf("tampered token opened as %q", token)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantMsg != "" && !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestRunTokenCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("token_command tests use sh")
+	}
+	ctx := context.Background()
+
+	token, err := RunTokenCommand(ctx, "printf '  from-vault\\n'")
+	if err != nil || token != "from-vault" {
+		t.Errorf("token = %q, %v", token, err)
+	}
+
+	_, err = RunTokenCommand(ctx, "echo vault is sealed >&2; exit 3")
+	if err == nil || !strings.Contains(err.Error(), "vault is sealed") {
+		t.Errorf("failing command = %v, want its stderr", err)
+	}
+
+	_, err = RunTokenCommand(ctx, "true")
+	if err == nil || !strings.Contains(err.Error(), "printed nothing") {
+		t.Errorf("silent command = %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package filter
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldNames lists the filterable fields of a resource struct, sorted.
+func FieldNames(sample interface{}) []string {
+	return sortedNames(fieldsOf(sample))
+}
+
+// fieldsOf maps a struct's JSON field names to comparable values: numbers
+// become float64, strings and booleans stay as they are, and string slices
+// (record regions) are kept for any-element matching. Nested structs and
+// pointers are skipped.
+func fieldsOf(v interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return fields
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fields
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+
+		fv := rv.Field(i)
+		switch fv.Kind() {
+		case reflect.String:
+			fields[name] = fv.String()
+		case reflect.Bool:
+			fields[name] = fv.Bool()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			fields[name] = float64(fv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			fields[name] = float64(fv.Uint())
+		case reflect.Float32, reflect.Float64:
+			fields[name] = fv.Float()
+		case reflect.Slice:
+			if fv.Type().Elem().Kind() == reflect.String {
+				list := make([]string, fv.Len())
+				for j := range list {
+					list[j] = fv.Index(j).String()
+				}
+				fields[name] = list
+			}
+		}
+	}
+	return fields
+}
+
+func sortedNames(fields map[string]interface{}) []string {
+	names := make([]string, 0, len(fields))
+	for k := range fields {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
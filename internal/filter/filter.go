@@ -0,0 +1,347 @@
+// Package filter implements the --where expression language used to select
+// domains, zones and records client-side.
+//
+//	type in ("A","AAAA") && ttl < 300 && content =~ "^203\."
+//
+// Fields are the resource's JSON field names. Comparisons are ==, !=, <,
+// <=, >, >=, =~ (regexp match), !~ and in (...). Conditions combine with
+// && / and, || / or, ! / not and parentheses. A bare field is true when it
+// is a true boolean or non-empty.
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Expr is a parsed filter expression.
+type Expr struct {
+	src  string
+	root node
+}
+
+// SyntaxError reports where an expression failed to parse.
+type SyntaxError struct {
+	Expr string
+	Pos  int // byte offset into Expr
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid filter at column %d: %s\n  %s\n  %s^",
+		e.Pos+1, e.Msg, e.Expr, strings.Repeat(" ", e.Pos))
+}
+
+// Parse compiles an expression. Regular expressions are compiled here, so
+// a bad pattern is a parse error.
+func Parse(src string) (*Expr, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: src, toks: toks}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorf(t, "unexpected %s", t.describe())
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+// String returns the source text of the expression.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Fields returns the field names the expression refers to.
+func (e *Expr) Fields() []string {
+	var names []string
+	seen := map[string]bool{}
+	walk(e.root, func(f string) {
+		if !seen[f] {
+			seen[f] = true
+			names = append(names, f)
+		}
+	})
+	return names
+}
+
+// Validate checks that every field the expression uses exists on the
+// resource type of sample, so mistakes surface before any API call.
+func (e *Expr) Validate(sample interface{}) error {
+	available := FieldNames(sample)
+	known := map[string]bool{}
+	for _, f := range available {
+		known[f] = true
+	}
+	for _, f := range e.Fields() {
+		if !known[f] {
+			return fmt.Errorf("unknown filter field %q (available: %s)", f, strings.Join(available, ", "))
+		}
+	}
+	return nil
+}
+
+// Match evaluates the expression against a resource struct.
+func (e *Expr) Match(v interface{}) (bool, error) {
+	return e.root.eval(fieldsOf(v))
+}
+
+type node interface {
+	eval(fields map[string]interface{}) (bool, error)
+}
+
+type andNode struct{ left, right node }
+type orNode struct{ left, right node }
+type notNode struct{ inner node }
+
+// truthNode is a bare field used as a condition.
+type truthNode struct{ field string }
+
+type compareNode struct {
+	field string
+	op    string
+	value literal
+	list  []literal
+	re    *regexp.Regexp
+}
+
+type literal struct {
+	str   string
+	num   float64
+	isNum bool
+	bool  bool
+	isB   bool
+}
+
+func (n andNode) eval(f map[string]interface{}) (bool, error) {
+	ok, err := n.left.eval(f)
+	if err != nil || !ok {
+		return false, err
+	}
+	return n.right.eval(f)
+}
+
+func (n orNode) eval(f map[string]interface{}) (bool, error) {
+	ok, err := n.left.eval(f)
+	if err != nil || ok {
+		return ok, err
+	}
+	return n.right.eval(f)
+}
+
+func (n notNode) eval(f map[string]interface{}) (bool, error) {
+	ok, err := n.inner.eval(f)
+	return !ok, err
+}
+
+func (n truthNode) eval(f map[string]interface{}) (bool, error) {
+	v, ok := f[n.field]
+	if !ok {
+		return false, unknownField(n.field, f)
+	}
+	switch v := v.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return v != "", nil
+	case float64:
+		return v != 0, nil
+	case []string:
+		return len(v) > 0, nil
+	}
+	return v != nil, nil
+}
+
+func (n compareNode) eval(f map[string]interface{}) (bool, error) {
+	v, ok := f[n.field]
+	if !ok {
+		return false, unknownField(n.field, f)
+	}
+	// List fields such as regions match when any element does.
+	if list, ok := v.([]string); ok {
+		negated := n.op == "!=" || n.op == "!~"
+		for _, s := range list {
+			hit, err := n.compare(s)
+			if err != nil {
+				return false, err
+			}
+			if hit != negated {
+				return !negated, nil
+			}
+		}
+		return negated, nil
+	}
+	return n.compare(v)
+}
+
+func (n compareNode) compare(v interface{}) (bool, error) {
+	switch n.op {
+	case "=~":
+		return n.re.MatchString(toString(v)), nil
+	case "!~":
+		return !n.re.MatchString(toString(v)), nil
+	case "in":
+		for _, lit := range n.list {
+			if c, ok := compareValue(v, lit); ok && c == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	c, ok := compareValue(v, n.value)
+	if !ok {
+		return false, fmt.Errorf("cannot compare %s (%s) with %s", n.field, typeName(v), n.value.describe())
+	}
+	switch n.op {
+	case "==":
+		return c == 0, nil
+	case "!=":
+		return c != 0, nil
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	case ">=":
+		return c >= 0, nil
+	}
+	return false, fmt.Errorf("unknown operator %s", n.op)
+}
+
+// compareValue orders a field value against a literal. Strings compare
+// case-insensitively, as DNS names and record types do; timestamps are
+// ISO 8601 so they order correctly as strings.
+func compareValue(v interface{}, lit literal) (int, bool) {
+	switch v := v.(type) {
+	case float64:
+		n := lit.num
+		if !lit.isNum {
+			parsed, err := strconv.ParseFloat(lit.str, 64)
+			if err != nil || lit.isB {
+				return 0, false
+			}
+			n = parsed
+		}
+		switch {
+		case v < n:
+			return -1, true
+		case v > n:
+			return 1, true
+		}
+		return 0, true
+	case bool:
+		b := lit.bool
+		if !lit.isB {
+			parsed, err := strconv.ParseBool(lit.str)
+			if err != nil || lit.isNum {
+				return 0, false
+			}
+			b = parsed
+		}
+		if v == b {
+			return 0, true
+		}
+		if !v {
+			return -1, true
+		}
+		return 1, true
+	case string:
+		return strings.Compare(strings.ToLower(v), strings.ToLower(lit.text())), true
+	case nil:
+		return strings.Compare("", lit.text()), true
+	}
+	return 0, false
+}
+
+func (l literal) text() string {
+	switch {
+	case l.isNum:
+		return strconv.FormatFloat(l.num, 'f', -1, 64)
+	case l.isB:
+		return strconv.FormatBool(l.bool)
+	}
+	return l.str
+}
+
+func (l literal) describe() string {
+	switch {
+	case l.isNum:
+		return "number " + l.text()
+	case l.isB:
+		return l.text()
+	}
+	return strconv.Quote(l.str)
+}
+
+func toString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func unknownField(name string, fields map[string]interface{}) error {
+	return fmt.Errorf("unknown filter field %q (available: %s)", name, strings.Join(sortedNames(fields), ", "))
+}
+
+func walk(n node, visit func(field string)) {
+	switch n := n.(type) {
+	case andNode:
+		walk(n.left, visit)
+		walk(n.right, visit)
+	case orNode:
+		walk(n.left, visit)
+		walk(n.right, visit)
+	case notNode:
+		walk(n.inner, visit)
+	case truthNode:
+		visit(n.field)
+	case compareNode:
+		visit(n.field)
+	}
+}
+
+// Select returns the items the expression matches, in order. A nil
+// expression selects everything.
+func Select[T any](e *Expr, items []T) ([]T, error) {
+	if e == nil {
+		return items, nil
+	}
+	out := make([]T, 0, len(items))
+	for _, item := range items {
+		ok, err := e.Match(item)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
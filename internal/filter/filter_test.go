@@ -0,0 +1,121 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+var records = []dnsimple.ZoneRecord{
+	{ID: 1, Type: "A", Name: "www", Content: "203.0.113.10", TTL: 60},
+	{ID: 2, Type: "AAAA", Name: "www", Content: "2001:db8::1", TTL: 3600},
+	{ID: 3, Type: "A", Name: "", Content: "198.51.100.7", TTL: 120},
+	{ID: 4, Type: "TXT", Name: "", Content: "v=spf1 -all", TTL: 3600, Regions: []string{"SV1", "IAD"}},
+	{ID: 5, Type: "NS", Name: "", Content: "ns1.dnsimple.com", TTL: 3600, SystemRecord: true},
+}
+
+func matchIDs(t *testing.T, src string) []int64 {
+	t.Helper()
+	expr, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", src, err)
+	}
+	var ids []int64
+	for _, r := range records {
+		ok, err := expr.Match(r)
+		if err != nil {
+			t.Fatalf("Match(%q): %v", src, err)
+		}
+		if ok {
+			ids = append(ids, r.ID)
+		}
+	}
+	return ids
+}
+
+func TestMatch(t *testing.T) {
+	for src, want := range map[string][]int64{
+		`type in ("A","AAAA") && ttl < 300 && content =~ "^203\."`:  {1},
+		`type in ("A","AAAA") && ttl < 300 && content =~ "^203\\."`: {1},
+		`type == "a"`:                                  {1, 3},
+		`type != "A" and not system_record`:            {2, 4},
+		`name == "" || ttl >= 3600`:                    {2, 3, 4, 5},
+		`!(ttl > 100) or content !~ "\d\."`:            {1, 2, 4},
+		`regions == "iad"`:                             {4},
+		`regions`:                                      {4},
+		`type not in ('NS', 'TXT')`:                    {1, 2, 3},
+		`(type == "A" || type == "TXT") && ttl <= 120`: {1, 3},
+		`system_record == true`:                        {5},
+		`ttl == "60"`:                                  {1},
+	} {
+		got := matchIDs(t, src)
+		if len(got) != len(want) {
+			t.Errorf("%s: got %v, want %v", src, got, want)
+			continue
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("%s: got %v, want %v", src, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for src, want := range map[string]string{
+		`type = "A"`:            "column 6: use == to compare",
+		`type == A`:             "column 9: expected a value after ==, found \"A\" (quote strings)",
+		`type == "A" &&`:        "column 15: expected a condition, found end of expression",
+		`(ttl < 5`:              "column 9: expected ) to close the ( at column 1",
+		`content =~ "("`:        "column 12: invalid regular expression",
+		`type in "A"`:           "column 9: expected ( after in",
+		`type == "A`:            "column 9: unterminated string",
+		`ttl < 5 extra`:         "column 9: unexpected \"extra\"",
+		`type == "A" & ttl < 5`: "column 13: use &&",
+	} {
+		_, err := Parse(src)
+		var syn *SyntaxError
+		if !errors.As(err, &syn) {
+			t.Errorf("Parse(%q) = %v, want SyntaxError", src, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) error:\n%v\nwant it to contain %q", src, err, want)
+		}
+	}
+}
+
+func TestSyntaxErrorPointsAtColumn(t *testing.T) {
+	_, err := Parse(`ttl < 300 && type = "A"`)
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != 3 || strings.Index(lines[2], "^") != strings.Index(lines[1], "=") {
+		t.Errorf("caret misplaced:\n%v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	expr, err := Parse(`typ == "A"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := expr.Validate(dnsimple.ZoneRecord{}); err == nil || !strings.Contains(err.Error(), `unknown filter field "typ"`) {
+		t.Errorf("Validate = %v", err)
+	}
+	expr, _ = Parse(`state == "registered" && expires_at < "2027-01-01" && auto_renew`)
+	if err := expr.Validate(dnsimple.Domain{}); err != nil {
+		t.Errorf("Validate(Domain) = %v", err)
+	}
+	if _, err := expr.Match(dnsimple.Domain{State: "registered", ExpiresAt: "2026-05-01T00:00:00Z", AutoRenew: true}); err != nil {
+		t.Errorf("Match(Domain) = %v", err)
+	}
+}
+
+func TestMatchTypeMismatch(t *testing.T) {
+	expr, _ := Parse(`ttl > "soon"`)
+	if _, err := expr.Match(records[0]); err == nil || !strings.Contains(err.Error(), "cannot compare ttl (number)") {
+		t.Errorf("got %v", err)
+	}
+}
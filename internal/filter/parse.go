@@ -0,0 +1,327 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type token struct {
+	kind tokKind
+	text string
+	pos  int
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return "string " + strconv.Quote(t.text)
+	case tokNumber:
+		return "number " + t.text
+	}
+	return strconv.Quote(t.text)
+}
+
+// twoCharOps must be tried before their one-character prefixes.
+var twoCharOps = []string{"&&", "||", "==", "!=", "<=", ">=", "=~", "!~"}
+
+func lex(src string) ([]token, error) {
+	var toks []token
+	bad := func(pos int, format string, args ...interface{}) error {
+		return &SyntaxError{Expr: src, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+	}
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			toks = append(toks, token{tokLParen, "(", i})
+			i++
+		case c == ')':
+			toks = append(toks, token{tokRParen, ")", i})
+			i++
+		case c == ',':
+			toks = append(toks, token{tokComma, ",", i})
+			i++
+		case c == '"' || c == '\'':
+			s, n, err := lexString(src[i:])
+			if err != nil {
+				return nil, bad(i, "%s", err)
+			}
+			toks = append(toks, token{tokString, s, i})
+			i += n
+		case c >= '0' && c <= '9' || c == '-' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+			start := i
+			i++
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.') {
+				i++
+			}
+			if _, err := strconv.ParseFloat(src[start:i], 64); err != nil {
+				return nil, bad(start, "invalid number %q", src[start:i])
+			}
+			toks = append(toks, token{tokNumber, src[start:i], start})
+		case isIdentStart(rune(c)):
+			start := i
+			for i < len(src) && isIdentPart(rune(src[i])) {
+				i++
+			}
+			toks = append(toks, token{tokIdent, src[start:i], start})
+		default:
+			matched := false
+			for _, op := range twoCharOps {
+				if strings.HasPrefix(src[i:], op) {
+					toks = append(toks, token{tokOp, op, i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if matched {
+				continue
+			}
+			switch c {
+			case '<', '>', '!':
+				toks = append(toks, token{tokOp, string(c), i})
+				i++
+			case '=':
+				return nil, bad(i, "use == to compare")
+			case '&', '|':
+				return nil, bad(i, "use %c%c", c, c)
+			default:
+				return nil, bad(i, "unexpected character %q", c)
+			}
+		}
+	}
+	return append(toks, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// lexString reads a quoted string. Backslash escapes \" \' \\ \n \t; any
+// other backslash is kept, so regexps like "^203\." need no doubling.
+func lexString(s string) (string, int, error) {
+	quote := s[0]
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == quote:
+			return b.String(), i + 1, nil
+		case c == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case quote, '\\':
+				b.WriteByte(s[i])
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r) || r == '.'
+}
+
+type parser struct {
+	src  string
+	toks []token
+	i    int
+}
+
+func (p *parser) peek() token { return p.toks[p.i] }
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return &SyntaxError{Expr: p.src, Pos: t.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// keyword reports whether t is the bare word w (and, or, not, in).
+func keyword(t token, w string) bool {
+	return t.kind == tokIdent && strings.EqualFold(t.text, w)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.text == "||" && t.kind == tokOp || keyword(t, "or"); t = p.peek() {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t.text == "&&" && t.kind == tokOp || keyword(t, "and"); t = p.peek() {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if t := p.peek(); t.kind == tokOp && t.text == "!" || keyword(t, "not") {
+		p.next()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c.kind != tokRParen {
+			return nil, p.errorf(c, "expected ) to close the ( at column %d, found %s", t.pos+1, c.describe())
+		}
+		return inner, nil
+	case t.kind == tokIdent && !keyword(t, "and") && !keyword(t, "or") && !keyword(t, "in"):
+		return p.parseComparison(t)
+	case t.kind == tokEOF:
+		return nil, p.errorf(t, "expected a condition, found end of expression")
+	}
+	return nil, p.errorf(t, "expected a field name, found %s", t.describe())
+}
+
+func (p *parser) parseComparison(field token) (node, error) {
+	name := strings.ToLower(field.text)
+	op := p.peek()
+
+	switch {
+	case keyword(op, "in"):
+		p.next()
+		list, err := p.parseList(op)
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{field: name, op: "in", list: list}, nil
+	case keyword(op, "not"):
+		// "field not in (...)"
+		p.next()
+		if in := p.next(); !keyword(in, "in") {
+			return nil, p.errorf(in, "expected in after not, found %s", in.describe())
+		}
+		list, err := p.parseList(op)
+		if err != nil {
+			return nil, err
+		}
+		return notNode{compareNode{field: name, op: "in", list: list}}, nil
+	case op.kind != tokOp || op.text == "!" || op.text == "&&" || op.text == "||":
+		// A bare field is a truth test.
+		return truthNode{field: name}, nil
+	}
+
+	p.next()
+	valTok := p.peek()
+	val, err := p.parseValue(op)
+	if err != nil {
+		return nil, err
+	}
+	n := compareNode{field: name, op: op.text, value: val}
+	if op.text == "=~" || op.text == "!~" {
+		if val.isNum || val.isB {
+			return nil, p.errorf(valTok, "%s needs a quoted regular expression", op.text)
+		}
+		re, err := regexp.Compile(val.str)
+		if err != nil {
+			return nil, p.errorf(valTok, "invalid regular expression: %s", strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+		}
+		n.re = re
+	}
+	return n, nil
+}
+
+func (p *parser) parseValue(after token) (literal, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokString:
+		return literal{str: t.text}, nil
+	case t.kind == tokNumber:
+		f, _ := strconv.ParseFloat(t.text, 64)
+		return literal{num: f, isNum: true}, nil
+	case keyword(t, "true"), keyword(t, "false"):
+		return literal{bool: keyword(t, "true"), isB: true}, nil
+	case t.kind == tokIdent:
+		return literal{}, p.errorf(t, "expected a value after %s, found %s (quote strings)", after.text, t.describe())
+	}
+	return literal{}, p.errorf(t, "expected a value after %s, found %s", after.text, t.describe())
+}
+
+func (p *parser) parseList(in token) ([]literal, error) {
+	if t := p.next(); t.kind != tokLParen {
+		return nil, p.errorf(t, "expected ( after in, found %s", t.describe())
+	}
+	var list []literal
+	for {
+		val, err := p.parseValue(in)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, val)
+		t := p.next()
+		if t.kind == tokRParen {
+			return list, nil
+		}
+		if t.kind != tokComma {
+			return nil, p.errorf(t, "expected , or ) in list, found %s", t.describe())
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Package forwarding checks the input for DNSimple email forwards and
+// describes existing forwards, hiding the API's deprecated field names.
+package forwarding
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+// CatchAll is the alias of a catch-all forward. DNSimple reads aliases as
+// regular expressions, and this one matches any local part.
+const CatchAll = ".*"
+
+// Alias checks the local part of a forward's address. "*" stands for
+// CatchAll, and a full address at domain is reduced to its local part.
+func Alias(input, domain string) (string, error) {
+	a := strings.TrimSpace(input)
+	if local, host, ok := strings.Cut(a, "@"); ok {
+		if !strings.EqualFold(host, domain) {
+			return "", fmt.Errorf("%s is not an address at %s", a, domain)
+		}
+		a = local
+	}
+	switch {
+	case a == "":
+		return "", fmt.Errorf("give the alias to forward, such as hello, or * for a catch-all")
+	case a == "*":
+		return CatchAll, nil
+	case strings.ContainsAny(a, " \t"):
+		return "", fmt.Errorf("alias %q contains spaces", a)
+	}
+	return a, nil
+}
+
+// Destination checks the address mail is forwarded to.
+func Destination(input string) (string, error) {
+	d := strings.TrimSpace(input)
+	addr, err := mail.ParseAddress(d)
+	if err != nil || addr.Address != d {
+		return "", fmt.Errorf("%q is not an email address", input)
+	}
+	return d, nil
+}
+
+// From returns the address a forward receives mail at.
+func From(f dnsimple.EmailForward) string {
+	if f.AliasEmail != "" {
+		return f.AliasEmail
+	}
+	return f.From
+}
+
+// To returns the address a forward sends mail on to.
+func To(f dnsimple.EmailForward) string {
+	if f.DestinationEmail != "" {
+		return f.DestinationEmail
+	}
+	return f.To
+}
+
+// IsCatchAll reports whether f forwards every address of its domain.
+func IsCatchAll(f dnsimple.EmailForward) bool {
+	local, _, _ := strings.Cut(From(f), "@")
+	return local == CatchAll
+}
@@ -0,0 +1,52 @@
+package forwarding
+
+import (
+	"testing"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+func TestAlias(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: "hello", want: "hello"},
+		{in: " sales ", want: "sales"},
+		{in: "*", want: CatchAll},
+		{in: "hello@Example.com", want: "hello"},
+		{in: "hello@other.example", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "two words", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Alias(tt.in, "example.com")
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Alias(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestDestination(t *testing.T) {
+	for _, ok := range []string{"ops@example.net", " team+dns@example.org "} {
+		if _, err := Destination(ok); err != nil {
+			t.Errorf("Destination(%q): %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"", "ops", "Ops <ops@example.net>", "ops@example.net, dev@example.net"} {
+		if _, err := Destination(bad); err == nil {
+			t.Errorf("Destination(%q) accepted", bad)
+		}
+	}
+}
+
+func TestDeprecatedFields(t *testing.T) {
+	f := dnsimple.EmailForward{From: ".*@example.com", To: "ops@example.net"}
+	if From(f) != ".*@example.com" || To(f) != "ops@example.net" || !IsCatchAll(f) {
+		t.Errorf("From=%q To=%q IsCatchAll=%v", From(f), To(f), IsCatchAll(f))
+	}
+	f = dnsimple.EmailForward{AliasEmail: "hello@example.com", DestinationEmail: "ops@example.net"}
+	if From(f) != "hello@example.com" || To(f) != "ops@example.net" || IsCatchAll(f) {
+		t.Errorf("From=%q To=%q IsCatchAll=%v", From(f), To(f), IsCatchAll(f))
+	}
+}
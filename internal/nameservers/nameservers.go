@@ -0,0 +1,152 @@
+// Package nameservers validates and compares the NS sets at a zone's apex
+// and replaces them through DNSimple's zone NS endpoint.
+package nameservers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+// Change describes replacing a zone's name servers.
+type Change struct {
+	Zone    string   `json:"zone"`
+	Before  []string `json:"before"`
+	After   []string `json:"after"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+// NewChange compares two NS sets. Names are compared as given, so both
+// should come from Normalize or FromRecords.
+func NewChange(zone string, before, after []string) Change {
+	c := Change{Zone: zone, Before: before, After: after, Added: []string{}, Removed: []string{}}
+	old := make(map[string]bool, len(before))
+	for _, n := range before {
+		old[n] = true
+	}
+	next := make(map[string]bool, len(after))
+	for _, n := range after {
+		next[n] = true
+		if !old[n] {
+			c.Added = append(c.Added, n)
+		}
+	}
+	for _, n := range before {
+		if !next[n] {
+			c.Removed = append(c.Removed, n)
+		}
+	}
+	return c
+}
+
+// Empty reports whether the change leaves the NS set as it is.
+func (c Change) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0
+}
+
+// Split breaks user input such as "ns1.example.net, ns2.example.net" into
+// names.
+func Split(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
+
+// Normalize lower-cases the names and drops trailing dots, keeping their
+// order. It rejects an empty set, duplicates, IP addresses and anything
+// that is not a fully qualified host name.
+func Normalize(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("give at least one name server")
+	}
+	out := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, raw := range names {
+		n := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(raw)), ".")
+		if err := checkHostname(n); err != nil {
+			return nil, fmt.Errorf("%q is not a valid name server: %w", raw, err)
+		}
+		if seen[n] {
+			return nil, fmt.Errorf("%s is listed twice", n)
+		}
+		seen[n] = true
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+func checkHostname(n string) error {
+	switch {
+	case n == "":
+		return fmt.Errorf("empty name")
+	case net.ParseIP(n) != nil:
+		return fmt.Errorf("use the server's host name, not its address")
+	case len(n) > 253:
+		return fmt.Errorf("longer than 253 characters")
+	}
+	labels := strings.Split(n, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("not fully qualified")
+	}
+	for _, l := range labels {
+		if l == "" || len(l) > 63 {
+			return fmt.Errorf("labels must be 1 to 63 characters")
+		}
+		if l[0] == '-' || l[len(l)-1] == '-' {
+			return fmt.Errorf("labels cannot start or end with a hyphen")
+		}
+		for _, r := range l {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+				return fmt.Errorf("unexpected character %q", r)
+			}
+		}
+	}
+	return nil
+}
+
+// FromRecords returns the sorted names of the NS records at the apex.
+func FromRecords(records []dnsimple.ZoneRecord) []string {
+	var names []string
+	for _, r := range records {
+		if r.Type == "NS" && r.Name == "" {
+			names = append(names, strings.TrimSuffix(strings.ToLower(r.Content), "."))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// List fetches the NS records at the apex of a zone.
+func List(ctx context.Context, client *dnsimple.Client, accountID, zone string) ([]dnsimple.ZoneRecord, error) {
+	resp, err := client.Zones.ListRecords(ctx, accountID, zone, &dnsimple.ZoneRecordListOptions{
+		Name:        dnsimple.String(""),
+		Type:        dnsimple.String("NS"),
+		ListOptions: dnsimple.ListOptions{PerPage: dnsimple.Int(100)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// Update replaces the NS records at the apex of a zone and returns the new
+// ones. dnsimple-go has no wrapper for this endpoint, so it goes through
+// the client's generic request.
+func Update(ctx context.Context, client *dnsimple.Client, accountID, zone string, names []string) ([]dnsimple.ZoneRecord, error) {
+	path := fmt.Sprintf("/v2/%s/zones/%s/ns_records", url.PathEscape(accountID), url.PathEscape(zone))
+	body := struct {
+		NSNames []string `json:"ns_names"`
+	}{names}
+	resp := &dnsimple.ZoneRecordsResponse{}
+	if _, err := client.Request(ctx, http.MethodPut, path, body, resp, nil); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
@@ -0,0 +1,95 @@
+package nameservers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+func TestNormalize(t *testing.T) {
+	got, err := Normalize(Split("NS1.DNSimple.com., ns2.dnsimple-edge.net\tns3.example.org"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"ns1.dnsimple.com", "ns2.dnsimple-edge.net", "ns3.example.org"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Normalize = %v, want %v", got, want)
+	}
+
+	bad := map[string][]string{
+		"empty":     nil,
+		"address":   {"192.0.2.53"},
+		"bare":      {"localhost"},
+		"duplicate": {"ns1.example.net", "NS1.example.net."},
+		"hyphen":    {"-ns1.example.net"},
+		"scheme":    {"https://ns1.example.net"},
+		"empty lbl": {"ns1..example.net"},
+	}
+	for name, in := range bad {
+		if _, err := Normalize(in); err == nil {
+			t.Errorf("%s: Normalize(%q) accepted", name, in)
+		}
+	}
+}
+
+func TestNewChange(t *testing.T) {
+	c := NewChange("example.com", []string{"ns1.a.net", "ns2.a.net"}, []string{"ns2.a.net", "ns1.b.net"})
+	if !reflect.DeepEqual(c.Added, []string{"ns1.b.net"}) || !reflect.DeepEqual(c.Removed, []string{"ns1.a.net"}) {
+		t.Errorf("added %v, removed %v", c.Added, c.Removed)
+	}
+	if c.Empty() {
+		t.Error("change reported empty")
+	}
+	if same := NewChange("example.com", c.After, c.After); !same.Empty() {
+		t.Errorf("identical sets differ: %+v", same)
+	}
+}
+
+func TestFromRecords(t *testing.T) {
+	got := FromRecords([]dnsimple.ZoneRecord{
+		{Type: "NS", Name: "", Content: "ns2.dnsimple.com."},
+		{Type: "NS", Name: "sub", Content: "ns.delegated.net"},
+		{Type: "A", Name: "", Content: "203.0.113.10"},
+		{Type: "NS", Name: "", Content: "NS1.dnsimple.com"},
+	})
+	if want := []string{"ns1.dnsimple.com", "ns2.dnsimple.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FromRecords = %v, want %v", got, want)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var gotBody map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/v2/1010/zones/example.com/ns_records" {
+			http.Error(w, "unexpected "+r.Method+" "+r.URL.Path, http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		var data []dnsimple.ZoneRecord
+		for i, n := range gotBody["ns_names"] {
+			data = append(data, dnsimple.ZoneRecord{ID: int64(i + 1), Type: "NS", Content: n})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer srv.Close()
+
+	client := dnsimple.NewClient(srv.Client())
+	client.BaseURL = srv.URL
+	records, err := Update(context.Background(), client, "1010", "example.com", []string{"ns1.b.net", "ns2.b.net"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(gotBody["ns_names"], ","); got != "ns1.b.net,ns2.b.net" {
+		t.Errorf("sent ns_names %q", got)
+	}
+	if got := FromRecords(records); !reflect.DeepEqual(got, []string{"ns1.b.net", "ns2.b.net"}) {
+		t.Errorf("returned %v", got)
+	}
+}
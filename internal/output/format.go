@@ -1,16 +1,76 @@
 package output
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
+	"strings"
 )
 
-// JSON prints the value as indented JSON to stdout.
-func JSON(v interface{}) error {
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(v)
+// Kind names an output format.
+type Kind string
+
+const (
+	KindTable    Kind = "table"
+	KindJSON     Kind = "json"
+	KindYAML     Kind = "yaml"
+	KindCSV      Kind = "csv"
+	KindTSV      Kind = "tsv"
+	KindNDJSON   Kind = "ndjson"
+	KindTemplate Kind = "template"
+	KindJSONPath Kind = "jsonpath"
+)
+
+// Format is a parsed --output value. Arg holds the expression for the
+// template and jsonpath kinds.
+type Format struct {
+	Kind Kind
+	Arg  string
+}
+
+// FormatHelp lists the accepted --output values.
+const FormatHelp = "table|json|yaml|csv|tsv|ndjson|template=<go-template>|jsonpath=<expr>"
+
+// ParseFormat parses an --output value. An empty string means table.
+func ParseFormat(s string) (Format, error) {
+	name, arg, hasArg := strings.Cut(strings.TrimSpace(s), "=")
+	kind := Kind(strings.ToLower(name))
+	switch kind {
+	case "":
+		return Format{Kind: KindTable}, nil
+	case KindTable, KindJSON, KindYAML, KindCSV, KindTSV, KindNDJSON:
+		if hasArg {
+			return Format{}, fmt.Errorf("output format %q takes no argument", name)
+		}
+		return Format{Kind: kind}, nil
+	case "yml":
+		return Format{Kind: KindYAML}, nil
+	case KindTemplate, KindJSONPath:
+		if strings.TrimSpace(arg) == "" {
+			return Format{}, fmt.Errorf("output format %s needs an expression, e.g. %s=...", name, name)
+		}
+		if kind == KindJSONPath {
+			if _, err := parseJSONPath(arg); err != nil {
+				return Format{}, err
+			}
+		}
+		return Format{Kind: kind, Arg: arg}, nil
+	}
+	return Format{}, fmt.Errorf("unknown output format %q (want %s)", s, FormatHelp)
+}
+
+// String returns the format in --output syntax.
+func (f Format) String() string {
+	if f.Arg != "" {
+		return string(f.Kind) + "=" + f.Arg
+	}
+	if f.Kind == "" {
+		return string(KindTable)
+	}
+	return string(f.Kind)
+}
+
+// IsTable reports whether the format is the human-readable table.
+func (f Format) IsTable() bool {
+	return f.Kind == "" || f.Kind == KindTable
 }
 
 // PrintKeyValue prints a styled key-value pair.
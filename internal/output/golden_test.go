@@ -0,0 +1,70 @@
+package output
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dorkitude/simple/internal/ui"
+	"github.com/muesli/termenv"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// golden compares got with testdata/<name>.golden.
+func golden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s mismatch:\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+func TestDisplayModesGolden(t *testing.T) {
+	t.Cleanup(func() {
+		ui.Configure(ui.Options{})
+		lipgloss.SetColorProfile(termenv.Ascii)
+	})
+	view := View[host]{
+		Title:   "🌐 2 hosts",
+		Columns: append(hostColumns, Column[host]{Name: "tags", Header: "Tags", Value: func(h host) string { return "●" }, Style: func(s string) string { return ui.SuccessStyle.Render(s) }}),
+	}
+	renderAll := func() string {
+		out := render(t, "table", func(r *Renderer) error {
+			return List(r, hosts[:1], view)
+		})
+		return out + ui.Success("Zone 'example.com' is fully distributed ✨") + "\n" +
+			ui.Warn("Token expires soon") + "\n" +
+			ui.Err("failed to list domains: 401") + "\n"
+	}
+
+	for _, mode := range []struct {
+		name    string
+		opts    ui.Options
+		profile termenv.Profile
+	}{
+		{"color", ui.Options{}, termenv.TrueColor},
+		{"no-color", ui.Options{NoColor: true}, termenv.TrueColor},
+		{"plain", ui.Options{Plain: true}, termenv.TrueColor},
+	} {
+		t.Run(mode.name, func(t *testing.T) {
+			lipgloss.SetColorProfile(mode.profile)
+			if mode.opts != (ui.Options{}) {
+				ui.Configure(mode.opts)
+			}
+			golden(t, "display-"+mode.name, renderAll())
+			ui.Configure(ui.Options{})
+		})
+	}
+}
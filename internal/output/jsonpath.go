@@ -0,0 +1,145 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a parsed JSONPath expression. The supported subset covers
+// what scripts need: $ (or a leading .), .field, ['field'], [n], [-n],
+// [*] and .* — optionally wrapped in {} kubectl-style.
+type jsonPath struct {
+	expr  string
+	steps []pathStep
+}
+
+type pathStep struct {
+	field    string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func parseJSONPath(expr string) (*jsonPath, error) {
+	s := strings.TrimSpace(expr)
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	s = strings.TrimPrefix(s, "$")
+
+	p := &jsonPath{expr: expr}
+	bad := func(why string) error {
+		return fmt.Errorf("invalid jsonpath %q: %s", expr, why)
+	}
+	for s != "" {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			if strings.HasPrefix(s, ".") {
+				return nil, bad("recursive descent (..) is not supported")
+			}
+			if strings.HasPrefix(s, "*") {
+				p.steps = append(p.steps, pathStep{wildcard: true})
+				s = s[1:]
+				continue
+			}
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			if end == 0 {
+				if s == "" {
+					// A lone "." is the whole document.
+					continue
+				}
+				return nil, bad("empty field name")
+			}
+			p.steps = append(p.steps, pathStep{field: s[:end]})
+			s = s[end:]
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, bad("missing ]")
+			}
+			inner := strings.TrimSpace(s[1:end])
+			s = s[end+1:]
+			switch {
+			case inner == "*":
+				p.steps = append(p.steps, pathStep{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				p.steps = append(p.steps, pathStep{field: inner[1 : len(inner)-1]})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, bad(fmt.Sprintf("unsupported selector [%s]", inner))
+				}
+				p.steps = append(p.steps, pathStep{index: n, isIndex: true})
+			}
+		default:
+			return nil, bad(fmt.Sprintf("unexpected %q", s[0]))
+		}
+	}
+	return p, nil
+}
+
+// eval applies the path to a generic JSON value. Missing fields and
+// out-of-range indexes yield no results rather than an error, so a path
+// over a list skips items that lack the field.
+func (p *jsonPath) eval(root interface{}) ([]interface{}, error) {
+	nodes := []interface{}{root}
+	for _, step := range p.steps {
+		var next []interface{}
+		for _, node := range nodes {
+			switch v := node.(type) {
+			case map[string]interface{}:
+				switch {
+				case step.wildcard:
+					for _, k := range sortedKeys(v) {
+						next = append(next, v[k])
+					}
+				case step.isIndex:
+					return nil, fmt.Errorf("jsonpath %q: cannot index an object", p.expr)
+				default:
+					if e, ok := v[step.field]; ok {
+						next = append(next, e)
+					}
+				}
+			case []interface{}:
+				switch {
+				case step.wildcard:
+					next = append(next, v...)
+				case step.isIndex:
+					i := step.index
+					if i < 0 {
+						i += len(v)
+					}
+					if i >= 0 && i < len(v) {
+						next = append(next, v[i])
+					}
+				default:
+					// .field on a list maps over its items.
+					for _, e := range v {
+						if m, ok := e.(map[string]interface{}); ok {
+							if f, ok := m[step.field]; ok {
+								next = append(next, f)
+							}
+						}
+					}
+				}
+			}
+		}
+		nodes = next
+	}
+	return nodes, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,220 @@
+package output
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type host struct {
+	ID      int64    `json:"id"`
+	Name    string   `json:"name"`
+	Content string   `json:"content"`
+	Tags    []string `json:"tags"`
+}
+
+var hostColumns = []Column[host]{
+	{Name: "name", Header: "Name", Value: func(h host) string { return h.Name }},
+	{Name: "content", Header: "Content", Value: func(h host) string { return h.Content }, OmitEmpty: true},
+}
+
+var hosts = []host{
+	{ID: 1234567, Name: "www", Content: "1.2.3.4", Tags: []string{"a"}},
+	{ID: 2, Name: "txt", Content: "v=spf1, \"quoted\"\ttab\nline"},
+}
+
+func render(t *testing.T, format string, fn func(*Renderer) error) string {
+	t.Helper()
+	f, err := ParseFormat(format)
+	if err != nil {
+		t.Fatalf("ParseFormat(%q): %v", format, err)
+	}
+	var buf bytes.Buffer
+	if err := fn(&Renderer{Format: f, Out: &buf}); err != nil {
+		t.Fatalf("render %s: %v", format, err)
+	}
+	return buf.String()
+}
+
+func renderList(t *testing.T, format string) string {
+	return render(t, format, func(r *Renderer) error {
+		return List(r, hosts, View[host]{Columns: hostColumns})
+	})
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{
+		"":                   {Kind: KindTable},
+		"JSON":               {Kind: KindJSON},
+		"yml":                {Kind: KindYAML},
+		"template={{.name}}": {Kind: KindTemplate, Arg: "{{.name}}"},
+		"jsonpath=$.a=b":     {Kind: KindJSONPath, Arg: "$.a=b"},
+	} {
+		got, err := ParseFormat(in)
+		if err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %+v, %v; want %+v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"xml", "json=1", "template=", "jsonpath=$..a"} {
+		if _, err := ParseFormat(in); err == nil {
+			t.Errorf("ParseFormat(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestListTable(t *testing.T) {
+	out := renderList(t, "table")
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines:\n%s", len(lines), out)
+	}
+	if !strings.Contains(lines[0], "NAME") || !strings.Contains(lines[0], "CONTENT") {
+		t.Errorf("header = %q", lines[0])
+	}
+	if strings.Index(lines[1], "1.2.3.4") != strings.Index(lines[0], "CONTENT") {
+		t.Errorf("columns not aligned:\n%s", out)
+	}
+}
+
+func TestListTableEmpty(t *testing.T) {
+	out := render(t, "table", func(r *Renderer) error {
+		return List(r, []host{}, View[host]{Empty: "No hosts found", Columns: hostColumns})
+	})
+	if !strings.Contains(out, "No hosts found") {
+		t.Errorf("got %q", out)
+	}
+}
+
+func TestListCSVEscapes(t *testing.T) {
+	want := "name,content\nwww,1.2.3.4\ntxt,\"v=spf1, \"\"quoted\"\"\ttab\nline\"\n"
+	if got := renderList(t, "csv"); got != want {
+		t.Errorf("csv:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestListTSVEscapes(t *testing.T) {
+	want := "name\tcontent\nwww\t1.2.3.4\ntxt\tv=spf1, \"quoted\"\\ttab\\nline\n"
+	if got := renderList(t, "tsv"); got != want {
+		t.Errorf("tsv:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestListStructured(t *testing.T) {
+	if got := renderList(t, "ndjson"); strings.Count(got, "\n") != 2 || !strings.HasPrefix(got, `{"id":1234567,`) {
+		t.Errorf("ndjson:\n%s", got)
+	}
+	if got := renderList(t, "yaml"); !strings.Contains(got, "  id: 1234567\n") {
+		t.Errorf("yaml keeps integers whole:\n%s", got)
+	}
+	if got := renderList(t, `template={{range .}}{{.name}}:{{join "," .tags}};{{end}}`); got != "www:a;txt:;\n" {
+		t.Errorf("template = %q", got)
+	}
+	if got := renderList(t, "jsonpath={[*].name}"); got != "www\ntxt\n" {
+		t.Errorf("jsonpath = %q", got)
+	}
+	if got := renderList(t, "jsonpath=$[-1].id"); got != "2\n" {
+		t.Errorf("jsonpath = %q", got)
+	}
+}
+
+func TestItemTableOmitsEmpty(t *testing.T) {
+	out := render(t, "table", func(r *Renderer) error {
+		return Item(r, host{Name: "bare"}, View[host]{Columns: hostColumns})
+	})
+	if !strings.Contains(out, "Name:") || strings.Contains(out, "Content:") {
+		t.Errorf("got:\n%s", out)
+	}
+}
+
+func TestValueCSVFlattens(t *testing.T) {
+	v := map[string]interface{}{
+		"user":    map[string]interface{}{"id": 7, "email": "a@b.c"},
+		"account": nil,
+	}
+	out := render(t, "csv", func(r *Renderer) error {
+		_, err := r.Value(v)
+		return err
+	})
+	if want := "account,user.email,user.id\n,a@b.c,7\n"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+
+	ok, err := (&Renderer{Format: Format{Kind: KindTable}}).Value(v)
+	if ok || err != nil {
+		t.Errorf("table Value = %v, %v; want caller to render", ok, err)
+	}
+}
+
+type rec struct {
+	Type    string
+	Name    string
+	TTL     int
+	Content string
+}
+
+var recColumns = []Column[rec]{
+	{Name: "type", Header: "Type", Value: func(r rec) string { return r.Type }},
+	{Name: "name", Header: "Name", Value: func(r rec) string { return r.Name }},
+	{Name: "ttl", Header: "TTL", Value: func(r rec) string { return strconv.Itoa(r.TTL) }},
+	{Name: "content", Header: "Content", Value: func(r rec) string { return r.Content }, Truncate: 10},
+	{Name: "note", Header: "Note", Wide: true, Value: func(r rec) string { return "n" }},
+}
+
+var recs = []rec{
+	{"TXT", "www", 3600, "v=spf1 include:example.com ~all"},
+	{"A", "www", 60, "1.2.3.4"},
+	{"A", "api", 600, "5.6.7.8"},
+}
+
+func renderRecs(t *testing.T, format string, layout Layout) string {
+	return render(t, format, func(r *Renderer) error {
+		return List(r, recs, View[rec]{Columns: recColumns, Layout: layout})
+	})
+}
+
+func TestLayoutColumnsAndSort(t *testing.T) {
+	got := renderRecs(t, "csv", Layout{Columns: []string{"name", "ttl"}, SortBy: []string{"-name", "ttl"}})
+	if want := "name,ttl\nwww,60\nwww,3600\napi,600\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	// TTLs compare as numbers, not strings.
+	got = renderRecs(t, "csv", Layout{Columns: []string{"ttl"}, SortBy: []string{"ttl"}})
+	if want := "ttl\n60\n600\n3600\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestLayoutUnknownColumn(t *testing.T) {
+	f, _ := ParseFormat("table")
+	err := List(&Renderer{Format: f, Out: &bytes.Buffer{}}, recs, View[rec]{Columns: recColumns, Layout: Layout{SortBy: []string{"bogus"}}})
+	if err == nil || !strings.Contains(err.Error(), "available: type, name, ttl, content, note") {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestLayoutWide(t *testing.T) {
+	narrow := renderRecs(t, "table", Layout{})
+	if strings.Contains(narrow, "NOTE") || !strings.Contains(narrow, "v=spf1 ...") {
+		t.Errorf("default table should hide wide columns and truncate:\n%s", narrow)
+	}
+	wide := renderRecs(t, "table", Layout{Wide: true})
+	if !strings.Contains(wide, "NOTE") || !strings.Contains(wide, "v=spf1 include:example.com ~all") {
+		t.Errorf("wide table should show everything:\n%s", wide)
+	}
+	// CSV is never truncated.
+	if got := renderRecs(t, "csv", Layout{Columns: []string{"content"}}); !strings.Contains(got, "~all") {
+		t.Errorf("csv truncated: %q", got)
+	}
+}
+
+func TestLayoutGroupBy(t *testing.T) {
+	out := renderRecs(t, "table", Layout{GroupBy: "type", SortBy: []string{"name"}})
+	a, txt := strings.Index(out, "Type: A (2)"), strings.Index(out, "Type: TXT (1)")
+	if a < 0 || txt < a {
+		t.Fatalf("missing or misordered group headings:\n%s", out)
+	}
+	if api, www := strings.Index(out, "api"), strings.Index(out, "www"); api > www || www > txt {
+		t.Errorf("rows not sorted within group:\n%s", out)
+	}
+}
@@ -0,0 +1,619 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dorkitude/simple/internal/ui"
+	"gopkg.in/yaml.v3"
+)
+
+// Column is one field of a resource as shown in tables and CSV/TSV.
+type Column[T any] struct {
+	// Name is the machine name used as the CSV/TSV header.
+	Name string
+	// Header labels the column in tables (upper-cased) and detail views.
+	Header string
+	Value  func(T) string
+	// Style decorates a table cell; it never affects CSV/TSV.
+	Style func(cell string) string
+	// OmitEmpty hides the field in detail views when its value is empty.
+	OmitEmpty bool
+	// Wide columns are only shown with --wide or when named in --columns.
+	Wide bool
+	// Truncate shortens table cells to this many characters unless the
+	// layout is wide. CSV/TSV are never truncated.
+	Truncate int
+}
+
+// View describes how a resource renders in table, CSV and TSV output.
+// Structured formats ignore the columns and encode the data itself, but
+// still honour the layout's sorting and grouping.
+type View[T any] struct {
+	Title   string
+	Empty   string
+	Columns []Column[T]
+	Layout  Layout
+}
+
+// Layout is the user's choice of list columns, order and grouping.
+type Layout struct {
+	// Columns names the columns to show, in order. Empty means the
+	// default set.
+	Columns []string
+	// SortBy lists column names to sort by; a leading "-" sorts that key
+	// descending.
+	SortBy []string
+	// GroupBy names a column whose values split the table into sections.
+	GroupBy string
+	Wide    bool
+}
+
+// Renderer writes command results in the selected format.
+type Renderer struct {
+	Format Format
+	Out    io.Writer
+}
+
+// New returns a renderer writing to stdout.
+func New(f Format) *Renderer {
+	return &Renderer{Format: f, Out: os.Stdout}
+}
+
+// List renders a slice of resources, applying the view's layout.
+func List[T any](r *Renderer, items []T, view View[T]) error {
+	if items == nil {
+		items = []T{}
+	}
+	cols, err := view.selectColumns()
+	if err != nil {
+		return err
+	}
+	items, err = view.arrange(items)
+	if err != nil {
+		return err
+	}
+
+	switch r.Format.Kind {
+	case KindCSV, KindTSV:
+		return r.writeDelimited(headerNames(cols), rowsOf(items, cols))
+	case "", KindTable:
+		if len(items) == 0 && view.Empty != "" {
+			fmt.Fprintln(r.Out, ui.Warn(view.Empty))
+			return nil
+		}
+		r.title(view.Title)
+		headers := make([]string, len(cols))
+		for i, c := range cols {
+			headers[i] = strings.ToUpper(c.Header)
+		}
+		rows := make([][]string, len(items))
+		for i, item := range items {
+			row := make([]string, len(cols))
+			for j, c := range cols {
+				row[j] = c.cell(item, view.Layout.Wide)
+			}
+			rows[i] = row
+		}
+
+		var groups []string
+		var group *Column[T]
+		if view.Layout.GroupBy != "" {
+			group, _ = view.column(view.Layout.GroupBy)
+			groups = make([]string, len(items))
+			for i, item := range items {
+				groups[i] = group.Value(item)
+			}
+		}
+		r.writeTable(headers, rows, groups, groupLabel(group))
+		return nil
+	}
+	return r.structured(items)
+}
+
+func groupLabel[T any](c *Column[T]) string {
+	if c == nil {
+		return ""
+	}
+	return c.Header
+}
+
+// ColumnNames lists a view's columns for help text, marking wide ones.
+func ColumnNames[T any](cols []Column[T]) string {
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = c.Name
+		if c.Wide {
+			names[i] += "*"
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+func (v View[T]) column(name string) (*Column[T], error) {
+	for i := range v.Columns {
+		if strings.EqualFold(v.Columns[i].Name, name) {
+			return &v.Columns[i], nil
+		}
+	}
+	names := make([]string, len(v.Columns))
+	for i, c := range v.Columns {
+		names[i] = c.Name
+	}
+	return nil, fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+func (v View[T]) selectColumns() ([]Column[T], error) {
+	if len(v.Layout.Columns) == 0 {
+		cols := make([]Column[T], 0, len(v.Columns))
+		for _, c := range v.Columns {
+			if !c.Wide || v.Layout.Wide {
+				cols = append(cols, c)
+			}
+		}
+		return cols, nil
+	}
+	cols := make([]Column[T], 0, len(v.Layout.Columns))
+	for _, name := range v.Layout.Columns {
+		c, err := v.column(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, *c)
+	}
+	return cols, nil
+}
+
+// arrange returns items sorted by the layout's sort keys and then, stably,
+// by group so each group's rows stay in sort order.
+func (v View[T]) arrange(items []T) ([]T, error) {
+	type key struct {
+		col  *Column[T]
+		desc bool
+	}
+	var keys []key
+	for _, s := range v.Layout.SortBy {
+		s = strings.TrimSpace(s)
+		desc := strings.HasPrefix(s, "-")
+		c, err := v.column(strings.TrimLeft(s, "+-"))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key{col: c, desc: desc})
+	}
+	if v.Layout.GroupBy != "" {
+		c, err := v.column(v.Layout.GroupBy)
+		if err != nil {
+			return nil, err
+		}
+		keys = append([]key{{col: c}}, keys...)
+	}
+	if len(keys) == 0 {
+		return items, nil
+	}
+
+	sorted := append([]T(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		for _, k := range keys {
+			c := compareCells(k.col.Value(sorted[i]), k.col.Value(sorted[j]))
+			if c == 0 {
+				continue
+			}
+			if k.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	return sorted, nil
+}
+
+// compareCells orders numbers numerically and everything else
+// case-insensitively, so TTLs and IDs sort the way people expect.
+func compareCells(a, b string) int {
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+	if c := strings.Compare(strings.ToLower(a), strings.ToLower(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// Item renders a single resource. Tables show it as label/value pairs.
+func Item[T any](r *Renderer, item T, view View[T]) error {
+	switch r.Format.Kind {
+	case KindCSV, KindTSV:
+		return r.writeDelimited(headerNames(view.Columns), rowsOf([]T{item}, view.Columns))
+	case "", KindTable:
+		r.title(view.Title)
+		for _, c := range view.Columns {
+			v := c.Value(item)
+			if v == "" && c.OmitEmpty {
+				continue
+			}
+			if c.Style != nil {
+				v = c.Style(v)
+			}
+			fmt.Fprintf(r.Out, "  %-14s %s\n", c.Header+":", v)
+		}
+		return nil
+	}
+	return r.structured(item)
+}
+
+// Value renders v in any machine format and reports whether it did. For
+// the table format it does nothing, leaving the caller to print its own
+// human-readable output. CSV/TSV columns come from v's JSON fields.
+func (r *Renderer) Value(v interface{}) (bool, error) {
+	switch r.Format.Kind {
+	case "", KindTable:
+		return false, nil
+	case KindCSV, KindTSV:
+		g, err := toGeneric(v)
+		if err != nil {
+			return true, err
+		}
+		header, rows := flattenRows(g)
+		return true, r.writeDelimited(header, rows)
+	}
+	return true, r.structured(v)
+}
+
+func (r *Renderer) structured(v interface{}) error {
+	switch r.Format.Kind {
+	case KindJSON:
+		enc := json.NewEncoder(r.Out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case KindNDJSON:
+		enc := json.NewEncoder(r.Out)
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return enc.Encode(v)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if err := enc.Encode(rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	case KindYAML:
+		g, err := toGeneric(v)
+		if err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(r.Out)
+		enc.SetIndent(2)
+		if err := enc.Encode(g); err != nil {
+			return err
+		}
+		return enc.Close()
+	case KindTemplate:
+		return r.template(v)
+	case KindJSONPath:
+		return r.jsonPath(v)
+	}
+	return fmt.Errorf("unsupported output format %q", r.Format)
+}
+
+func (r *Renderer) template(v interface{}) error {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(r.Format.Arg)
+	if err != nil {
+		return fmt.Errorf("invalid output template: %w", err)
+	}
+	g, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, g); err != nil {
+		return fmt.Errorf("failed to execute output template: %w", err)
+	}
+	return writeLine(r.Out, buf.String())
+}
+
+func (r *Renderer) jsonPath(v interface{}) error {
+	path, err := parseJSONPath(r.Format.Arg)
+	if err != nil {
+		return err
+	}
+	g, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+	results, err := path.eval(g)
+	if err != nil {
+		return err
+	}
+	lines := make([]string, 0, len(results))
+	for _, res := range results {
+		lines = append(lines, scalarString(res))
+	}
+	return writeLine(r.Out, strings.Join(lines, "\n"))
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join": func(sep string, v interface{}) string {
+		list, _ := v.([]interface{})
+		parts := make([]string, len(list))
+		for i, e := range list {
+			parts[i] = scalarString(e)
+		}
+		return strings.Join(parts, sep)
+	},
+}
+
+// writeLine writes s, adding a trailing newline so shell pipelines see a
+// complete last line.
+func writeLine(w io.Writer, s string) error {
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+func (r *Renderer) title(title string) {
+	if title == "" {
+		return
+	}
+	fmt.Fprintln(r.Out, ui.Title(title))
+	fmt.Fprintln(r.Out)
+}
+
+// writeTable aligns cells by their display width, so styled and wide
+// characters line up. When groups is set it holds each row's group value
+// and a heading is printed wherever it changes.
+func (r *Renderer) writeTable(headers []string, rows [][]string, groups []string, groupHeader string) {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = lipgloss.Width(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	line := func(cells []string, style func(string) string) {
+		var b strings.Builder
+		b.WriteString("  ")
+		for i, cell := range cells {
+			if style != nil {
+				cell = style(cell)
+			}
+			b.WriteString(cell)
+			if i < len(cells)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-lipgloss.Width(cell)+2))
+			}
+		}
+		fmt.Fprintln(r.Out, strings.TrimRight(b.String(), " "))
+	}
+
+	line(headers, func(s string) string { return ui.SubtleStyle.Render(s) })
+	for i, row := range rows {
+		if groups != nil && (i == 0 || groups[i] != groups[i-1]) {
+			n := 0
+			for _, g := range groups[i:] {
+				if g != groups[i] {
+					break
+				}
+				n++
+			}
+			value := groups[i]
+			if value == "" {
+				value = "(none)"
+			}
+			fmt.Fprintln(r.Out)
+			fmt.Fprintf(r.Out, "  %s %s\n",
+				ui.AccentStyle.Render(groupHeader+": "+value),
+				ui.SubtleStyle.Render(fmt.Sprintf("(%d)", n)))
+		}
+		line(row, nil)
+	}
+}
+
+func (r *Renderer) writeDelimited(header []string, rows [][]string) error {
+	if r.Format.Kind == KindTSV {
+		var b strings.Builder
+		for _, row := range append([][]string{header}, rows...) {
+			for i, cell := range row {
+				if i > 0 {
+					b.WriteByte('\t')
+				}
+				b.WriteString(escapeTSV(cell))
+			}
+			b.WriteByte('\n')
+		}
+		_, err := io.WriteString(r.Out, b.String())
+		return err
+	}
+
+	w := csv.NewWriter(r.Out)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// truncate shortens s to n characters, ending in "..." when cut.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n || n <= 3 {
+		return s
+	}
+	return string(runes[:n-3]) + "..."
+}
+
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+func escapeTSV(s string) string {
+	return tsvEscaper.Replace(s)
+}
+
+func (c Column[T]) cell(item T, wide bool) string {
+	v := c.Value(item)
+	if c.Truncate > 0 && !wide {
+		v = truncate(v, c.Truncate)
+	}
+	if c.Style != nil {
+		return c.Style(v)
+	}
+	return v
+}
+
+func headerNames[T any](cols []Column[T]) []string {
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func rowsOf[T any](items []T, cols []Column[T]) [][]string {
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		row := make([]string, len(cols))
+		for j, c := range cols {
+			row[j] = c.Value(item)
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// toGeneric round-trips v through JSON so field names follow its JSON tags
+// and every format sees the same shape. Whole numbers stay integers.
+func toGeneric(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var g interface{}
+	if err := dec.Decode(&g); err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+	return normalizeNumbers(g), nil
+}
+
+func normalizeNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalizeNumbers(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalizeNumbers(e)
+		}
+	}
+	return v
+}
+
+// flattenRows turns a generic object or list of objects into CSV rows.
+// Nested objects become dotted columns; arrays are kept as JSON.
+func flattenRows(g interface{}) ([]string, [][]string) {
+	items, ok := g.([]interface{})
+	if !ok {
+		items = []interface{}{g}
+	}
+
+	flat := make([]map[string]string, len(items))
+	seen := map[string]bool{}
+	var header []string
+	for i, item := range items {
+		flat[i] = map[string]string{}
+		flatten("", item, flat[i])
+		for k := range flat[i] {
+			if !seen[k] {
+				seen[k] = true
+				header = append(header, k)
+			}
+		}
+	}
+	sort.Strings(header)
+
+	rows := make([][]string, len(flat))
+	for i, m := range flat {
+		row := make([]string, len(header))
+		for j, k := range header {
+			row[j] = m[k]
+		}
+		rows[i] = row
+	}
+	return header, rows
+}
+
+func flatten(prefix string, v interface{}, into map[string]string) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		key := prefix
+		if key == "" {
+			key = "value"
+		}
+		into[key] = scalarString(v)
+		return
+	}
+	for k, e := range m {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+		flatten(k, e, into)
+	}
+}
+
+// scalarString formats a generic value for plain-text output: strings
+// raw, null empty, objects and arrays as compact JSON.
+func scalarString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -0,0 +1,390 @@
+// Package recipes loads YAML record recipes and renders them into zone
+// records. Unlike DNSimple templates, recipes take typed parameters, such as
+// a verification token, and live on the local machine: built-in recipes are
+// embedded in the binary and user recipes are read from a directory.
+package recipes
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed builtin/*.yaml
+var builtinFS embed.FS
+
+// SourceBuiltin is the Source of recipes embedded in the binary.
+const SourceBuiltin = "builtin"
+
+// Parameter types.
+const (
+	TypeString   = "string"
+	TypeInt      = "int"
+	TypeBool     = "bool"
+	TypeHostname = "hostname"
+	TypeIPv4     = "ipv4"
+	TypeIPv6     = "ipv6"
+)
+
+// paramTypes lists the valid parameter types, in the order errors name them.
+var paramTypes = []string{TypeString, TypeInt, TypeBool, TypeHostname, TypeIPv4, TypeIPv6}
+
+// Recipe is a set of records to add to a zone. Record names and contents
+// are Go templates that see the zone as .zone and each parameter by name.
+type Recipe struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description" json:"description"`
+	Params      []Param  `yaml:"params" json:"params"`
+	Records     []Record `yaml:"records" json:"records"`
+	// Source is SourceBuiltin or the file the recipe was read from.
+	Source string `yaml:"-" json:"source"`
+}
+
+// Param is a value given to a recipe with --param name=value.
+type Param struct {
+	Name        string `yaml:"name" json:"name"`
+	Type        string `yaml:"type" json:"type"`
+	Description string `yaml:"description" json:"description"`
+	Required    bool   `yaml:"required" json:"required"`
+	Default     string `yaml:"default" json:"default,omitempty"`
+}
+
+// Record is one record a recipe creates. A record whose content renders
+// empty is skipped, so optional parameters can leave records out.
+type Record struct {
+	Type     string `yaml:"type" json:"type"`
+	Name     string `yaml:"name" json:"name"`
+	Content  string `yaml:"content" json:"content"`
+	TTL      int    `yaml:"ttl" json:"ttl,omitempty"`
+	Priority int    `yaml:"priority" json:"priority,omitempty"`
+}
+
+var funcs = template.FuncMap{
+	// dashed turns example.com into example-com, as some providers name
+	// their per-domain hosts.
+	"dashed": func(s string) string { return strings.ReplaceAll(s, ".", "-") },
+	"lower":  strings.ToLower,
+}
+
+// Builtin returns the recipes embedded in the binary, sorted by name.
+func Builtin() ([]Recipe, error) {
+	return loadFS(builtinFS, "builtin", SourceBuiltin)
+}
+
+// LoadDir reads every .yaml and .yml file in dir. A missing directory holds
+// no recipes.
+func LoadDir(dir string) ([]Recipe, error) {
+	list, err := loadFS(os.DirFS(dir), ".", dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return list, err
+}
+
+// UserDir is where user recipes live: the recipes directory under the
+// config directory.
+func UserDir() (string, error) {
+	dir, err := config.ResolveConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "recipes"), nil
+}
+
+// Load returns the built-in recipes and those in dir, sorted by name. A
+// recipe in dir replaces a built-in one of the same name.
+func Load(dir string) ([]Recipe, error) {
+	builtin, err := Builtin()
+	if err != nil {
+		return nil, err
+	}
+	user, err := LoadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]Recipe{}
+	for _, r := range append(builtin, user...) {
+		byName[r.Name] = r
+	}
+	out := make([]Recipe, 0, len(byName))
+	for _, r := range byName {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// Find returns the recipe called name.
+func Find(list []Recipe, name string) (Recipe, error) {
+	names := make([]string, 0, len(list))
+	for _, r := range list {
+		if r.Name == name {
+			return r, nil
+		}
+		names = append(names, r.Name)
+	}
+	return Recipe{}, fmt.Errorf("no recipe named %q (have: %s)", name, strings.Join(names, ", "))
+}
+
+func loadFS(fsys fs.FS, dir, source string) ([]Recipe, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []Recipe
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, filepath.ToSlash(filepath.Join(dir, e.Name())))
+		if err != nil {
+			return nil, err
+		}
+		src := source
+		if source != SourceBuiltin {
+			src = filepath.Join(source, e.Name())
+		}
+		r, err := Parse(data, strings.TrimSuffix(e.Name(), ext))
+		if err != nil {
+			return nil, fmt.Errorf("recipe %s: %w", src, err)
+		}
+		r.Source = src
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// Parse reads one recipe. name is used when the YAML does not set one.
+func Parse(data []byte, name string) (Recipe, error) {
+	var r Recipe
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&r); err != nil {
+		return Recipe{}, err
+	}
+	if r.Name == "" {
+		r.Name = name
+	}
+	return r, r.validate()
+}
+
+func (r *Recipe) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("missing name")
+	}
+	if len(r.Records) == 0 {
+		return fmt.Errorf("no records")
+	}
+	seen := map[string]bool{}
+	for i := range r.Params {
+		p := &r.Params[i]
+		if !isIdentifier(p.Name) || p.Name == "zone" {
+			return fmt.Errorf("parameter %q: names are letters, digits and _, and zone is reserved", p.Name)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("parameter %s is declared twice", p.Name)
+		}
+		seen[p.Name] = true
+		if p.Type == "" {
+			p.Type = TypeString
+		}
+		if !slices.Contains(paramTypes, p.Type) {
+			return fmt.Errorf("parameter %s: unknown type %q (use %s)", p.Name, p.Type, strings.Join(paramTypes, ", "))
+		}
+		if err := checkValue(p.Type, p.Default); p.Default != "" && err != nil {
+			return fmt.Errorf("parameter %s: default: %w", p.Name, err)
+		}
+	}
+	for i, rec := range r.Records {
+		if rec.Type == "" {
+			return fmt.Errorf("record %d: missing type", i+1)
+		}
+		for _, text := range []string{rec.Name, rec.Content} {
+			if _, err := template.New("").Funcs(funcs).Parse(text); err != nil {
+				return fmt.Errorf("record %d: %w", i+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseParams turns name=value pairs into a map.
+func ParseParams(pairs []string) (map[string]string, error) {
+	out := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		name, value, ok := strings.Cut(p, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid parameter %q: use name=value", p)
+		}
+		out[strings.TrimSpace(name)] = value
+	}
+	return out, nil
+}
+
+// Render checks values against the recipe's parameters and returns the
+// records it creates in zone.
+func Render(r Recipe, zone string, values map[string]string) ([]dnsimple.ZoneRecord, error) {
+	declared := make([]string, 0, len(r.Params))
+	data := map[string]interface{}{"zone": zone}
+	for _, p := range r.Params {
+		declared = append(declared, p.Name)
+		v, ok := values[p.Name]
+		if !ok || v == "" {
+			v = p.Default
+		}
+		if v == "" {
+			if p.Required {
+				return nil, fmt.Errorf("parameter %s is required", p.Name)
+			}
+			data[p.Name] = zeroValue(p.Type)
+			continue
+		}
+		if err := checkValue(p.Type, v); err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		data[p.Name] = typedValue(p.Type, v)
+	}
+	for name := range values {
+		if _, ok := data[name]; !ok || name == "zone" {
+			return nil, fmt.Errorf("recipe %s has no parameter %q (have: %s)", r.Name, name, strings.Join(declared, ", "))
+		}
+	}
+
+	out := make([]dnsimple.ZoneRecord, 0, len(r.Records))
+	for i, rec := range r.Records {
+		name, err := execute(rec.Name, data)
+		if err != nil {
+			return nil, fmt.Errorf("record %d name: %w", i+1, err)
+		}
+		content, err := execute(rec.Content, data)
+		if err != nil {
+			return nil, fmt.Errorf("record %d content: %w", i+1, err)
+		}
+		if content == "" {
+			continue
+		}
+		if name == "@" {
+			name = ""
+		}
+		out = append(out, dnsimple.ZoneRecord{
+			Type:     strings.ToUpper(rec.Type),
+			Name:     name,
+			Content:  content,
+			TTL:      rec.TTL,
+			Priority: rec.Priority,
+		})
+	}
+	return out, nil
+}
+
+func execute(text string, data map[string]interface{}) (string, error) {
+	t, err := template.New("").Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+func checkValue(typ, v string) error {
+	switch typ {
+	case TypeString:
+		return nil
+	case TypeInt:
+		if _, err := strconv.Atoi(v); err != nil {
+			return fmt.Errorf("%q is not a whole number", v)
+		}
+	case TypeBool:
+		if _, err := strconv.ParseBool(v); err != nil {
+			return fmt.Errorf("%q is not true or false", v)
+		}
+	case TypeHostname:
+		if !isHostname(v) {
+			return fmt.Errorf("%q is not a host name", v)
+		}
+	case TypeIPv4:
+		if ip := net.ParseIP(v); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("%q is not an IPv4 address", v)
+		}
+	case TypeIPv6:
+		if ip := net.ParseIP(v); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("%q is not an IPv6 address", v)
+		}
+	default:
+		return fmt.Errorf("unknown type %q", typ)
+	}
+	return nil
+}
+
+func typedValue(typ, v string) interface{} {
+	switch typ {
+	case TypeInt:
+		n, _ := strconv.Atoi(v)
+		return n
+	case TypeBool:
+		b, _ := strconv.ParseBool(v)
+		return b
+	case TypeHostname:
+		return strings.TrimSuffix(strings.ToLower(v), ".")
+	}
+	return v
+}
+
+func zeroValue(typ string) interface{} {
+	switch typ {
+	case TypeInt:
+		return 0
+	case TypeBool:
+		return false
+	}
+	return ""
+}
+
+func isIdentifier(s string) bool {
+	if s == "" || s[0] >= '0' && s[0] <= '9' {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(strings.ToLower(s), ".")
+	if s == "" || len(s) > 253 || net.ParseIP(s) != nil {
+		return false
+	}
+	for _, l := range strings.Split(s, ".") {
+		if l == "" || len(l) > 63 || l[0] == '-' || l[len(l)-1] == '-' {
+			return false
+		}
+		for _, r := range l {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
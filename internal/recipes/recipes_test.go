@@ -0,0 +1,131 @@
+package recipes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinRecipesRender(t *testing.T) {
+	list, err := Builtin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, r := range list {
+		names = append(names, r.Name)
+		if r.Source != SourceBuiltin {
+			t.Errorf("%s: source %q", r.Name, r.Source)
+		}
+	}
+	if got := strings.Join(names, ","); got != "github-pages,google-workspace,m365,vercel" {
+		t.Fatalf("builtin recipes = %s", got)
+	}
+
+	m365, _ := Find(list, "m365")
+	if _, err := Render(m365, "example.com", nil); err == nil || !strings.Contains(err.Error(), "verification is required") {
+		t.Errorf("missing required parameter: %v", err)
+	}
+	records, err := Render(m365, "example.com", map[string]string{"verification": "ms123", "tenant": "contoso"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 6 {
+		t.Fatalf("m365 with a tenant renders %d records, want 6", len(records))
+	}
+	if r := records[1]; r.Type != "MX" || r.Name != "" || r.Content != "example-com.mail.protection.outlook.com" {
+		t.Errorf("MX = %+v", r)
+	}
+	if r := records[4]; r.Content != "selector1-example-com._domainkey.contoso.onmicrosoft.com" {
+		t.Errorf("DKIM selector = %+v", r)
+	}
+
+	// Optional parameters left out drop their records.
+	gw, _ := Find(list, "google-workspace")
+	records, err = Render(gw, "example.com", map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Errorf("google-workspace without options renders %+v", records)
+	}
+	if _, err := Render(gw, "example.com", map[string]string{"token": "x"}); err == nil || !strings.Contains(err.Error(), `no parameter "token"`) {
+		t.Errorf("unknown parameter: %v", err)
+	}
+}
+
+func TestLoadUserRecipes(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("vercel.yaml", `
+description: Our own Vercel setup
+records:
+  - {type: cname, name: www, content: "cname.vercel-dns.com"}
+`)
+	write("mail.yml", `
+name: relay
+params:
+  - {name: host, type: hostname, required: true}
+  - {name: weight, type: int, default: "10"}
+records:
+  - {type: MX, name: "@", content: "{{ .host }}", priority: 5}
+  - {type: TXT, name: relay, content: "weight={{ .weight }}"}
+`)
+	write("notes.txt", "not a recipe")
+
+	list, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vercel, err := Find(list, "vercel")
+	if err != nil || vercel.Description != "Our own Vercel setup" || vercel.Source != filepath.Join(dir, "vercel.yaml") {
+		t.Fatalf("user recipe did not replace the builtin: %+v, %v", vercel, err)
+	}
+	relay, err := Find(list, "relay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Render(relay, "example.com", map[string]string{"host": "203.0.113.5"}); err == nil {
+		t.Error("address accepted as a hostname parameter")
+	}
+	records, err := Render(relay, "example.com", map[string]string{"host": "Relay.Example.net."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records[0].Content != "relay.example.net" || records[1].Content != "weight=10" {
+		t.Errorf("rendered %+v", records)
+	}
+
+	write("broken.yaml", "records:\n  - {type: A, content: \"{{ .ip \"}\n")
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "broken.yaml") {
+		t.Errorf("broken recipe: %v", err)
+	}
+}
+
+func TestParseParams(t *testing.T) {
+	got, err := ParseParams([]string{"verification=abc=123", "tenant=contoso"})
+	if err != nil || got["verification"] != "abc=123" || got["tenant"] != "contoso" {
+		t.Errorf("ParseParams = %v, %v", got, err)
+	}
+	if _, err := ParseParams([]string{"verification"}); err == nil {
+		t.Error("pair without = accepted")
+	}
+}
+
+func TestParseRejectsUnknownParameterType(t *testing.T) {
+	_, err := Parse([]byte(`
+params:
+  - {name: addr, type: ipaddr}
+records:
+  - {type: A, name: "@", content: "{{ .addr }}"}
+`), "typo")
+	if err == nil || !strings.Contains(err.Error(), `unknown type "ipaddr"`) {
+		t.Errorf("Parse = %v, want an unknown type error", err)
+	}
+}
@@ -0,0 +1,190 @@
+package registrar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+// Billable registrar actions.
+const (
+	ActionRegister = "register"
+	ActionRenew    = "renew"
+	ActionTransfer = "transfer"
+)
+
+// MaxPeriod is the longest registration term, in years, registries allow.
+const MaxPeriod = 10
+
+// Quote is the cost of a billable action, shown before it is confirmed.
+type Quote struct {
+	Domain  string  `json:"domain"`
+	Action  string  `json:"action"`
+	Period  int     `json:"period"`
+	Premium bool    `json:"premium"`
+	Price   float64 `json:"price"`
+	Total   float64 `json:"total"`
+}
+
+// NewQuote prices action for period years from the domain's prices.
+// Registrations and transfers are for one year.
+func NewQuote(action string, period int, p dnsimple.DomainPrice) (Quote, error) {
+	q := Quote{Domain: p.Domain, Action: action, Period: 1, Premium: p.Premium}
+	switch action {
+	case ActionRegister:
+		q.Price = p.RegistrationPrice
+	case ActionTransfer:
+		q.Price = p.TransferPrice
+	case ActionRenew:
+		if period < 1 || period > MaxPeriod {
+			return Quote{}, fmt.Errorf("period must be 1 to %d years", MaxPeriod)
+		}
+		q.Period = period
+		q.Price = p.RenewalPrice
+	default:
+		return Quote{}, fmt.Errorf("unknown registrar action %q", action)
+	}
+	q.Total = q.Price * float64(q.Period)
+	return q, nil
+}
+
+// PremiumPrice is the price the API wants echoed back to confirm a premium
+// order, or "" when the domain is not premium.
+func (q Quote) PremiumPrice() string {
+	if !q.Premium {
+		return ""
+	}
+	return strconv.FormatFloat(q.Price, 'f', 2, 64)
+}
+
+// Approval is how a billable order gets the go-ahead: --dry-run stops after
+// the preview, --confirm names the domain up front, and otherwise the user
+// types the domain back at a terminal.
+type Approval struct {
+	DryRun bool
+	// Confirm is the domain given with --confirm, if any.
+	Confirm string
+	// Interactive reports whether Ask can reach a user.
+	Interactive bool
+	// Ask shows question and returns what the user typed when asked for
+	// want.
+	Ask func(question, want string) (string, error)
+}
+
+// Approve reports whether the order for q may be placed. A dry run is not
+// approved and is not an error. A --confirm that does not name the domain
+// is an error, and so is a run that can neither use --confirm nor ask. An
+// answer other than the domain name cancels with context.Canceled.
+func (a Approval) Approve(q Quote) (bool, error) {
+	switch {
+	case a.DryRun:
+		return false, nil
+	case a.Confirm == q.Domain:
+		return true, nil
+	case a.Confirm != "":
+		return false, fmt.Errorf("--confirm %s does not match %s", a.Confirm, q.Domain)
+	case !a.Interactive || a.Ask == nil:
+		return false, fmt.Errorf("refusing to %s %s without confirmation; pass --confirm %s", q.Action, q.Domain, q.Domain)
+	}
+	answer, err := a.Ask(fmt.Sprintf("This %s costs $%.2f.", q.Action, q.Total), q.Domain)
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(answer) != q.Domain {
+		return false, context.Canceled
+	}
+	return true, nil
+}
+
+// Order calls place once a approves the order for q, and never otherwise.
+// It reports whether the order was placed.
+func Order(q Quote, a Approval, place func() error) (bool, error) {
+	ok, err := a.Approve(q)
+	if !ok || err != nil {
+		return false, err
+	}
+	return true, place()
+}
+
+// Name lower-cases and checks a full domain name given for an order.
+func Name(raw string) (string, error) {
+	name := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(raw)), ".")
+	if err := checkName(name); err != nil {
+		return "", fmt.Errorf("%q is not a valid domain name: %w", raw, err)
+	}
+	if !strings.Contains(name, ".") {
+		return "", fmt.Errorf("%q has no TLD", raw)
+	}
+	return name, nil
+}
+
+// TLD returns the part of a registrable name after its first label, such as
+// "co.uk" for "example.co.uk".
+func TLD(name string) string {
+	_, tld, _ := strings.Cut(name, ".")
+	return tld
+}
+
+// ParseAttributes turns name=value pairs into extended attributes.
+func ParseAttributes(pairs []string) (map[string]string, error) {
+	out := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		name, value, ok := strings.Cut(p, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid extended attribute %q: use name=value", p)
+		}
+		out[name] = strings.TrimSpace(value)
+	}
+	return out, nil
+}
+
+// CheckAttributes checks values against the extended attributes a TLD
+// defines: required ones must be given, unknown ones are refused, and
+// attributes with a fixed list of options take one of them.
+func CheckAttributes(tld string, defs []dnsimple.TldExtendedAttribute, values map[string]string) error {
+	known := make(map[string]bool, len(defs))
+	var missing []string
+	for _, d := range defs {
+		known[d.Name] = true
+		v, ok := values[d.Name]
+		if !ok || v == "" {
+			if d.Required {
+				missing = append(missing, d.Name)
+			}
+			continue
+		}
+		if len(d.Options) == 0 {
+			continue
+		}
+		allowed := make([]string, 0, len(d.Options))
+		for _, o := range d.Options {
+			if o.Value == v {
+				allowed = nil
+				break
+			}
+			allowed = append(allowed, o.Value)
+		}
+		if allowed != nil {
+			return fmt.Errorf("extended attribute %s must be one of %s", d.Name, strings.Join(allowed, ", "))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf(".%s requires the extended attributes %s", tld, strings.Join(missing, ", "))
+	}
+	var unknown []string
+	for name := range values {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf(".%s has no extended attributes named %s", tld, strings.Join(unknown, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Package registrar checks whether domain names can be registered and what
+// they cost, for many candidate names at once, and prices and validates
+// registration, renewal and transfer orders before they are placed.
+package registrar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+// DefaultTLDs are tried for a bare label when no TLDs are given.
+var DefaultTLDs = []string{"com", "net", "org", "io", "dev", "app"}
+
+// workers bounds the lookups in flight at once.
+const workers = 8
+
+// Lookup answers availability and price questions about one name.
+type Lookup interface {
+	CheckDomain(ctx context.Context, name string) (*dnsimple.DomainCheck, error)
+	GetDomainPrices(ctx context.Context, name string) (*dnsimple.DomainPrice, error)
+}
+
+// NewLookup adapts an API client to Lookup for one account.
+func NewLookup(client *dnsimple.Client, accountID string) Lookup {
+	return apiLookup{client: client, accountID: accountID}
+}
+
+type apiLookup struct {
+	client    *dnsimple.Client
+	accountID string
+}
+
+func (l apiLookup) CheckDomain(ctx context.Context, name string) (*dnsimple.DomainCheck, error) {
+	resp, err := l.client.Registrar.CheckDomain(ctx, l.accountID, name)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (l apiLookup) GetDomainPrices(ctx context.Context, name string) (*dnsimple.DomainPrice, error) {
+	resp, err := l.client.Registrar.GetDomainPrices(ctx, l.accountID, name)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// Result is what is known about one candidate name. Prices are zero when
+// they could not be fetched, in which case Error says why.
+type Result struct {
+	Domain            string  `json:"domain"`
+	Available         bool    `json:"available"`
+	Premium           bool    `json:"premium"`
+	RegistrationPrice float64 `json:"registration_price,omitempty"`
+	RenewalPrice      float64 `json:"renewal_price,omitempty"`
+	TransferPrice     float64 `json:"transfer_price,omitempty"`
+	Error             string  `json:"error,omitempty"`
+}
+
+// Candidates turns names and bare labels into the domains to check. A bare
+// label such as "acme" is tried with every TLD in tlds, or DefaultTLDs when
+// tlds is empty. Names are lower-cased and listed once, in order.
+func Candidates(args, tlds []string) ([]string, error) {
+	if len(tlds) == 0 {
+		tlds = DefaultTLDs
+	}
+	var out []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+	for _, raw := range args {
+		name := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(raw)), ".")
+		if err := checkName(name); err != nil {
+			return nil, fmt.Errorf("%q is not a valid domain name: %w", raw, err)
+		}
+		if strings.Contains(name, ".") {
+			add(name)
+			continue
+		}
+		for _, tld := range tlds {
+			tld = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(tld)), ".")
+			if err := checkName(tld); err != nil {
+				return nil, fmt.Errorf("%q is not a valid TLD: %w", tld, err)
+			}
+			add(name + "." + tld)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("give at least one name to check")
+	}
+	return out, nil
+}
+
+func checkName(n string) error {
+	if n == "" {
+		return fmt.Errorf("empty name")
+	}
+	for _, l := range strings.Split(n, ".") {
+		if l == "" || len(l) > 63 {
+			return fmt.Errorf("labels must be 1 to 63 characters")
+		}
+		if l[0] == '-' || l[len(l)-1] == '-' {
+			return fmt.Errorf("labels cannot start or end with a hyphen")
+		}
+		for _, r := range l {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+				return fmt.Errorf("unexpected character %q", r)
+			}
+		}
+	}
+	return nil
+}
+
+// Check looks up availability and prices for every name concurrently and
+// returns the results in the order of names. A failed lookup is reported in
+// its Result rather than failing the whole check; only a cancelled ctx
+// returns an error.
+func Check(ctx context.Context, l Lookup, names []string) ([]Result, error) {
+	results := make([]Result, len(names))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(names); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = checkOne(ctx, l, names[i])
+			}
+		}()
+	}
+	for i := range names {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func checkOne(ctx context.Context, l Lookup, name string) Result {
+	r := Result{Domain: name}
+	check, err := l.CheckDomain(ctx, name)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	r.Available, r.Premium = check.Available, check.Premium
+
+	price, err := l.GetDomainPrices(ctx, name)
+	if err != nil {
+		r.Error = "prices: " + err.Error()
+		return r
+	}
+	r.Premium = r.Premium || price.Premium
+	r.RegistrationPrice = price.RegistrationPrice
+	r.RenewalPrice = price.RenewalPrice
+	r.TransferPrice = price.TransferPrice
+	return r
+}
@@ -0,0 +1,230 @@
+package registrar
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+func TestCandidates(t *testing.T) {
+	got, err := Candidates([]string{"Acme", "acme.io.", "acme.dev"}, []string{"io", ".DEV"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"acme.io", "acme.dev"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Candidates = %v, want %v", got, want)
+	}
+
+	got, err = Candidates([]string{"acme"}, nil)
+	if err != nil || len(got) != len(DefaultTLDs) || got[0] != "acme.com" {
+		t.Errorf("bare label without TLDs = %v, %v", got, err)
+	}
+
+	for _, bad := range [][]string{nil, {""}, {"-acme.com"}, {"acme com"}, {"acme..com"}} {
+		if _, err := Candidates(bad, nil); err == nil {
+			t.Errorf("Candidates(%q) accepted", bad)
+		}
+	}
+	if _, err := Candidates([]string{"acme"}, []string{"c_m"}); err == nil {
+		t.Error("invalid TLD accepted")
+	}
+}
+
+type fakeLookup struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (f *fakeLookup) CheckDomain(ctx context.Context, name string) (*dnsimple.DomainCheck, error) {
+	f.mu.Lock()
+	f.inFlight++
+	f.peak = max(f.peak, f.inFlight)
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.inFlight--
+		f.mu.Unlock()
+	}()
+	if strings.HasSuffix(name, ".invalid") {
+		return nil, errors.New("TLD .invalid is not supported")
+	}
+	return &dnsimple.DomainCheck{Domain: name, Available: strings.HasPrefix(name, "free")}, nil
+}
+
+func (f *fakeLookup) GetDomainPrices(ctx context.Context, name string) (*dnsimple.DomainPrice, error) {
+	if strings.HasPrefix(name, "noprice") {
+		return nil, errors.New("unavailable")
+	}
+	return &dnsimple.DomainPrice{Domain: name, Premium: strings.HasPrefix(name, "free1"), RegistrationPrice: 14, RenewalPrice: 15, TransferPrice: 16}, nil
+}
+
+func TestCheckKeepsOrderAndReportsFailures(t *testing.T) {
+	names := []string{"free.com", "taken.com", "bad.invalid", "noprice.com"}
+	for i := 0; i < 20; i++ {
+		names = append(names, "free"+strings.Repeat("1", i+1)+".com")
+	}
+	l := &fakeLookup{}
+	results, err := Check(context.Background(), l, names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(names) {
+		t.Fatalf("got %d results for %d names", len(results), len(names))
+	}
+	for i, r := range results {
+		if r.Domain != names[i] {
+			t.Fatalf("result %d is %s, want %s", i, r.Domain, names[i])
+		}
+	}
+	if r := results[0]; !r.Available || r.Premium || r.RegistrationPrice != 14 || r.TransferPrice != 16 || r.Error != "" {
+		t.Errorf("free.com = %+v", r)
+	}
+	if r := results[1]; r.Available || r.RenewalPrice != 15 {
+		t.Errorf("taken.com = %+v", r)
+	}
+	if r := results[2]; !strings.Contains(r.Error, "not supported") {
+		t.Errorf("bad.invalid = %+v", r)
+	}
+	if r := results[3]; r.RegistrationPrice != 0 || !strings.HasPrefix(r.Error, "prices:") {
+		t.Errorf("noprice.com = %+v", r)
+	}
+	if !results[4].Premium {
+		t.Errorf("premium price not reported: %+v", results[4])
+	}
+	if l.peak > workers {
+		t.Errorf("%d lookups in flight, want at most %d", l.peak, workers)
+	}
+}
+
+func TestCheckCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Check(ctx, &fakeLookup{}, []string{"a.com", "b.com"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Check after cancel = %v", err)
+	}
+}
+
+func TestNewQuote(t *testing.T) {
+	p := dnsimple.DomainPrice{Domain: "acme.io", RegistrationPrice: 49, RenewalPrice: 50, TransferPrice: 48}
+	q, err := NewQuote(ActionRenew, 3, p)
+	if err != nil || q.Price != 50 || q.Total != 150 || q.PremiumPrice() != "" {
+		t.Errorf("renew quote = %+v, %v", q, err)
+	}
+	q, err = NewQuote(ActionTransfer, 0, p)
+	if err != nil || q.Period != 1 || q.Total != 48 {
+		t.Errorf("transfer quote = %+v, %v", q, err)
+	}
+	for _, period := range []int{0, MaxPeriod + 1} {
+		if _, err := NewQuote(ActionRenew, period, p); err == nil {
+			t.Errorf("renewal for %d years accepted", period)
+		}
+	}
+
+	p.Premium = true
+	p.RegistrationPrice = 1960
+	if q, _ := NewQuote(ActionRegister, 1, p); q.PremiumPrice() != "1960.00" {
+		t.Errorf("premium price = %q", q.PremiumPrice())
+	}
+}
+
+func TestCheckAttributes(t *testing.T) {
+	defs := []dnsimple.TldExtendedAttribute{
+		{Name: "x-ca-legal-type", Required: true, Options: []dnsimple.TldExtendedAttributeOption{{Value: "CCT"}, {Value: "RES"}}},
+		{Name: "x-ca-lang"},
+	}
+	tests := []struct {
+		pairs   []string
+		wantErr string
+	}{
+		{pairs: []string{"x-ca-legal-type=RES", "x-ca-lang=en"}},
+		{pairs: nil, wantErr: "requires the extended attributes x-ca-legal-type"},
+		{pairs: []string{"x-ca-legal-type=ABC"}, wantErr: "must be one of CCT, RES"},
+		{pairs: []string{"x-ca-legal-type=CCT", "x-us-nexus=C11"}, wantErr: "no extended attributes named x-us-nexus"},
+	}
+	for _, tt := range tests {
+		values, err := ParseAttributes(tt.pairs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = CheckAttributes("ca", defs, values)
+		if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("CheckAttributes(%v) = %v, want %q", tt.pairs, err, tt.wantErr)
+		}
+	}
+	if _, err := ParseAttributes([]string{"no-value"}); err == nil {
+		t.Error("attribute without = accepted")
+	}
+}
+
+func TestApprovalAndOrder(t *testing.T) {
+	quotes := []Quote{
+		{Domain: "acme.io", Action: ActionRegister, Period: 1, Price: 49, Total: 49},
+		{Domain: "acme.io", Action: ActionRenew, Period: 2, Price: 50, Total: 100},
+		{Domain: "acme.io", Action: ActionTransfer, Period: 1, Price: 48, Total: 48},
+	}
+	answer := func(typed string) func(string, string) (string, error) {
+		return func(question, want string) (string, error) {
+			if want != "acme.io" || !strings.Contains(question, "costs $") {
+				t.Errorf("asked %q for %q", question, want)
+			}
+			return typed, nil
+		}
+	}
+	tests := []struct {
+		name       string
+		approval   Approval
+		wantPlaced bool
+		wantErr    string
+		canceled   bool
+	}{
+		{name: "dry run", approval: Approval{DryRun: true, Confirm: "acme.io", Interactive: true, Ask: answer("acme.io")}},
+		{name: "confirm matches", approval: Approval{Confirm: "acme.io"}, wantPlaced: true},
+		{name: "confirm mismatch", approval: Approval{Confirm: "acme.com", Interactive: true, Ask: answer("acme.io")}, wantErr: "does not match"},
+		{name: "not a terminal", approval: Approval{Ask: answer("acme.io")}, wantErr: "without confirmation; pass --confirm acme.io"},
+		{name: "typed domain", approval: Approval{Interactive: true, Ask: answer(" acme.io\n")}, wantPlaced: true},
+		{name: "typed something else", approval: Approval{Interactive: true, Ask: answer("y")}, canceled: true},
+		{name: "nothing typed", approval: Approval{Interactive: true, Ask: answer("")}, canceled: true},
+	}
+	for _, tt := range tests {
+		for _, q := range quotes {
+			t.Run(tt.name+"/"+q.Action, func(t *testing.T) {
+				calls := 0
+				placed, err := Order(q, tt.approval, func() error {
+					calls++
+					return nil
+				})
+				if placed != tt.wantPlaced || calls != map[bool]int{true: 1}[tt.wantPlaced] {
+					t.Errorf("placed = %v after %d calls, want %v", placed, calls, tt.wantPlaced)
+				}
+				switch {
+				case tt.canceled:
+					if !errors.Is(err, context.Canceled) {
+						t.Errorf("err = %v, want context.Canceled", err)
+					}
+				case tt.wantErr != "":
+					if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+						t.Errorf("err = %v, want %q", err, tt.wantErr)
+					}
+				case err != nil:
+					t.Errorf("err = %v", err)
+				}
+			})
+		}
+	}
+
+	askFails := Approval{Interactive: true, Ask: func(string, string) (string, error) { return "", errors.New("stdin closed") }}
+	if placed, err := Order(quotes[0], askFails, func() error { t.Fatal("ordered after a failed prompt"); return nil }); placed || err == nil {
+		t.Errorf("failed prompt = %v, %v", placed, err)
+	}
+	failing := errors.New("insufficient funds")
+	if placed, err := Order(quotes[0], Approval{Confirm: "acme.io"}, func() error { return failing }); !placed || !errors.Is(err, failing) {
+		t.Errorf("order error = %v, %v", placed, err)
+	}
+}
@@ -0,0 +1,125 @@
+// Package templates previews what applying a DNSimple record template to a
+// domain would change.
+package templates
+
+import (
+	"strings"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+// DomainPlaceholder in a template record's content is replaced with the
+// domain the template is applied to.
+const DomainPlaceholder = "{{domain}}"
+
+// Plan sorts a template's records by what applying it would do to a zone.
+type Plan struct {
+	// Template names what is applied: a template, or a local recipe.
+	Template string `json:"template"`
+	Domain   string `json:"domain"`
+	// Add holds the records that would be created.
+	Add []dnsimple.ZoneRecord `json:"add"`
+	// Existing holds records the zone already has an identical copy of.
+	Existing []dnsimple.ZoneRecord `json:"existing"`
+	// Conflicts holds records the zone cannot take alongside what it has,
+	// such as a CNAME at a name that already has other records.
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// Conflict is a template record that clashes with the zone.
+type Conflict struct {
+	Record dnsimple.ZoneRecord `json:"record"`
+	Reason string              `json:"reason"`
+}
+
+// Expand turns a template record into the zone record it creates on domain.
+func Expand(r dnsimple.TemplateRecord, domain string) dnsimple.ZoneRecord {
+	return dnsimple.ZoneRecord{
+		Type:     strings.ToUpper(r.Type),
+		Name:     r.Name,
+		Content:  strings.ReplaceAll(r.Content, DomainPlaceholder, domain),
+		TTL:      r.TTL,
+		Priority: r.Priority,
+	}
+}
+
+// Preview compares a template's records with a zone's current records.
+func Preview(template, domain string, records []dnsimple.TemplateRecord, zone []dnsimple.ZoneRecord) Plan {
+	expanded := make([]dnsimple.ZoneRecord, 0, len(records))
+	for _, tr := range records {
+		expanded = append(expanded, Expand(tr, domain))
+	}
+	return Compare(template, domain, expanded, zone)
+}
+
+// Compare sorts records that are about to be added to a zone by what adding
+// them would do. name labels the plan, such as a template or recipe name.
+func Compare(name, domain string, records, zone []dnsimple.ZoneRecord) Plan {
+	p := Plan{
+		Template:  name,
+		Domain:    domain,
+		Add:       []dnsimple.ZoneRecord{},
+		Existing:  []dnsimple.ZoneRecord{},
+		Conflicts: []Conflict{},
+	}
+	for _, r := range records {
+		if reason := conflict(r, zone); reason != "" {
+			p.Conflicts = append(p.Conflicts, Conflict{Record: r, Reason: reason})
+			continue
+		}
+		if hasCopy(r, zone) {
+			p.Existing = append(p.Existing, r)
+			continue
+		}
+		p.Add = append(p.Add, r)
+	}
+	return p
+}
+
+// Same reports whether two records hold the same data, ignoring IDs, TTL
+// and a trailing dot on the content.
+func Same(a, b dnsimple.ZoneRecord) bool {
+	return strings.EqualFold(a.Type, b.Type) &&
+		strings.EqualFold(a.Name, b.Name) &&
+		a.Priority == b.Priority &&
+		strings.TrimSuffix(a.Content, ".") == strings.TrimSuffix(b.Content, ".")
+}
+
+func hasCopy(r dnsimple.ZoneRecord, zone []dnsimple.ZoneRecord) bool {
+	for _, z := range zone {
+		if Same(r, z) {
+			return true
+		}
+	}
+	return false
+}
+
+// conflict explains why r cannot be added to zone, or returns "".
+func conflict(r dnsimple.ZoneRecord, zone []dnsimple.ZoneRecord) string {
+	for _, z := range zone {
+		if !strings.EqualFold(r.Name, z.Name) || Same(r, z) {
+			continue
+		}
+		switch {
+		case r.Type == "CNAME":
+			return "the zone already has " + z.Type + " records at " + displayName(r.Name)
+		case strings.EqualFold(z.Type, "CNAME"):
+			return displayName(r.Name) + " is a CNAME in the zone"
+		case isSPF(r) && isSPF(z):
+			return "the zone already has an SPF record at " + displayName(r.Name)
+		}
+	}
+	return ""
+}
+
+// isSPF reports whether r is an SPF policy; a name may only have one.
+func isSPF(r dnsimple.ZoneRecord) bool {
+	return strings.EqualFold(r.Type, "TXT") && strings.HasPrefix(strings.ToLower(strings.Trim(r.Content, `"`)), "v=spf1")
+}
+
+func displayName(name string) string {
+	if name == "" {
+		return "@"
+	}
+	return name
+}
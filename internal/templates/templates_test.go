@@ -0,0 +1,55 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+func TestPreview(t *testing.T) {
+	zone := []dnsimple.ZoneRecord{
+		{ID: 1, Type: "A", Name: "", Content: "203.0.113.10", TTL: 3600},
+		{ID: 2, Type: "CNAME", Name: "www", Content: "example.com.", TTL: 3600},
+		{ID: 3, Type: "MX", Name: "", Content: "mx.example.net", TTL: 3600, Priority: 10},
+		{ID: 4, Type: "TXT", Name: "blog", Content: "hello", TTL: 3600},
+		{ID: 5, Type: "TXT", Name: "", Content: "v=spf1 include:mx.example.net ~all", TTL: 3600},
+	}
+	tmpl := []dnsimple.TemplateRecord{
+		{Type: "CNAME", Name: "www", Content: DomainPlaceholder, TTL: 600},
+		{Type: "A", Name: "", Content: "203.0.113.80"},
+		{Type: "mx", Name: "", Content: "mx.example.net", Priority: 20},
+		{Type: "TXT", Name: "_dmarc", Content: "v=DMARC1; p=none; rua=mailto:dmarc@" + DomainPlaceholder},
+		{Type: "CNAME", Name: "blog", Content: "ghs.example.net"},
+		{Type: "A", Name: "www", Content: "203.0.113.81"},
+		{Type: "TXT", Name: "", Content: "v=spf1 include:_spf.google.com ~all"},
+	}
+
+	p := Preview("brand-web", "example.com", tmpl, zone)
+	if p.Template != "brand-web" || p.Domain != "example.com" {
+		t.Errorf("plan is for %s on %s", p.Template, p.Domain)
+	}
+	if len(p.Existing) != 1 || p.Existing[0].Name != "www" {
+		t.Errorf("existing = %+v, want the www CNAME", p.Existing)
+	}
+	if len(p.Add) != 3 {
+		t.Fatalf("add = %+v, want 3 records", p.Add)
+	}
+	if p.Add[1].Type != "MX" || p.Add[1].Priority != 20 {
+		t.Errorf("MX with a new priority = %+v, want it added", p.Add[1])
+	}
+	if want := "v=DMARC1; p=none; rua=mailto:dmarc@example.com"; p.Add[2].Content != want {
+		t.Errorf("placeholder not expanded: %q", p.Add[2].Content)
+	}
+	if len(p.Conflicts) != 3 {
+		t.Fatalf("conflicts = %+v, want 3", p.Conflicts)
+	}
+	if c := p.Conflicts[0]; c.Record.Name != "blog" || c.Reason != "the zone already has TXT records at blog" {
+		t.Errorf("first conflict = %+v", c)
+	}
+	if c := p.Conflicts[1]; c.Record.Name != "www" || c.Reason != "www is a CNAME in the zone" {
+		t.Errorf("second conflict = %+v", c)
+	}
+	if c := p.Conflicts[2]; c.Reason != "the zone already has an SPF record at @" {
+		t.Errorf("third conflict = %+v", c)
+	}
+}
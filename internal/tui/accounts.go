@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+type openAccountSwitcherMsg struct{}
+
+type accountsLoadedMsg struct {
+	gen      int
+	accounts []dnsimple.Account
+	current  string
+	err      error
+}
+
+// accountSwitchedMsg reports a completed switch from one account ID to
+// another.
+type accountSwitchedMsg struct {
+	from    string
+	account dnsimple.Account
+	err     error
+}
+
+// accountSwitcher is the modal that lists the accounts the token can reach
+// and switches the session to one of them.
+type accountSwitcher struct {
+	visible  bool
+	loading  bool
+	busy     bool
+	errMsg   string
+	accounts []dnsimple.Account
+	current  string
+	selected int
+	spinner  spinner.Model
+	req      requestScope
+}
+
+func newAccountSwitcher() accountSwitcher {
+	spin := spinner.New()
+	spin.Spinner = spinner.Line
+	spin.Style = subtitleStyle
+	return accountSwitcher{spinner: spin}
+}
+
+func (s *accountSwitcher) open() tea.Cmd {
+	s.visible = true
+	s.loading = true
+	s.busy = false
+	s.errMsg = ""
+	return tea.Batch(s.spinner.Tick, s.loadCmd())
+}
+
+func (s *accountSwitcher) close() {
+	s.visible = false
+	s.loading = false
+	s.req.stop()
+}
+
+func (s *accountSwitcher) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if s.busy {
+			return nil
+		}
+		switch {
+		case matches(msg, keys.Back):
+			s.close()
+		case matches(msg, keys.Up):
+			if s.selected > 0 {
+				s.selected--
+			}
+		case matches(msg, keys.Down):
+			if s.selected < len(s.accounts)-1 {
+				s.selected++
+			}
+		case matches(msg, keys.Enter):
+			if s.loading || len(s.accounts) == 0 {
+				return nil
+			}
+			account := s.accounts[s.selected]
+			if strconv.FormatInt(account.ID, 10) == s.current {
+				s.close()
+				return nil
+			}
+			s.busy = true
+			s.errMsg = ""
+			return tea.Batch(s.spinner.Tick, switchAccountCmd(s.current, account))
+		}
+	case accountsLoadedMsg:
+		if !s.req.current(msg.gen) {
+			return nil
+		}
+		s.loading = false
+		if msg.err != nil {
+			s.errMsg = msg.err.Error()
+			return nil
+		}
+		s.accounts = msg.accounts
+		s.current = msg.current
+		s.selected = 0
+		for i, a := range s.accounts {
+			if strconv.FormatInt(a.ID, 10) == s.current {
+				s.selected = i
+			}
+		}
+	case spinner.TickMsg:
+		if s.loading || s.busy {
+			var cmd tea.Cmd
+			s.spinner, cmd = s.spinner.Update(msg)
+			return cmd
+		}
+	}
+	return nil
+}
+
+func (s accountSwitcher) View(width, height int) string {
+	lines := []string{
+		panelTitleStyle.Render("Switch Account"),
+		"",
+		subtitleStyle.Render("Accounts this token can access. Each account keeps its own last tab and domain."),
+		"",
+	}
+
+	switch {
+	case s.loading:
+		lines = append(lines, s.spinner.View()+" Loading accounts...")
+	case len(s.accounts) == 0 && s.errMsg == "":
+		lines = append(lines, subtitleStyle.Render("No accounts found."))
+	default:
+		start, end := windowRange(len(s.accounts), s.selected, maxInt(5, minInt(12, height-14)))
+		for i := start; i < end; i++ {
+			a := s.accounts[i]
+			prefix := "  "
+			style := itemStyle
+			if i == s.selected {
+				prefix = "› "
+				style = selectedItemStyle
+			}
+			marker := ""
+			if strconv.FormatInt(a.ID, 10) == s.current {
+				marker = successStyle.Render("  ● current")
+			}
+			row := fmt.Sprintf("%s%-10d %s", prefix, a.ID, a.Email)
+			lines = append(lines, style.Render(truncateText(row, 70))+marker)
+			if a.PlanIdentifier != "" {
+				lines = append(lines, subtitleStyle.Render("   "+a.PlanIdentifier))
+			}
+		}
+	}
+
+	if s.busy {
+		lines = append(lines, "", s.spinner.View()+" Switching account...")
+	}
+	if s.errMsg != "" {
+		lines = append(lines, "", errorStyle.Render(s.errMsg))
+	}
+
+	lines = append(lines, "", footerStyle.Render("enter: switch   j/k: move   esc: cancel"))
+	box := modalPanelStyle.Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(maxInt(70, width), maxInt(20, height), lipgloss.Center, lipgloss.Center, box)
+}
+
+func (s *accountSwitcher) loadCmd() tea.Cmd {
+	ctx, gen := s.req.begin()
+	return func() tea.Msg {
+		backend := getBackend()
+		accounts, err := backend.ListAccounts(ctx)
+		if err != nil {
+			return accountsLoadedMsg{gen: gen, err: err}
+		}
+		// With several accounts and none chosen there is no current
+		// account yet; the list is still useful.
+		current, _ := backend.AccountID(ctx)
+		return accountsLoadedMsg{gen: gen, accounts: accounts, current: current}
+	}
+}
+
+// switchAccountCmd is not cancellable: once the user confirms, the switch
+// completes even if they navigate away.
+func switchAccountCmd(from string, account dnsimple.Account) tea.Cmd {
+	return func() tea.Msg {
+		err := getBackend().UseAccount(context.Background(), strconv.FormatInt(account.ID, 10))
+		return accountSwitchedMsg{from: from, account: account, err: err}
+	}
+}
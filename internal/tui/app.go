@@ -25,7 +25,10 @@ type AppModel struct {
 
 func NewAppModel() *AppModel {
 	m := &AppModel{}
-	if config.HasToken() {
+	if config.TokenSource() == config.SourceFile && config.TokenEncrypted() && !config.HasPassphrase() {
+		m.route = routeAuth
+		m.auth = NewUnlockModel()
+	} else if config.HasToken() {
 		m.route = routeShell
 		m.shell = NewShellModel(nil)
 	} else {
@@ -67,7 +70,7 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.String() == "ctrl+c" {
 			return m, tea.Quit
 		}
-		if matches(msg, keys.Quit) && !(m.route == routeShell && m.shell.BlocksGlobalKeys()) {
+		if matches(msg, keys.Quit) && !m.blocksQuit() {
 			return m, tea.Quit
 		}
 	case authDoneMsg:
@@ -85,6 +88,15 @@ func (m *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// blocksQuit reports whether the active screen is taking text input, so "q"
+// must reach it rather than quit.
+func (m *AppModel) blocksQuit() bool {
+	if m.route == routeShell {
+		return m.shell.BlocksGlobalKeys()
+	}
+	return m.auth.BlocksGlobalKeys()
+}
+
 func (m *AppModel) View() string {
 	switch m.route {
 	case routeShell:
@@ -21,6 +21,9 @@ const (
 	authStepWelcome authStep = iota
 	authStepConfigDir
 	authStepTokenInput
+	authStepNewPassphrase
+	authStepConfirmPassphrase
+	authStepUnlock
 	authStepValidating
 	authStepSuccess
 	authStepError
@@ -36,13 +39,22 @@ type authDoneMsg struct {
 	data *dnsimple.WhoamiData
 }
 
+type tokenUnlockedMsg struct {
+	err error
+}
+
 type AuthModel struct {
 	step         authStep
 	width        int
 	height       int
 	configDir    string
+	profile      string
+	storage      string
+	tokenCommand string
 	pathInput    textinput.Model
 	tokenInput   textinput.Model
+	passInput    textinput.Model
+	newPass      string
 	spinner      spinner.Model
 	validating   bool
 	validToken   string
@@ -72,6 +84,23 @@ func NewAuthModel() AuthModel {
 	tokenInput.EchoCharacter = '•'
 	tokenInput.Width = 64
 
+	passInput := textinput.New()
+	passInput.Placeholder = "Passphrase"
+	passInput.Prompt = "> "
+	passInput.CharLimit = 256
+	passInput.EchoMode = textinput.EchoPassword
+	passInput.EchoCharacter = '•'
+	passInput.Width = 64
+
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+	profile, err := cfg.Current()
+	if err != nil {
+		profile = &config.Profile{}
+	}
+
 	spin := spinner.New()
 	spin.Spinner = spinner.Dot
 	spin.Style = subtitleStyle
@@ -79,14 +108,30 @@ func NewAuthModel() AuthModel {
 	return AuthModel{
 		step:         authStepWelcome,
 		configDir:    cfgDir,
+		profile:      config.CurrentProfileName(),
+		storage:      profile.StorageMode(),
+		tokenCommand: profile.TokenCommand,
 		pathInput:    pathInput,
 		tokenInput:   tokenInput,
+		passInput:    passInput,
 		spinner:      spin,
 		tokenHelpURL: "https://dnsimple.com/a/YOUR_ACCOUNT_ID/account/access_tokens",
 	}
 }
 
+// NewUnlockModel asks for the passphrase of an encrypted token that is
+// already stored.
+func NewUnlockModel() AuthModel {
+	m := NewAuthModel()
+	m.step = authStepUnlock
+	m.passInput.Focus()
+	return m
+}
+
 func (m *AuthModel) Init() tea.Cmd {
+	if m.step == authStepUnlock {
+		return textinput.Blink
+	}
 	return nil
 }
 
@@ -101,10 +146,24 @@ func (m *AuthModel) Update(msg tea.Msg) tea.Cmd {
 		switch m.step {
 		case authStepWelcome:
 			if matches(msg, keys.Enter) {
+				if m.storage == config.StorageCommand {
+					m.step = authStepValidating
+					m.validating = true
+					m.errMsg = ""
+					return tea.Batch(m.spinner.Tick, tokenCommandCmd(m.tokenCommand))
+				}
 				m.step = authStepTokenInput
 				m.tokenInput.Focus()
 				return textinput.Blink
 			}
+			if msg.String() == "e" && m.storage != config.StorageCommand {
+				if m.storage == config.StorageEncrypted {
+					m.storage = config.StoragePlaintext
+				} else {
+					m.storage = config.StorageEncrypted
+				}
+				return nil
+			}
 			if msg.String() == "c" {
 				m.step = authStepConfigDir
 				m.pathInput.SetValue(m.configDir)
@@ -153,20 +212,73 @@ func (m *AuthModel) Update(msg tea.Msg) tea.Cmd {
 					m.step = authStepError
 					return nil
 				}
-				m.step = authStepValidating
-				m.validating = true
-				m.errMsg = ""
-				m.validToken = ""
-				return tea.Batch(m.spinner.Tick, validateTokenCmd(token))
+				m.tokenInput.Blur()
+				if m.storage == config.StorageEncrypted && !config.HasPassphrase() {
+					m.step = authStepNewPassphrase
+					m.newPass = ""
+					m.passInput.SetValue("")
+					m.passInput.Focus()
+					return textinput.Blink
+				}
+				return m.startValidation(token)
 			}
 			var cmd tea.Cmd
 			m.tokenInput, cmd = m.tokenInput.Update(msg)
 			return cmd
+		case authStepNewPassphrase, authStepConfirmPassphrase:
+			if matches(msg, keys.Back) {
+				m.step = authStepTokenInput
+				m.passInput.Blur()
+				m.tokenInput.Focus()
+				return textinput.Blink
+			}
+			if matches(msg, keys.Enter) {
+				pass := m.passInput.Value()
+				if pass == "" {
+					m.errMsg = "passphrase cannot be empty"
+					m.step = authStepError
+					return nil
+				}
+				if m.step == authStepNewPassphrase {
+					m.newPass = pass
+					m.passInput.SetValue("")
+					m.step = authStepConfirmPassphrase
+					return nil
+				}
+				if pass != m.newPass {
+					m.errMsg = "passphrases do not match"
+					m.step = authStepError
+					return nil
+				}
+				m.passInput.Blur()
+				config.SetPassphrase(pass)
+				return m.startValidation(strings.TrimSpace(m.tokenInput.Value()))
+			}
+			var cmd tea.Cmd
+			m.passInput, cmd = m.passInput.Update(msg)
+			return cmd
+		case authStepUnlock:
+			if matches(msg, keys.Enter) {
+				pass := m.passInput.Value()
+				if pass == "" {
+					return nil
+				}
+				m.errMsg = ""
+				m.validating = true
+				return unlockTokenCmd(pass)
+			}
+			var cmd tea.Cmd
+			m.passInput, cmd = m.passInput.Update(msg)
+			return cmd
 		case authStepSuccess:
 			if matches(msg, keys.Enter) {
 				return func() tea.Msg { return authDoneMsg{data: m.whoami} }
 			}
 		case authStepError:
+			if matches(msg, keys.Enter) && m.storage == config.StorageCommand {
+				m.step = authStepWelcome
+				return nil
+			}
 			if matches(msg, keys.Enter) {
 				m.step = authStepTokenInput
 				m.tokenInput.Focus()
@@ -186,13 +298,21 @@ func (m *AuthModel) Update(msg tea.Msg) tea.Cmd {
 		}
 		m.validToken = msg.token
 		m.whoami = msg.data
-		if err := persistAuth(msg.token, msg.data); err != nil {
+		if err := persistAuth(msg.token, msg.data, m.storage); err != nil {
 			m.errMsg = err.Error()
 			m.step = authStepError
 			return nil
 		}
 		m.step = authStepSuccess
 		return nil
+	case tokenUnlockedMsg:
+		m.validating = false
+		if msg.err != nil {
+			m.errMsg = msg.err.Error()
+			m.passInput.SetValue("")
+			return nil
+		}
+		return func() tea.Msg { return authDoneMsg{} }
 	case spinner.TickMsg:
 		if m.step == authStepValidating {
 			var cmd tea.Cmd
@@ -211,9 +331,31 @@ func (m *AuthModel) Update(msg tea.Msg) tea.Cmd {
 		m.pathInput, cmd = m.pathInput.Update(msg)
 		return cmd
 	}
+	if m.step == authStepNewPassphrase || m.step == authStepConfirmPassphrase || m.step == authStepUnlock {
+		var cmd tea.Cmd
+		m.passInput, cmd = m.passInput.Update(msg)
+		return cmd
+	}
 	return nil
 }
 
+// BlocksGlobalKeys reports whether a text input has focus.
+func (m *AuthModel) BlocksGlobalKeys() bool {
+	switch m.step {
+	case authStepConfigDir, authStepTokenInput, authStepNewPassphrase, authStepConfirmPassphrase, authStepUnlock:
+		return true
+	}
+	return false
+}
+
+func (m *AuthModel) startValidation(token string) tea.Cmd {
+	m.step = authStepValidating
+	m.validating = true
+	m.errMsg = ""
+	m.validToken = ""
+	return tea.Batch(m.spinner.Tick, validateTokenCmd(token))
+}
+
 func (m AuthModel) View() string {
 	switch m.step {
 	case authStepWelcome:
@@ -222,6 +364,10 @@ func (m AuthModel) View() string {
 		return m.viewConfigDir()
 	case authStepTokenInput:
 		return m.viewTokenInput()
+	case authStepNewPassphrase, authStepConfirmPassphrase:
+		return m.viewNewPassphrase()
+	case authStepUnlock:
+		return m.viewUnlock()
 	case authStepValidating:
 		return m.viewValidating()
 	case authStepSuccess:
@@ -234,13 +380,24 @@ func (m AuthModel) View() string {
 }
 
 func (m AuthModel) tokenPathPreview() string {
-	return filepath.Join(m.configDir, "token")
+	return config.TokenFile(m.configDir, m.profile)
 }
 
 func (m AuthModel) configPathPreview() string {
 	return filepath.Join(m.configDir, "config.json")
 }
 
+func (m AuthModel) storageLine() string {
+	switch m.storage {
+	case config.StorageCommand:
+		return labelStyle.Render("Token source: ") + codeStyle.Render("token_command: "+m.tokenCommand)
+	case config.StorageEncrypted:
+		return labelStyle.Render("Token storage: ") + valueStyle.Render("encrypted (passphrase)")
+	default:
+		return labelStyle.Render("Token storage: ") + valueStyle.Render("plaintext")
+	}
+}
+
 func (m AuthModel) viewWelcome() string {
 	body := strings.Join([]string{
 		titleStyle.Render("DNSimple Setup"),
@@ -252,13 +409,21 @@ func (m AuthModel) viewWelcome() string {
 			labelStyle.Render("Config dir: ") + valueStyle.Render(m.configDir),
 			labelStyle.Render("Token file: ") + codeStyle.Render(m.tokenPathPreview()),
 			labelStyle.Render("Config file: ") + codeStyle.Render(m.configPathPreview()),
+			m.storageLine(),
 		}, "\n")),
 		"",
-		footerStyle.Render("Enter: continue   c: change config path   q: quit"),
+		footerStyle.Render(m.welcomeFooter()),
 	}, "\n")
 	return frame(m.width, body)
 }
 
+func (m AuthModel) welcomeFooter() string {
+	if m.storage == config.StorageCommand {
+		return "Enter: run token_command and validate   c: change config path   q: quit"
+	}
+	return "Enter: continue   c: change config path   e: toggle encryption   q: quit"
+}
+
 func (m AuthModel) viewConfigDir() string {
 	body := strings.Join([]string{
 		titleStyle.Render("Config Directory"),
@@ -272,7 +437,7 @@ func (m AuthModel) viewConfigDir() string {
 			subtitleStyle.Render("This updates both token and config file locations."),
 		}, "\n")),
 		"",
-		footerStyle.Render("Enter: apply   esc: back   q: quit"),
+		footerStyle.Render("Enter: apply   esc: back   ctrl+c: quit"),
 	}, "\n")
 	return frame(m.width, body)
 }
@@ -291,7 +456,51 @@ func (m AuthModel) viewTokenInput() string {
 			labelStyle.Render("Will be saved to: ") + codeStyle.Render(m.tokenPathPreview()),
 		}, "\n")),
 		"",
-		footerStyle.Render("Enter: validate   esc: back   q: quit"),
+		footerStyle.Render("Enter: validate   esc: back   ctrl+c: quit"),
+	}, "\n")
+	return frame(m.width, body)
+}
+
+func (m AuthModel) viewNewPassphrase() string {
+	title := "Choose a passphrase for the token file."
+	if m.step == authStepConfirmPassphrase {
+		title = "Repeat the passphrase."
+	}
+	body := strings.Join([]string{
+		titleStyle.Render("Token Passphrase"),
+		subtitleStyle.Render(title),
+		"",
+		panelStyle.Render(strings.Join([]string{
+			panelTitleStyle.Render("Passphrase"),
+			"",
+			m.passInput.View(),
+			"",
+			subtitleStyle.Render("The token is encrypted with scrypt + AES-GCM. Set SIMPLE_TOKEN_PASSPHRASE to skip this prompt later."),
+		}, "\n")),
+		"",
+		footerStyle.Render("Enter: continue   esc: back   ctrl+c: quit"),
+	}, "\n")
+	return frame(m.width, body)
+}
+
+func (m AuthModel) viewUnlock() string {
+	lines := []string{
+		panelTitleStyle.Render("Passphrase"),
+		"",
+		m.passInput.View(),
+		"",
+		labelStyle.Render("Token file: ") + codeStyle.Render(m.tokenPathPreview()),
+	}
+	if m.errMsg != "" {
+		lines = append(lines, "", errorStyle.Render(m.errMsg))
+	}
+	body := strings.Join([]string{
+		titleStyle.Render("Unlock Token"),
+		subtitleStyle.Render("The stored token is encrypted. Enter its passphrase for this session."),
+		"",
+		panelStyle.Render(strings.Join(lines, "\n")),
+		"",
+		footerStyle.Render("Enter: unlock   ctrl+c: quit"),
 	}, "\n")
 	return frame(m.width, body)
 }
@@ -341,28 +550,85 @@ func (m AuthModel) viewError() string {
 	return frame(m.width, body)
 }
 
+func tokenCommandCmd(command string) tea.Cmd {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+	profile, _ := cfg.Current()
+	sandbox := profile.IsSandbox()
+	return func() tea.Msg {
+		ctx := context.Background()
+		token, err := config.RunTokenCommand(ctx, command)
+		if err != nil {
+			return tokenValidatedMsg{err: err}
+		}
+		data, err := client.ValidateToken(ctx, token, sandbox)
+		return tokenValidatedMsg{token: token, data: data, err: err}
+	}
+}
+
+func unlockTokenCmd(pass string) tea.Cmd {
+	return func() tea.Msg {
+		config.SetPassphrase(pass)
+		_, err := config.LoadToken()
+		return tokenUnlockedMsg{err: err}
+	}
+}
+
 func validateTokenCmd(token string) tea.Cmd {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+	profile, _ := cfg.Current()
+	sandbox := profile.IsSandbox()
 	return func() tea.Msg {
-		data, err := client.ValidateToken(context.Background(), token, false)
+		data, err := client.ValidateToken(context.Background(), token, sandbox)
 		return tokenValidatedMsg{token: token, data: data, err: err}
 	}
 }
 
-func persistAuth(token string, whoami *dnsimple.WhoamiData) error {
-	if err := config.SaveToken(token); err != nil {
-		return fmt.Errorf("failed to save token: %w", err)
+// appFromToken resolves the account for a freshly entered token; tests
+// replace it to avoid calling the API.
+var appFromToken = client.NewFromToken
+
+func persistAuth(token string, whoami *dnsimple.WhoamiData, storage string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+	profile, err := cfg.Current()
+	if err != nil {
+		return err
 	}
 
-	cfg := &config.Config{}
-	cfg.Sandbox = false
+	// The previous login's account must be off disk before a user token is
+	// resolved, or the lookup reads it back from the saved config.
+	profile.AccountID = ""
+	if storage != config.StorageCommand {
+		profile.TokenStorage = ""
+		if storage == config.StorageEncrypted {
+			profile.TokenStorage = config.StorageEncrypted
+		}
+	}
+	// SaveToken reads the storage mode from the saved config.
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	if storage != config.StorageCommand {
+		if err := config.SaveToken(token); err != nil {
+			return fmt.Errorf("failed to save token: %w", err)
+		}
+	}
 
 	if whoami != nil {
 		if whoami.Account != nil {
-			cfg.AccountID = strconv.FormatInt(whoami.Account.ID, 10)
+			profile.AccountID = strconv.FormatInt(whoami.Account.ID, 10)
 		} else if whoami.User != nil {
 			// Best-effort account resolution for user tokens.
-			if app, err := client.NewFromFlags(context.Background(), "", false); err == nil {
-				cfg.AccountID = app.AccountID
+			if app, err := appFromToken(context.Background(), token, profile.IsSandbox()); err == nil {
+				profile.AccountID = app.AccountID
 			}
 		}
 	}
@@ -371,6 +637,7 @@ func persistAuth(token string, whoami *dnsimple.WhoamiData) error {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
+	client.InvalidateCurrent()
 	return nil
 }
 
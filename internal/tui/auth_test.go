@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/client"
+	"github.com/dorkitude/simple/internal/config"
+)
+
+func TestPersistAuthReresolvesAccountForUserToken(t *testing.T) {
+	t.Setenv("DNSIMPLE_CONFIG_DIR", t.TempDir())
+	t.Setenv("DNSIMPLE_TOKEN", "")
+	t.Setenv("DNSIMPLE_ACCOUNT", "")
+	t.Setenv("SIMPLE_PROFILE", "")
+	cfg := &config.Config{Profiles: map[string]*config.Profile{
+		config.DefaultProfile: {AccountID: "1010"},
+	}}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	// Like client.NewFromToken, trust an account already on the profile and
+	// only look one up when there is none.
+	prev := appFromToken
+	appFromToken = func(ctx context.Context, token string, sandbox bool) (*client.App, error) {
+		cfg, err := config.Load()
+		if err != nil {
+			return nil, err
+		}
+		if id, _ := cfg.AccountID(""); id != "" {
+			return &client.App{AccountID: id}, nil
+		}
+		return &client.App{AccountID: "2020"}, nil
+	}
+	t.Cleanup(func() { appFromToken = prev })
+
+	whoami := &dnsimple.WhoamiData{User: &dnsimple.User{ID: 2, Email: "new@example.com"}}
+	if err := persistAuth("new-user-token", whoami, config.StoragePlaintext); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, _ := cfg.AccountID(""); id != "2020" {
+		t.Errorf("account after re-login = %q, want 2020 (1010 belonged to the previous login)", id)
+	}
+	if token, err := config.LoadToken(); err != nil || token != "new-user-token" {
+		t.Errorf("stored token = %q, %v", token, err)
+	}
+}
@@ -0,0 +1,622 @@
+package tui
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/forwarding"
+	"github.com/dorkitude/simple/internal/nameservers"
+	"github.com/dorkitude/simple/internal/registrar"
+	"github.com/dorkitude/simple/internal/templates"
+)
+
+// Fixture names from the demo seed. contractActiveZone starts active and
+// contractInactiveZone starts inactive; contractSignedDomain has DNSSEC on.
+// contractTemplate applies cleanly to the seeded zones and contractClashing
+// conflicts with them. contractParkedDomain has a catch-all email forward.
+const (
+	contractActiveZone   = "acme.dev"
+	contractInactiveZone = "absurdophile.com"
+	contractSignedDomain = "acme.dev"
+	contractMissingName  = "missing.example"
+	contractTemplate     = "brand-web"
+	contractClashing     = "acme-cname"
+	contractParkedDomain = "alpha-example.net"
+	contractMissingID    = int64(1)
+	contractFreeName     = "rocketco.com"
+	contractTakenName    = "acme.io"
+)
+
+func TestDemoBackendContract(t *testing.T) {
+	runBackendContract(t, func(t *testing.T) Backend {
+		return newDemoBackend()
+	})
+}
+
+func TestRealBackendContract(t *testing.T) {
+	runBackendContract(t, func(t *testing.T) Backend {
+		return newFakeAPI(t).backend()
+	})
+}
+
+// runBackendContract checks the behavior the TUI relies on from every
+// Backend. newBackend must return a freshly seeded backend on each call.
+func runBackendContract(t *testing.T, newBackend func(t *testing.T) Backend) {
+	ctx := context.Background()
+
+	t.Run("Whoami", func(t *testing.T) {
+		data, err := newBackend(t).Whoami(ctx)
+		if err != nil {
+			t.Fatalf("Whoami: %v", err)
+		}
+		if data == nil || (data.Account == nil && data.User == nil) {
+			t.Fatalf("Whoami returned no account or user: %+v", data)
+		}
+	})
+
+	t.Run("Accounts", func(t *testing.T) {
+		b := newBackend(t)
+		accounts, err := b.ListAccounts(ctx)
+		if err != nil {
+			t.Fatalf("ListAccounts: %v", err)
+		}
+		if len(accounts) < 2 {
+			t.Fatalf("want several accounts, got %d", len(accounts))
+		}
+		for i := 1; i < len(accounts); i++ {
+			if accounts[i-1].ID >= accounts[i].ID {
+				t.Fatalf("accounts not sorted by ID at %d: %d >= %d", i, accounts[i-1].ID, accounts[i].ID)
+			}
+		}
+
+		start, err := b.AccountID(ctx)
+		if err != nil {
+			t.Fatalf("AccountID: %v", err)
+		}
+		if !hasAccount(accounts, start) {
+			t.Fatalf("AccountID %s is not among ListAccounts", start)
+		}
+
+		var other string
+		for _, a := range accounts {
+			if id := strconv.FormatInt(a.ID, 10); id != start {
+				other = id
+				break
+			}
+		}
+		if err := b.UseAccount(ctx, other); err != nil {
+			t.Fatalf("UseAccount(%s): %v", other, err)
+		}
+		if got, _ := b.AccountID(ctx); got != other {
+			t.Fatalf("AccountID after switch = %s, want %s", got, other)
+		}
+		if _, err := b.GetDomain(ctx, contractActiveZone); !isNotFound(err) {
+			t.Errorf("GetDomain(%s) in another account: want not-found, got %v", contractActiveZone, err)
+		}
+
+		if err := b.UseAccount(ctx, "1"); !isNotFound(err) {
+			t.Errorf("UseAccount(unknown): want not-found, got %v", err)
+		}
+		if got, _ := b.AccountID(ctx); got != other {
+			t.Errorf("failed UseAccount changed the account to %s", got)
+		}
+
+		if err := b.UseAccount(ctx, start); err != nil {
+			t.Fatalf("UseAccount(%s): %v", start, err)
+		}
+		if _, err := b.GetDomain(ctx, contractActiveZone); err != nil {
+			t.Errorf("GetDomain after switching back: %v", err)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		b := newBackend(t)
+		checks := map[string]error{}
+		_, checks["GetDomain"] = b.GetDomain(ctx, contractMissingName)
+		checks["DeleteDomain"] = b.DeleteDomain(ctx, contractMissingName)
+		_, checks["GetZone"] = b.GetZone(ctx, contractMissingName)
+		_, checks["GetZoneFile"] = b.GetZoneFile(ctx, contractMissingName)
+		_, checks["CheckZoneDistribution"] = b.CheckZoneDistribution(ctx, contractMissingName)
+		checks["ActivateZoneDNS"] = b.ActivateZoneDNS(ctx, contractMissingName)
+		checks["DeactivateZoneDNS"] = b.DeactivateZoneDNS(ctx, contractMissingName)
+		_, checks["ListRecords"] = b.ListRecords(ctx, contractMissingName)
+		_, checks["GetRecord"] = b.GetRecord(ctx, contractActiveZone, contractMissingID)
+		_, checks["CheckRecordDistribution"] = b.CheckRecordDistribution(ctx, contractActiveZone, contractMissingID)
+		_, checks["CreateRecord"] = b.CreateRecord(ctx, contractMissingName, dnsimple.ZoneRecord{Type: "TXT", Content: "hello"})
+		checks["DeleteRecord"] = b.DeleteRecord(ctx, contractActiveZone, contractMissingID)
+		checks["DeleteRecord(missing zone)"] = b.DeleteRecord(ctx, contractMissingName, contractMissingID)
+		checks["DeleteWebhook"] = b.DeleteWebhook(ctx, contractMissingID)
+		_, checks["GetDnssec"] = b.GetDnssec(ctx, contractMissingName)
+		checks["EnableDnssec"] = b.EnableDnssec(ctx, contractMissingName)
+		checks["DisableDnssec"] = b.DisableDnssec(ctx, contractMissingName)
+		_, checks["ListDSRecords"] = b.ListDSRecords(ctx, contractMissingName)
+		_, checks["UpdateZoneNameServers"] = b.UpdateZoneNameServers(ctx, contractMissingName, []string{"ns1.example.net"})
+		_, checks["ListEmailForwards"] = b.ListEmailForwards(ctx, contractMissingName)
+		_, checks["CreateEmailForward"] = b.CreateEmailForward(ctx, contractMissingName, "hello", "ops@example.net")
+		checks["DeleteEmailForward"] = b.DeleteEmailForward(ctx, contractActiveZone, contractMissingID)
+		checks["DeleteEmailForward(missing domain)"] = b.DeleteEmailForward(ctx, contractMissingName, contractMissingID)
+		_, checks["ListTemplateRecords"] = b.ListTemplateRecords(ctx, contractMissingName)
+		checks["ApplyTemplate"] = b.ApplyTemplate(ctx, contractMissingName, contractActiveZone)
+		checks["ApplyTemplate(missing domain)"] = b.ApplyTemplate(ctx, contractTemplate, contractMissingName)
+
+		for name, err := range checks {
+			if !isNotFound(err) {
+				t.Errorf("%s: want not-found error, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("ListOrdering", func(t *testing.T) {
+		b := newBackend(t)
+		domains, err := b.ListDomains(ctx)
+		if err != nil {
+			t.Fatalf("ListDomains: %v", err)
+		}
+		for i := 1; i < len(domains); i++ {
+			if domains[i-1].Name >= domains[i].Name {
+				t.Fatalf("domains not sorted by name at %d: %q >= %q", i, domains[i-1].Name, domains[i].Name)
+			}
+		}
+		zones, err := b.ListZones(ctx)
+		if err != nil {
+			t.Fatalf("ListZones: %v", err)
+		}
+		for i := 1; i < len(zones); i++ {
+			if zones[i-1].Name >= zones[i].Name {
+				t.Fatalf("zones not sorted by name at %d: %q >= %q", i, zones[i-1].Name, zones[i].Name)
+			}
+		}
+	})
+
+	t.Run("RecordOrdering", func(t *testing.T) {
+		records, err := newBackend(t).ListRecords(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("ListRecords: %v", err)
+		}
+		if len(records) < 2 {
+			t.Fatalf("want several records in %s, got %d", contractActiveZone, len(records))
+		}
+		for i := 1; i < len(records); i++ {
+			if records[i-1].ID >= records[i].ID {
+				t.Fatalf("records not sorted by ID at %d: %d >= %d", i, records[i-1].ID, records[i].ID)
+			}
+		}
+	})
+
+	t.Run("UpdateZoneNameServers", func(t *testing.T) {
+		b := newBackend(t)
+		before, err := b.ListRecords(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("ListRecords: %v", err)
+		}
+		if got := nameservers.FromRecords(before); len(got) != len(demoNameServers) {
+			t.Fatalf("seeded name servers = %v, want %v", got, demoNameServers)
+		}
+
+		want := []string{"ns1.dr.example.net", "ns2.dr.example.net"}
+		created, err := b.UpdateZoneNameServers(ctx, contractActiveZone, want)
+		if err != nil {
+			t.Fatalf("UpdateZoneNameServers: %v", err)
+		}
+		if got := nameservers.FromRecords(created); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("returned name servers = %v, want %v", got, want)
+		}
+
+		after, err := b.ListRecords(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("ListRecords after update: %v", err)
+		}
+		if got := nameservers.FromRecords(after); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("listed name servers = %v, want %v", got, want)
+		}
+		if len(after) != len(before)-len(demoNameServers)+len(want) {
+			t.Errorf("want only the NS records replaced: %d records before, %d after", len(before), len(after))
+		}
+		for i := 1; i < len(after); i++ {
+			if after[i-1].ID >= after[i].ID {
+				t.Fatalf("records not sorted by ID after update at %d", i)
+			}
+		}
+	})
+
+	t.Run("GetRecordMatchesList", func(t *testing.T) {
+		b := newBackend(t)
+		records, err := b.ListRecords(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("ListRecords: %v", err)
+		}
+		for _, want := range records {
+			got, err := b.GetRecord(ctx, contractActiveZone, want.ID)
+			if err != nil {
+				t.Fatalf("GetRecord(%d): %v", want.ID, err)
+			}
+			if got.ID != want.ID || got.Type != want.Type || got.Name != want.Name || got.Content != want.Content || got.TTL != want.TTL {
+				t.Errorf("GetRecord(%d) = %+v, want %+v", want.ID, *got, want)
+			}
+		}
+	})
+
+	t.Run("CreateRecord", func(t *testing.T) {
+		b := newBackend(t)
+		before, err := b.ListRecords(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("ListRecords: %v", err)
+		}
+		want := dnsimple.ZoneRecord{Type: "MX", Name: "mail", Content: "mx.example.net", Priority: 10}
+		created, err := b.CreateRecord(ctx, contractActiveZone, want)
+		if err != nil {
+			t.Fatalf("CreateRecord: %v", err)
+		}
+		if created.ID == 0 || created.TTL == 0 {
+			t.Errorf("created record lacks an ID or TTL: %+v", *created)
+		}
+		got, err := b.GetRecord(ctx, contractActiveZone, created.ID)
+		if err != nil {
+			t.Fatalf("GetRecord(%d): %v", created.ID, err)
+		}
+		if got.Type != want.Type || got.Name != want.Name || got.Content != want.Content || got.Priority != want.Priority {
+			t.Errorf("GetRecord = %+v, want %+v", *got, want)
+		}
+		after, err := b.ListRecords(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("ListRecords after create: %v", err)
+		}
+		if len(after) != len(before)+1 || after[len(after)-1].ID != created.ID {
+			t.Errorf("want the new record listed last: %d records before, %d after", len(before), len(after))
+		}
+	})
+
+	t.Run("DeleteRecord", func(t *testing.T) {
+		b := newBackend(t)
+		before, err := b.ListRecords(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("ListRecords: %v", err)
+		}
+		target := before[len(before)/2]
+
+		if err := b.DeleteRecord(ctx, contractActiveZone, target.ID); err != nil {
+			t.Fatalf("DeleteRecord: %v", err)
+		}
+		if _, err := b.GetRecord(ctx, contractActiveZone, target.ID); !isNotFound(err) {
+			t.Errorf("GetRecord after delete: want not-found, got %v", err)
+		}
+		if err := b.DeleteRecord(ctx, contractActiveZone, target.ID); !isNotFound(err) {
+			t.Errorf("second DeleteRecord: want not-found, got %v", err)
+		}
+
+		after, err := b.ListRecords(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("ListRecords after delete: %v", err)
+		}
+		if len(after) != len(before)-1 {
+			t.Fatalf("want %d records after delete, got %d", len(before)-1, len(after))
+		}
+		for _, r := range after {
+			if r.ID == target.ID {
+				t.Fatalf("deleted record %d still listed", target.ID)
+			}
+		}
+	})
+
+	t.Run("DeleteDomain", func(t *testing.T) {
+		b := newBackend(t)
+		if err := b.DeleteDomain(ctx, contractActiveZone); err != nil {
+			t.Fatalf("DeleteDomain: %v", err)
+		}
+		if _, err := b.GetDomain(ctx, contractActiveZone); !isNotFound(err) {
+			t.Errorf("GetDomain after delete: want not-found, got %v", err)
+		}
+		if _, err := b.GetZone(ctx, contractActiveZone); !isNotFound(err) {
+			t.Errorf("GetZone after domain delete: want not-found, got %v", err)
+		}
+		if err := b.DeleteDomain(ctx, contractActiveZone); !isNotFound(err) {
+			t.Errorf("second DeleteDomain: want not-found, got %v", err)
+		}
+		domains, err := b.ListDomains(ctx)
+		if err != nil {
+			t.Fatalf("ListDomains: %v", err)
+		}
+		for _, d := range domains {
+			if d.Name == contractActiveZone {
+				t.Fatalf("deleted domain %s still listed", d.Name)
+			}
+		}
+	})
+
+	t.Run("Dnssec", func(t *testing.T) {
+		b := newBackend(t)
+		assertEnabled := func(step string, want bool) {
+			t.Helper()
+			got, err := b.GetDnssec(ctx, contractSignedDomain)
+			if err != nil {
+				t.Fatalf("%s: GetDnssec: %v", step, err)
+			}
+			if got != want {
+				t.Fatalf("%s: DNSSEC enabled = %v, want %v", step, got, want)
+			}
+		}
+
+		assertEnabled("seed", true)
+		ds, err := b.ListDSRecords(ctx, contractSignedDomain)
+		if err != nil {
+			t.Fatalf("ListDSRecords: %v", err)
+		}
+		if len(ds) == 0 || ds[0].Algorithm == "" || ds[0].Digest == "" {
+			t.Fatalf("want a seeded DS record for %s, got %+v", contractSignedDomain, ds)
+		}
+		for i := 1; i < len(ds); i++ {
+			if ds[i-1].ID >= ds[i].ID {
+				t.Fatalf("DS records not sorted by ID at %d", i)
+			}
+		}
+
+		for i := 0; i < 2; i++ {
+			if err := b.DisableDnssec(ctx, contractSignedDomain); err != nil {
+				t.Fatalf("DisableDnssec: %v", err)
+			}
+			assertEnabled("disable", false)
+		}
+		if err := b.EnableDnssec(ctx, contractSignedDomain); err != nil {
+			t.Fatalf("EnableDnssec: %v", err)
+		}
+		assertEnabled("enable", true)
+
+		if on, err := b.GetDnssec(ctx, contractInactiveZone); err != nil || on {
+			t.Errorf("GetDnssec(%s) = %v, %v; want unsigned", contractInactiveZone, on, err)
+		}
+	})
+
+	t.Run("Webhooks", func(t *testing.T) {
+		b := newBackend(t)
+		before, err := b.ListWebhooks(ctx)
+		if err != nil {
+			t.Fatalf("ListWebhooks: %v", err)
+		}
+
+		const url = "https://hooks.example.net/dnsimple"
+		created, err := b.CreateWebhook(ctx, url)
+		if err != nil {
+			t.Fatalf("CreateWebhook: %v", err)
+		}
+		if created.ID == 0 || created.URL != url {
+			t.Fatalf("CreateWebhook = %+v", *created)
+		}
+		for _, w := range before {
+			if w.ID == created.ID {
+				t.Fatalf("CreateWebhook reused ID %d", w.ID)
+			}
+		}
+
+		after, err := b.ListWebhooks(ctx)
+		if err != nil {
+			t.Fatalf("ListWebhooks after create: %v", err)
+		}
+		if len(after) != len(before)+1 {
+			t.Fatalf("want %d webhooks after create, got %d", len(before)+1, len(after))
+		}
+		for i := 1; i < len(after); i++ {
+			if after[i-1].ID >= after[i].ID {
+				t.Fatalf("webhooks not sorted by ID at %d: %d >= %d", i, after[i-1].ID, after[i].ID)
+			}
+		}
+
+		if err := b.DeleteWebhook(ctx, created.ID); err != nil {
+			t.Fatalf("DeleteWebhook: %v", err)
+		}
+		if err := b.DeleteWebhook(ctx, created.ID); !isNotFound(err) {
+			t.Errorf("second DeleteWebhook: want not-found, got %v", err)
+		}
+		final, err := b.ListWebhooks(ctx)
+		if err != nil {
+			t.Fatalf("ListWebhooks after delete: %v", err)
+		}
+		if len(final) != len(before) {
+			t.Fatalf("want %d webhooks after delete, got %d", len(before), len(final))
+		}
+	})
+
+	t.Run("EmailForwards", func(t *testing.T) {
+		b := newBackend(t)
+		parked, err := b.ListEmailForwards(ctx, contractParkedDomain)
+		if err != nil {
+			t.Fatalf("ListEmailForwards: %v", err)
+		}
+		if len(parked) != 2 || parked[1].AliasEmail != forwarding.CatchAll+"@"+contractParkedDomain {
+			t.Fatalf("seeded forwards of %s = %+v, want hello@ and a catch-all", contractParkedDomain, parked)
+		}
+
+		before, err := b.ListEmailForwards(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("ListEmailForwards: %v", err)
+		}
+		created, err := b.CreateEmailForward(ctx, contractActiveZone, "sales", "ops@example.net")
+		if err != nil {
+			t.Fatalf("CreateEmailForward: %v", err)
+		}
+		if created.ID == 0 || created.AliasEmail != "sales@"+contractActiveZone || created.DestinationEmail != "ops@example.net" {
+			t.Fatalf("CreateEmailForward = %+v", *created)
+		}
+		after, err := b.ListEmailForwards(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("ListEmailForwards after create: %v", err)
+		}
+		if len(after) != len(before)+1 || after[len(after)-1].ID != created.ID {
+			t.Fatalf("want the new forward listed last: %d forwards before, %d after", len(before), len(after))
+		}
+		for i := 1; i < len(after); i++ {
+			if after[i-1].ID >= after[i].ID {
+				t.Fatalf("forwards not sorted by ID at %d", i)
+			}
+		}
+
+		if err := b.DeleteEmailForward(ctx, contractActiveZone, created.ID); err != nil {
+			t.Fatalf("DeleteEmailForward: %v", err)
+		}
+		if err := b.DeleteEmailForward(ctx, contractActiveZone, created.ID); !isNotFound(err) {
+			t.Errorf("second DeleteEmailForward: want not-found, got %v", err)
+		}
+	})
+
+	t.Run("Registrar", func(t *testing.T) {
+		b := newBackend(t)
+		results, err := registrar.Check(ctx, b, []string{contractFreeName, contractTakenName, contractActiveZone, "zq.dev", contractMissingName})
+		if err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+		free, taken, owned, premium, unsupported := results[0], results[1], results[2], results[3], results[4]
+		if !free.Available || free.Premium || free.RegistrationPrice != 14 || free.RenewalPrice != 14 || free.TransferPrice != 14 || free.Error != "" {
+			t.Errorf("%s = %+v", contractFreeName, free)
+		}
+		if taken.Available || taken.TransferPrice != 49 {
+			t.Errorf("%s = %+v", contractTakenName, taken)
+		}
+		if owned.Available {
+			t.Errorf("%s is in the account but reported available", contractActiveZone)
+		}
+		if !premium.Premium || premium.RegistrationPrice != 600 {
+			t.Errorf("zq.dev = %+v, want premium", premium)
+		}
+		if !strings.Contains(unsupported.Error, "not supported") {
+			t.Errorf("%s = %+v, want unsupported TLD", contractMissingName, unsupported)
+		}
+	})
+
+	t.Run("Templates", func(t *testing.T) {
+		b := newBackend(t)
+		list, err := b.ListTemplates(ctx)
+		if err != nil {
+			t.Fatalf("ListTemplates: %v", err)
+		}
+		for i := 1; i < len(list); i++ {
+			if list[i-1].Name >= list[i].Name {
+				t.Fatalf("templates not sorted by name at %d: %q >= %q", i, list[i-1].Name, list[i].Name)
+			}
+		}
+		tmpl, err := b.ListTemplateRecords(ctx, contractTemplate)
+		if err != nil {
+			t.Fatalf("ListTemplateRecords: %v", err)
+		}
+		for i := 1; i < len(tmpl); i++ {
+			if tmpl[i-1].ID >= tmpl[i].ID {
+				t.Fatalf("template records not sorted by ID at %d", i)
+			}
+		}
+
+		before, err := b.ListRecords(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("ListRecords: %v", err)
+		}
+		plan := templates.Preview(contractTemplate, contractActiveZone, tmpl, before)
+		if len(plan.Add) == 0 || len(plan.Conflicts) > 0 {
+			t.Fatalf("want a clean plan that adds records, got %+v", plan)
+		}
+		if err := b.ApplyTemplate(ctx, contractTemplate, contractActiveZone); err != nil {
+			t.Fatalf("ApplyTemplate: %v", err)
+		}
+		after, err := b.ListRecords(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("ListRecords after apply: %v", err)
+		}
+		if len(after) != len(before)+len(plan.Add) {
+			t.Errorf("want %d records after apply, got %d", len(before)+len(plan.Add), len(after))
+		}
+		if again := templates.Preview(contractTemplate, contractActiveZone, tmpl, after); len(again.Add) != 0 {
+			t.Errorf("records left to add after apply: %+v", again.Add)
+		}
+
+		err = b.ApplyTemplate(ctx, contractClashing, contractActiveZone)
+		if err == nil || isNotFound(err) {
+			t.Errorf("ApplyTemplate with a conflict: want a refusal, got %v", err)
+		}
+	})
+
+	t.Run("ZoneActivation", func(t *testing.T) {
+		b := newBackend(t)
+		assertActive := func(step string, want bool) {
+			t.Helper()
+			z, err := b.GetZone(ctx, contractInactiveZone)
+			if err != nil {
+				t.Fatalf("%s: GetZone: %v", step, err)
+			}
+			if z.Active != want {
+				t.Fatalf("%s: GetZone active = %v, want %v", step, z.Active, want)
+			}
+			zones, err := b.ListZones(ctx)
+			if err != nil {
+				t.Fatalf("%s: ListZones: %v", step, err)
+			}
+			for _, lz := range zones {
+				if lz.Name == contractInactiveZone && lz.Active != want {
+					t.Fatalf("%s: ListZones active = %v, want %v", step, lz.Active, want)
+				}
+			}
+		}
+
+		assertActive("seed", false)
+		for i := 0; i < 2; i++ {
+			if err := b.ActivateZoneDNS(ctx, contractInactiveZone); err != nil {
+				t.Fatalf("ActivateZoneDNS: %v", err)
+			}
+			assertActive("activate", true)
+		}
+		for i := 0; i < 2; i++ {
+			if err := b.DeactivateZoneDNS(ctx, contractInactiveZone); err != nil {
+				t.Fatalf("DeactivateZoneDNS: %v", err)
+			}
+			assertActive("deactivate", false)
+		}
+	})
+
+	t.Run("ZoneFile", func(t *testing.T) {
+		b := newBackend(t)
+		file, err := b.GetZoneFile(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("GetZoneFile: %v", err)
+		}
+		if file != strings.TrimSpace(file) {
+			t.Errorf("zone file has surrounding whitespace: %q", file)
+		}
+
+		lines := strings.Split(file, "\n")
+		if want := "$ORIGIN " + contractActiveZone + "."; lines[0] != want {
+			t.Errorf("first line = %q, want %q", lines[0], want)
+		}
+
+		var soa int
+		var rrs []string
+		for _, line := range lines {
+			switch {
+			case strings.Contains(line, " IN SOA "):
+				soa++
+			case strings.Contains(line, " IN "):
+				rrs = append(rrs, line)
+			}
+		}
+		if soa != 1 {
+			t.Errorf("want exactly one SOA line, got %d", soa)
+		}
+
+		records, err := b.ListRecords(ctx, contractActiveZone)
+		if err != nil {
+			t.Fatalf("ListRecords: %v", err)
+		}
+		if len(rrs) != len(records) {
+			t.Fatalf("zone file has %d resource records, zone has %d", len(rrs), len(records))
+		}
+		for _, r := range records {
+			found := false
+			for _, line := range rrs {
+				if strings.Contains(line, " IN "+r.Type+" ") && strings.HasSuffix(line, r.Content) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("zone file missing %s record %q", r.Type, r.Content)
+			}
+		}
+	})
+}
@@ -1,7 +1,6 @@
 package tui
 
 import (
-	"context"
 	"fmt"
 	"sort"
 	"strconv"
@@ -11,6 +10,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/filter"
 )
 
 type category int
@@ -19,6 +20,8 @@ const (
 	categoryDomains category = iota
 	categoryZones
 	categoryRecords
+	categoryWebhooks
+	categoryTemplates
 )
 
 func (c category) Label() string {
@@ -29,6 +32,10 @@ func (c category) Label() string {
 		return "Zones"
 	case categoryRecords:
 		return "Records"
+	case categoryWebhooks:
+		return "Webhooks"
+	case categoryTemplates:
+		return "Templates"
 	default:
 		return "Unknown"
 	}
@@ -48,6 +55,8 @@ const (
 	browserZonesList
 	browserRecordsZones
 	browserRecordsList
+	browserWebhooksList
+	browserTemplatesList
 )
 
 type browserItem struct {
@@ -55,9 +64,12 @@ type browserItem struct {
 	Title    string
 	Subtitle string
 	ID       int64
+	// Data is the API resource behind the row, for the advanced filter.
+	Data interface{}
 }
 
 type browserListLoadedMsg struct {
+	gen       int
 	screen    browserScreen
 	header    string
 	items     []browserItem
@@ -66,6 +78,7 @@ type browserListLoadedMsg struct {
 }
 
 type browserDetailLoadedMsg struct {
+	gen   int
 	title string
 	body  string
 	err   error
@@ -76,6 +89,14 @@ type domainSearchMatch struct {
 	score     int
 }
 
+// whereModal is the advanced filter box. It takes the same expression
+// language as --where on the CLI.
+type whereModal struct {
+	visible bool
+	input   textinput.Model
+	errMsg  string
+}
+
 type domainSearchModal struct {
 	visible  bool
 	input    textinput.Model
@@ -100,6 +121,14 @@ type BrowserModel struct {
 	recordsZone string
 	domainDash  DomainDashboardModel
 	search      domainSearchModal
+	whereBox    whereModal
+	where       *filter.Expr
+	all         []browserItem
+	confirm     confirmModal
+	hookForm    webhookForm
+	req         requestScope
+	interrupted bool
+	pendingKey  string
 }
 
 func NewBrowserModel(cat category) BrowserModel {
@@ -113,6 +142,10 @@ func NewBrowserModel(cat category) BrowserModel {
 		screen = browserZonesList
 	case categoryRecords:
 		screen = browserRecordsZones
+	case categoryWebhooks:
+		screen = browserWebhooksList
+	case categoryTemplates:
+		screen = browserTemplatesList
 	}
 
 	return BrowserModel{
@@ -123,6 +156,11 @@ func NewBrowserModel(cat category) BrowserModel {
 		search: domainSearchModal{
 			input: newDomainSearchInput(),
 		},
+		whereBox: whereModal{
+			input: newWhereInput(),
+		},
+		confirm:  newConfirmModal(),
+		hookForm: webhookForm{input: newWebhookURLInput()},
 	}
 }
 
@@ -144,6 +182,25 @@ func (m *BrowserModel) Update(msg tea.Msg) tea.Cmd {
 			return m.updateSearchModal(keyMsg)
 		}
 	}
+	if m.whereBox.visible {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return m.updateWhereModal(keyMsg)
+		}
+	}
+	if m.confirm.visible {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			confirmed, cmd := m.confirm.update(keyMsg)
+			if confirmed {
+				return tea.Batch(m.spinner.Tick, m.mutationCmd())
+			}
+			return cmd
+		}
+	}
+	if m.hookForm.visible {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			return m.updateWebhookForm(keyMsg)
+		}
+	}
 
 	if m.category == categoryDomains && m.screen == browserDomainDashboard {
 		switch msg := msg.(type) {
@@ -181,6 +238,7 @@ func (m *BrowserModel) Update(msg tea.Msg) tea.Cmd {
 			return m.handleEnter()
 		case matches(msg, keys.Back):
 			if m.category == categoryRecords && m.screen == browserRecordsList {
+				m.clearWhere()
 				m.screen = browserRecordsZones
 				m.recordsZone = ""
 				m.selected = 0
@@ -202,12 +260,28 @@ func (m *BrowserModel) Update(msg tea.Msg) tea.Cmd {
 				m.openSearchModal()
 				return textinput.Blink
 			}
+		case msg.String() == "F":
+			if m.all != nil || m.where != nil {
+				m.openWhereModal()
+				return textinput.Blink
+			}
 		case msg.String() == "f":
 			if m.category == categoryZones && m.screen == browserZonesList && len(m.items) > 0 {
 				m.errMsg = ""
 				m.loading = true
 				return tea.Batch(m.spinner.Tick, m.loadZoneFileCmd())
 			}
+		case msg.String() == "n":
+			if m.category == categoryWebhooks {
+				m.openWebhookForm()
+				return textinput.Blink
+			}
+		case msg.String() == "D":
+			if m.category == categoryWebhooks && len(m.items) > 0 {
+				m.confirm.open(mutationDeleteWebhook, "Delete Webhook",
+					"This will permanently delete the webhook. DNSimple stops sending events to it.\n\n"+m.webhookTarget())
+				return textinput.Blink
+			}
 		case msg.String() == "x":
 			if len(m.items) == 0 {
 				return nil
@@ -224,6 +298,9 @@ func (m *BrowserModel) Update(msg tea.Msg) tea.Cmd {
 			}
 		}
 	case browserListLoadedMsg:
+		if !m.req.current(msg.gen) {
+			return nil
+		}
 		m.loading = false
 		if msg.err != nil {
 			m.errMsg = msg.err.Error()
@@ -232,9 +309,19 @@ func (m *BrowserModel) Update(msg tea.Msg) tea.Cmd {
 			return nil
 		}
 		m.errMsg = ""
-		m.items = msg.items
+		m.all = msg.items
 		m.listHeader = msg.header
 		m.statusMsg = msg.statusMsg
+		m.applyWhere()
+		if m.pendingKey != "" {
+			for i, item := range m.items {
+				if item.Key == m.pendingKey {
+					m.selected = i
+					break
+				}
+			}
+			m.pendingKey = ""
+		}
 		if m.search.visible {
 			m.updateSearchMatches()
 		}
@@ -245,6 +332,9 @@ func (m *BrowserModel) Update(msg tea.Msg) tea.Cmd {
 		}
 		return nil
 	case browserDetailLoadedMsg:
+		if !m.req.current(msg.gen) {
+			return nil
+		}
 		m.loading = false
 		if msg.err != nil {
 			m.errMsg = msg.err.Error()
@@ -254,8 +344,10 @@ func (m *BrowserModel) Update(msg tea.Msg) tea.Cmd {
 		m.detailTitle = msg.title
 		m.detailBody = msg.body
 		return nil
+	case browserMutationMsg:
+		return m.handleMutation(msg)
 	case spinner.TickMsg:
-		if m.loading {
+		if m.loading || m.confirm.busy || m.hookForm.busy {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
 			return cmd
@@ -271,6 +363,7 @@ func (m *BrowserModel) handleEnter() tea.Cmd {
 
 	if m.category == categoryRecords && m.screen == browserRecordsZones {
 		m.recordsZone = m.items[m.selected].Key
+		m.clearWhere()
 		m.screen = browserRecordsList
 		m.selected = 0
 		m.detailTitle = ""
@@ -282,6 +375,7 @@ func (m *BrowserModel) handleEnter() tea.Cmd {
 
 	if m.category == categoryDomains && m.screen == browserDomainsList {
 		item := m.items[m.selected]
+		m.domainDash.CancelRequests()
 		m.domainDash = NewDomainDashboardModel(item.Key)
 		m.domainDash.SetSize(m.width, m.height)
 		m.screen = browserDomainDashboard
@@ -306,6 +400,10 @@ func (m BrowserModel) View() string {
 		subtitle = "Records in " + m.recordsZone
 	} else if m.category == categoryZones {
 		subtitle = "Use f for zone file and x for distribution status"
+	} else if m.category == categoryWebhooks {
+		subtitle = "URLs DNSimple posts account events to"
+	} else if m.category == categoryTemplates {
+		subtitle = "Record sets to apply from a domain dashboard's Actions"
 	}
 
 	body := []string{
@@ -322,6 +420,15 @@ func (m BrowserModel) View() string {
 	if m.search.visible {
 		content = overlayDialog(content, m.searchModalView())
 	}
+	if m.whereBox.visible {
+		content = overlayDialog(content, m.whereModalView())
+	}
+	if m.confirm.visible {
+		content = overlayDialog(content, m.confirm.view(m.spinner, m.width, m.height))
+	}
+	if m.hookForm.visible {
+		content = overlayDialog(content, m.webhookFormView())
+	}
 	return content
 }
 
@@ -338,8 +445,20 @@ func (m BrowserModel) listPanel() string {
 		return strings.Join(lines, "\n")
 	}
 
+	if m.where != nil {
+		lines = append(lines, linkStyle.Render(truncateText("Filter: "+m.where.String(), m.listRowWidth()))+
+			subtitleStyle.Render(fmt.Sprintf("  (%d of %d)", len(m.items), len(m.all))))
+	}
+
 	if len(m.items) == 0 {
-		lines = append(lines, "", subtitleStyle.Render("No items found."))
+		empty := "No items found."
+		if m.where != nil {
+			empty = "No items match the filter. Press F to change it."
+		}
+		lines = append(lines, "", subtitleStyle.Render(empty))
+		if m.statusMsg != "" && m.where != nil {
+			lines = append(lines, "", subtitleStyle.Render(m.statusMsg))
+		}
 		return strings.Join(lines, "\n")
 	}
 
@@ -397,16 +516,20 @@ func (m BrowserModel) detailPanel() string {
 func (m BrowserModel) footerHelp() string {
 	switch {
 	case m.category == categoryRecords && m.screen == browserRecordsZones:
-		return "Enter: open zone   /: global domain search   r: refresh   esc: home   q: quit"
+		return "Enter: open zone   F: filter   /: global domain search   r: refresh   esc: home   q: quit"
 	case m.category == categoryRecords && m.screen == browserRecordsList:
-		return "Enter: record details   x: distribution   /: global domain search   r: refresh   esc: zones   q: quit"
+		return "Enter: record details   x: distribution   F: filter   /: global domain search   r: refresh   esc: zones   q: quit"
 	case m.category == categoryZones && m.screen == browserZonesList:
-		return "Enter: details   f: zone file   x: distribution   /: global domain search   r: refresh   esc: home   q: quit"
+		return "Enter: details   f: zone file   x: distribution   F: filter   /: global domain search   r: refresh   esc: home   q: quit"
+	case m.category == categoryWebhooks:
+		return "Enter: details   n: new webhook   D: delete   F: filter   r: refresh   esc: home   q: quit"
+	case m.category == categoryTemplates:
+		return "Enter: details and records   F: filter   r: refresh   esc: home   q: quit"
 	default:
 		if m.category == categoryDomains {
-			return "Enter: open domain dashboard   /: global search   r: refresh   esc: home   q: quit"
+			return "Enter: open domain dashboard   F: filter   /: global search   r: refresh   esc: home   q: quit"
 		}
-		return "Enter: details   /: global domain search   r: refresh   esc: home   q: quit"
+		return "Enter: details   F: filter   /: global domain search   r: refresh   esc: home   q: quit"
 	}
 }
 
@@ -420,20 +543,20 @@ func (m BrowserModel) defaultHeader() string {
 	return m.category.Label()
 }
 
-func (m BrowserModel) loadListCmd() tea.Cmd {
+func (m *BrowserModel) loadListCmd() tea.Cmd {
 	cat := m.category
 	screen := m.screen
 	zone := m.recordsZone
+	ctx, gen := m.req.begin()
 
 	return func() tea.Msg {
-		ctx := context.Background()
 		backend := getBackend()
 
 		switch {
 		case cat == categoryDomains:
 			domains, err := backend.ListDomains(ctx)
 			if err != nil {
-				return browserListLoadedMsg{screen: screen, err: err}
+				return browserListLoadedMsg{gen: gen, screen: screen, err: err}
 			}
 			items := make([]browserItem, 0, len(domains))
 			for _, d := range domains {
@@ -449,9 +572,11 @@ func (m BrowserModel) loadListCmd() tea.Cmd {
 					Title:    d.Name,
 					Subtitle: meta,
 					ID:       d.ID,
+					Data:     d,
 				})
 			}
 			return browserListLoadedMsg{
+				gen:       gen,
 				screen:    screen,
 				header:    fmt.Sprintf("Domains (%d)", len(items)),
 				items:     items,
@@ -461,7 +586,7 @@ func (m BrowserModel) loadListCmd() tea.Cmd {
 		case cat == categoryZones && screen == browserZonesList:
 			zones, err := backend.ListZones(ctx)
 			if err != nil {
-				return browserListLoadedMsg{screen: screen, err: err}
+				return browserListLoadedMsg{gen: gen, screen: screen, err: err}
 			}
 			items := make([]browserItem, 0, len(zones))
 			for _, z := range zones {
@@ -482,9 +607,11 @@ func (m BrowserModel) loadListCmd() tea.Cmd {
 					Title:    z.Name,
 					Subtitle: strings.Join(flags, " | "),
 					ID:       z.ID,
+					Data:     z,
 				})
 			}
 			return browserListLoadedMsg{
+				gen:       gen,
 				screen:    screen,
 				header:    fmt.Sprintf("Zones (%d)", len(items)),
 				items:     items,
@@ -494,7 +621,7 @@ func (m BrowserModel) loadListCmd() tea.Cmd {
 		case cat == categoryRecords && screen == browserRecordsZones:
 			zones, err := backend.ListZones(ctx)
 			if err != nil {
-				return browserListLoadedMsg{screen: screen, err: err}
+				return browserListLoadedMsg{gen: gen, screen: screen, err: err}
 			}
 			items := make([]browserItem, 0, len(zones))
 			for _, z := range zones {
@@ -516,9 +643,11 @@ func (m BrowserModel) loadListCmd() tea.Cmd {
 					Title:    z.Name,
 					Subtitle: strings.Join(flags, " | "),
 					ID:       z.ID,
+					Data:     z,
 				})
 			}
 			return browserListLoadedMsg{
+				gen:       gen,
 				screen:    screen,
 				header:    fmt.Sprintf("Records / Zones (%d)", len(items)),
 				items:     items,
@@ -528,7 +657,7 @@ func (m BrowserModel) loadListCmd() tea.Cmd {
 		case cat == categoryRecords && screen == browserRecordsList:
 			records, err := backend.ListRecords(ctx, zone)
 			if err != nil {
-				return browserListLoadedMsg{screen: screen, err: err}
+				return browserListLoadedMsg{gen: gen, screen: screen, err: err}
 			}
 			items := make([]browserItem, 0, len(records))
 			for _, r := range records {
@@ -549,21 +678,69 @@ func (m BrowserModel) loadListCmd() tea.Cmd {
 					Title:    title,
 					Subtitle: meta,
 					ID:       r.ID,
+					Data:     r,
 				})
 			}
 			return browserListLoadedMsg{
+				gen:       gen,
 				screen:    screen,
 				header:    fmt.Sprintf("Records / %s (%d)", zone, len(items)),
 				items:     items,
 				statusMsg: "Use Enter to inspect a record. Esc returns to zones.",
 			}
+
+		case cat == categoryWebhooks:
+			webhooks, err := backend.ListWebhooks(ctx)
+			if err != nil {
+				return browserListLoadedMsg{gen: gen, screen: screen, err: err}
+			}
+			items := make([]browserItem, 0, len(webhooks))
+			for _, w := range webhooks {
+				items = append(items, browserItem{
+					Key:      strconv.FormatInt(w.ID, 10),
+					Title:    w.URL,
+					Subtitle: "#" + strconv.FormatInt(w.ID, 10),
+					ID:       w.ID,
+					Data:     w,
+				})
+			}
+			return browserListLoadedMsg{
+				gen:       gen,
+				screen:    screen,
+				header:    fmt.Sprintf("Webhooks (%d)", len(items)),
+				items:     items,
+				statusMsg: "Press n to add a webhook, D to delete the selected one.",
+			}
+
+		case cat == categoryTemplates:
+			list, err := backend.ListTemplates(ctx)
+			if err != nil {
+				return browserListLoadedMsg{gen: gen, screen: screen, err: err}
+			}
+			items := make([]browserItem, 0, len(list))
+			for _, t := range list {
+				items = append(items, browserItem{
+					Key:      t.SID,
+					Title:    t.Name,
+					Subtitle: t.SID,
+					ID:       t.ID,
+					Data:     t,
+				})
+			}
+			return browserListLoadedMsg{
+				gen:       gen,
+				screen:    screen,
+				header:    fmt.Sprintf("Templates (%d)", len(items)),
+				items:     items,
+				statusMsg: "Use Enter to see a template's records. Apply one from a domain dashboard.",
+			}
 		}
 
-		return browserListLoadedMsg{screen: screen, err: fmt.Errorf("unsupported browser state")}
+		return browserListLoadedMsg{gen: gen, screen: screen, err: fmt.Errorf("unsupported browser state")}
 	}
 }
 
-func (m BrowserModel) loadDetailCmd() tea.Cmd {
+func (m *BrowserModel) loadDetailCmd() tea.Cmd {
 	if len(m.items) == 0 {
 		return nil
 	}
@@ -571,16 +748,16 @@ func (m BrowserModel) loadDetailCmd() tea.Cmd {
 	cat := m.category
 	screen := m.screen
 	zone := m.recordsZone
+	ctx, gen := m.req.begin()
 
 	return func() tea.Msg {
-		ctx := context.Background()
 		backend := getBackend()
 
 		switch {
 		case cat == categoryDomains:
 			d, err := backend.GetDomain(ctx, item.Key)
 			if err != nil {
-				return browserDetailLoadedMsg{err: err}
+				return browserDetailLoadedMsg{gen: gen, err: err}
 			}
 			lines := []string{
 				"ID: " + strconv.FormatInt(d.ID, 10),
@@ -598,12 +775,12 @@ func (m BrowserModel) loadDetailCmd() tea.Cmd {
 				lines = append(lines, "Expires: "+d.ExpiresAt)
 			}
 			lines = append(lines, "Created: "+d.CreatedAt, "Updated: "+d.UpdatedAt)
-			return browserDetailLoadedMsg{title: d.Name, body: strings.Join(lines, "\n")}
+			return browserDetailLoadedMsg{gen: gen, title: d.Name, body: strings.Join(lines, "\n")}
 
 		case cat == categoryZones && screen == browserZonesList:
 			z, err := backend.GetZone(ctx, item.Key)
 			if err != nil {
-				return browserDetailLoadedMsg{err: err}
+				return browserDetailLoadedMsg{gen: gen, err: err}
 			}
 			lines := []string{
 				"ID: " + strconv.FormatInt(z.ID, 10),
@@ -614,12 +791,12 @@ func (m BrowserModel) loadDetailCmd() tea.Cmd {
 				"Created: " + z.CreatedAt,
 				"Updated: " + z.UpdatedAt,
 			}
-			return browserDetailLoadedMsg{title: z.Name, body: strings.Join(lines, "\n")}
+			return browserDetailLoadedMsg{gen: gen, title: z.Name, body: strings.Join(lines, "\n")}
 
 		case cat == categoryRecords && screen == browserRecordsList:
 			r, err := backend.GetRecord(ctx, zone, item.ID)
 			if err != nil {
-				return browserDetailLoadedMsg{err: err}
+				return browserDetailLoadedMsg{gen: gen, err: err}
 			}
 			name := r.Name
 			if name == "" {
@@ -645,69 +822,94 @@ func (m BrowserModel) loadDetailCmd() tea.Cmd {
 				"Updated: "+r.UpdatedAt,
 			)
 			return browserDetailLoadedMsg{
+				gen:   gen,
 				title: fmt.Sprintf("%s %s.%s", r.Type, name, zone),
 				body:  strings.Join(lines, "\n"),
 			}
+
+		case cat == categoryWebhooks:
+			// The list already carries everything the API has on a webhook.
+			w, _ := item.Data.(dnsimple.Webhook)
+			return browserDetailLoadedMsg{
+				gen:   gen,
+				title: "Webhook " + strconv.FormatInt(w.ID, 10),
+				body:  webhookDetail(w),
+			}
+
+		case cat == categoryTemplates:
+			t, _ := item.Data.(dnsimple.Template)
+			records, err := backend.ListTemplateRecords(ctx, t.SID)
+			if err != nil {
+				return browserDetailLoadedMsg{gen: gen, err: err}
+			}
+			return browserDetailLoadedMsg{gen: gen, title: t.Name, body: templateDetail(t, records)}
 		}
 
-		return browserDetailLoadedMsg{err: fmt.Errorf("unsupported detail view")}
+		return browserDetailLoadedMsg{gen: gen, err: fmt.Errorf("unsupported detail view")}
 	}
 }
 
-func (m BrowserModel) loadZoneFileCmd() tea.Cmd {
+func (m *BrowserModel) loadZoneFileCmd() tea.Cmd {
 	if len(m.items) == 0 {
 		return nil
 	}
 	item := m.items[m.selected]
+	ctx, gen := m.req.begin()
 	return func() tea.Msg {
-		zoneFile, err := getBackend().GetZoneFile(context.Background(), item.Key)
+		zoneFile, err := getBackend().GetZoneFile(ctx, item.Key)
 		if err != nil {
-			return browserDetailLoadedMsg{err: err}
+			return browserDetailLoadedMsg{gen: gen, err: err}
 		}
 		return browserDetailLoadedMsg{
+			gen:   gen,
 			title: "Zone file: " + item.Key,
 			body:  zoneFile,
 		}
 	}
 }
 
-func (m BrowserModel) loadZoneDistributionCmd() tea.Cmd {
+func (m *BrowserModel) loadZoneDistributionCmd() tea.Cmd {
 	if len(m.items) == 0 {
 		return nil
 	}
 	item := m.items[m.selected]
+	ctx, gen := m.req.begin()
 	return func() tea.Msg {
-		distributed, err := getBackend().CheckZoneDistribution(context.Background(), item.Key)
+		distributed, err := getBackend().CheckZoneDistribution(ctx, item.Key)
 		if err != nil {
-			return browserDetailLoadedMsg{err: err}
+			return browserDetailLoadedMsg{gen: gen, err: err}
 		}
 		status := "Not distributed yet"
 		if distributed {
 			status = "Fully distributed"
 		}
 		return browserDetailLoadedMsg{
+			gen:   gen,
 			title: "Zone distribution: " + item.Key,
 			body:  "Distributed: " + strconv.FormatBool(distributed) + "\nStatus: " + status,
 		}
 	}
 }
 
-func (m BrowserModel) loadRecordDistributionCmd() tea.Cmd {
+func (m *BrowserModel) loadRecordDistributionCmd() tea.Cmd {
 	if len(m.items) == 0 || m.recordsZone == "" {
 		return nil
 	}
 	item := m.items[m.selected]
+	zone := m.recordsZone
+	ctx, gen := m.req.begin()
 	return func() tea.Msg {
-		distributed, err := getBackend().CheckRecordDistribution(context.Background(), m.recordsZone, item.ID)
+		distributed, err := getBackend().CheckRecordDistribution(ctx, zone, item.ID)
 		if err != nil {
-			return browserDetailLoadedMsg{err: err}
+			return browserDetailLoadedMsg{gen: gen, err: err}
 		}
 		status := "Not distributed yet"
 		if distributed {
 			status = "Fully distributed"
 		}
 		return browserDetailLoadedMsg{
-			title: fmt.Sprintf("Record distribution: %d (%s)", item.ID, m.recordsZone),
+			gen:   gen,
+			title: fmt.Sprintf("Record distribution: %d (%s)", item.ID, zone),
 			body:  "Distributed: " + strconv.FormatBool(distributed) + "\nStatus: " + status,
 		}
 	}
@@ -752,7 +954,7 @@ func (m BrowserModel) listRowWidth() int {
 }
 
 func (m *BrowserModel) BlocksGlobalKeys() bool {
-	if m.search.visible {
+	if m.search.visible || m.whereBox.visible || m.confirm.visible || m.hookForm.visible {
 		return true
 	}
 	return m.category == categoryDomains &&
@@ -760,10 +962,57 @@ func (m *BrowserModel) BlocksGlobalKeys() bool {
 		m.domainDash.ModalVisible()
 }
 
+// CancelRequests aborts in-flight loads while the tab is hidden.
+func (m *BrowserModel) CancelRequests() {
+	if m.category == categoryDomains && m.screen == browserDomainDashboard {
+		m.domainDash.CancelRequests()
+		return
+	}
+	if m.loading {
+		m.loading = false
+		m.interrupted = true
+	}
+	m.req.stop()
+}
+
+// Resume reloads the list if CancelRequests interrupted a load.
+func (m *BrowserModel) Resume() tea.Cmd {
+	if m.category == categoryDomains && m.screen == browserDomainDashboard {
+		return m.domainDash.Resume()
+	}
+	if !m.interrupted {
+		return nil
+	}
+	m.interrupted = false
+	m.errMsg = ""
+	m.loading = true
+	return tea.Batch(m.spinner.Tick, m.loadListCmd())
+}
+
+// SelectedKey returns the key of the selected item, or the domain whose
+// dashboard is open.
+func (m *BrowserModel) SelectedKey() string {
+	if m.category == categoryDomains && m.screen == browserDomainDashboard {
+		return m.domainDash.domain
+	}
+	if m.selected < len(m.items) {
+		return m.items[m.selected].Key
+	}
+	return ""
+}
+
+// SelectKey selects the item with key once the list next loads.
+func (m *BrowserModel) SelectKey(key string) {
+	m.pendingKey = key
+}
+
 func (m *BrowserModel) OpenSearch() tea.Cmd {
 	if m.category != categoryDomains {
 		return nil
 	}
+	if m.screen == browserDomainDashboard {
+		m.domainDash.CancelRequests()
+	}
 	m.screen = browserDomainsList
 	m.detailTitle = ""
 	m.detailBody = ""
@@ -821,6 +1070,7 @@ func (m *BrowserModel) updateSearchModal(msg tea.KeyMsg) tea.Cmd {
 		m.closeSearchModal()
 		if m.category == categoryDomains && m.screen == browserDomainsList && m.selected >= 0 && m.selected < len(m.items) {
 			item := m.items[m.selected]
+			m.domainDash.CancelRequests()
 			m.domainDash = NewDomainDashboardModel(item.Key)
 			m.domainDash.SetSize(m.width, m.height)
 			m.screen = browserDomainDashboard
@@ -968,3 +1218,142 @@ func fuzzyScore(query, target string) (int, bool) {
 	score -= minInt(len(tr)/8, 6)
 	return score, true
 }
+
+func newWhereInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "where "
+	ti.Placeholder = `type in ("A","AAAA") && ttl < 300`
+	ti.CharLimit = 500
+	ti.Width = 60
+	return ti
+}
+
+func (m *BrowserModel) openWhereModal() {
+	m.whereBox.visible = true
+	m.whereBox.errMsg = ""
+	if m.where != nil {
+		m.whereBox.input.SetValue(m.where.String())
+	} else {
+		m.whereBox.input.SetValue("")
+	}
+	m.whereBox.input.CursorEnd()
+	m.whereBox.input.Focus()
+}
+
+func (m *BrowserModel) closeWhereModal() {
+	m.whereBox.visible = false
+	m.whereBox.errMsg = ""
+	m.whereBox.input.Blur()
+}
+
+func (m *BrowserModel) updateWhereModal(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case msg.String() == "esc":
+		m.closeWhereModal()
+		return nil
+	case matches(msg, keys.Enter):
+		src := strings.TrimSpace(m.whereBox.input.Value())
+		if src == "" {
+			m.clearWhere()
+			m.closeWhereModal()
+			return nil
+		}
+		expr, err := filter.Parse(src)
+		if err == nil {
+			err = expr.Validate(m.filterSample())
+		}
+		if err != nil {
+			m.whereBox.errMsg = err.Error()
+			return nil
+		}
+		key := m.SelectedKey()
+		m.where = expr
+		m.applyWhere()
+		m.selectKeyNow(key)
+		m.closeWhereModal()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.whereBox.input, cmd = m.whereBox.input.Update(msg)
+	m.whereBox.errMsg = ""
+	return cmd
+}
+
+// applyWhere narrows the loaded items to those matching the filter.
+func (m *BrowserModel) applyWhere() {
+	if m.where == nil {
+		m.items = m.all
+		return
+	}
+	items := make([]browserItem, 0, len(m.all))
+	for _, item := range m.all {
+		ok, err := m.where.Match(item.Data)
+		if err != nil {
+			m.statusMsg = "Filter error: " + err.Error()
+			continue
+		}
+		if ok {
+			items = append(items, item)
+		}
+	}
+	m.items = items
+	if m.selected >= len(m.items) {
+		m.selected = maxInt(0, len(m.items)-1)
+	}
+}
+
+func (m *BrowserModel) clearWhere() {
+	if m.where == nil {
+		return
+	}
+	key := m.SelectedKey()
+	m.where = nil
+	m.statusMsg = ""
+	m.applyWhere()
+	m.selectKeyNow(key)
+}
+
+func (m *BrowserModel) selectKeyNow(key string) {
+	m.selected = 0
+	for i, item := range m.items {
+		if item.Key == key {
+			m.selected = i
+			return
+		}
+	}
+}
+
+// filterSample is the resource type listed on the current screen, used to
+// check filter field names.
+func (m BrowserModel) filterSample() interface{} {
+	switch {
+	case m.category == categoryDomains:
+		return dnsimple.Domain{}
+	case m.category == categoryRecords && m.screen == browserRecordsList:
+		return dnsimple.ZoneRecord{}
+	case m.category == categoryWebhooks:
+		return dnsimple.Webhook{}
+	case m.category == categoryTemplates:
+		return dnsimple.Template{}
+	}
+	return dnsimple.Zone{}
+}
+
+func (m BrowserModel) whereModalView() string {
+	fields := filter.FieldNames(m.filterSample())
+	lines := []string{
+		panelTitleStyle.Render("Filter " + m.category.Label()),
+		"",
+		subtitleStyle.Render("Same syntax as --where: ==, !=, <, >, =~, in (...), &&, ||, !"),
+		subtitleStyle.Render(truncateText("Fields: "+strings.Join(fields, ", "), 76)),
+		"",
+		m.whereBox.input.View(),
+	}
+	if m.whereBox.errMsg != "" {
+		lines = append(lines, "", errorStyle.Render(m.whereBox.errMsg))
+	}
+	lines = append(lines, "", footerStyle.Render("enter: apply (empty clears)   esc: cancel"))
+	box := modalPanelStyle.Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(maxInt(70, m.width), maxInt(20, m.height), lipgloss.Center, lipgloss.Center, box)
+}
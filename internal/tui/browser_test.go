@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeText(m *ShellModel, s string) {
+	for _, r := range s {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestBrowserWhereFilter(t *testing.T) {
+	withDemoBackend(t)
+
+	m := newTestShell(t)
+	drain(t, m, m.activate(tabDomains))
+	total := len(m.domains.items)
+	if total == 0 {
+		t.Fatal("demo backend listed no domains")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	if !m.domains.whereBox.visible || !m.BlocksGlobalKeys() {
+		t.Fatal("F did not open the filter box")
+	}
+
+	// A parse error keeps the box open and shows where it went wrong.
+	typeText(m, `state = "registered"`)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.domains.whereBox.visible || !strings.Contains(m.domains.whereBox.errMsg, "use == to compare") {
+		t.Fatalf("visible=%v err=%q", m.domains.whereBox.visible, m.domains.whereBox.errMsg)
+	}
+
+	m.domains.whereBox.input.SetValue(`name =~ "\.com$"`)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.domains.whereBox.visible || m.domains.where == nil {
+		t.Fatal("valid filter was not applied")
+	}
+	for _, item := range m.domains.items {
+		if !strings.HasSuffix(item.Key, ".com") {
+			t.Errorf("filter kept %q", item.Key)
+		}
+	}
+	if len(m.domains.items) == total {
+		t.Error("filter removed nothing; pick a narrower expression")
+	}
+
+	// An empty expression clears the filter.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	m.domains.whereBox.input.SetValue("")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.domains.where != nil || len(m.domains.items) != total {
+		t.Errorf("filter not cleared: %d of %d items", len(m.domains.items), total)
+	}
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type confirmMutation int
+
+const (
+	mutationNone confirmMutation = iota
+	mutationZoneActivate
+	mutationZoneDeactivate
+	mutationDeleteRecord
+	mutationDeleteDomain
+	mutationDnssecEnable
+	mutationDnssecDisable
+	mutationDeleteWebhook
+	mutationZoneNameServers
+	mutationApplyTemplate
+	mutationApplyRecipe
+	mutationCreateForward
+	mutationDeleteForward
+)
+
+// confirmModal is the dialog every TUI mutation goes through: the user has
+// to type "confirm" before the action runs.
+type confirmModal struct {
+	visible bool
+	busy    bool
+	action  confirmMutation
+	title   string
+	body    string
+	input   textinput.Model
+	errMsg  string
+}
+
+func newConfirmModal() confirmModal {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Placeholder = "type confirm"
+	ti.CharLimit = 64
+	ti.Width = 24
+	return confirmModal{input: ti}
+}
+
+func (c *confirmModal) open(action confirmMutation, title, body string) {
+	c.visible = true
+	c.busy = false
+	c.action = action
+	c.title = title
+	c.body = body
+	c.errMsg = ""
+	c.input.SetValue("")
+	c.input.Focus()
+}
+
+func (c *confirmModal) close() {
+	c.visible = false
+	c.busy = false
+	c.errMsg = ""
+	c.input.Blur()
+}
+
+// update handles a key while the dialog is open. It reports true once the
+// user has typed confirm and pressed Enter; the dialog is then busy until
+// the caller closes it with the outcome.
+func (c *confirmModal) update(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if c.busy {
+		return false, nil
+	}
+	switch {
+	case matches(msg, keys.Back):
+		c.close()
+		return false, nil
+	case matches(msg, keys.Enter):
+		if strings.TrimSpace(c.input.Value()) != "confirm" {
+			c.errMsg = "Type confirm to proceed"
+			return false, nil
+		}
+		c.busy = true
+		c.errMsg = ""
+		return true, nil
+	}
+	var cmd tea.Cmd
+	c.input, cmd = c.input.Update(msg)
+	return false, cmd
+}
+
+func (c confirmModal) view(spin spinner.Model, width, height int) string {
+	lines := []string{
+		panelTitleStyle.Render(c.title),
+		"",
+		c.body,
+		"",
+		warningStyle.Render("Type 'confirm' to proceed."),
+		c.input.View(),
+	}
+	if c.errMsg != "" {
+		lines = append(lines, "", errorStyle.Render(c.errMsg))
+	}
+	if c.busy {
+		lines = append(lines, "", spin.View()+" Working...")
+	}
+	lines = append(lines, "", footerStyle.Render("Enter: confirm   esc: cancel"))
+	box := modalPanelStyle.Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(maxInt(60, width), maxInt(18, height), lipgloss.Center, lipgloss.Center, box)
+}
@@ -2,19 +2,29 @@ package tui
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/dnsimple/dnsimple-go/dnsimple"
 	"github.com/dorkitude/simple/internal/client"
+	"github.com/dorkitude/simple/internal/forwarding"
+	"github.com/dorkitude/simple/internal/nameservers"
+	"github.com/dorkitude/simple/internal/templates"
 )
 
 type Backend interface {
 	IsDemo() bool
 	Whoami(ctx context.Context) (*dnsimple.WhoamiData, error)
+	ListAccounts(ctx context.Context) ([]dnsimple.Account, error)
+	AccountID(ctx context.Context) (string, error)
+	UseAccount(ctx context.Context, accountID string) error
 	ListDomains(ctx context.Context) ([]dnsimple.Domain, error)
 	GetDomain(ctx context.Context, name string) (*dnsimple.Domain, error)
 	DeleteDomain(ctx context.Context, name string) error
@@ -27,7 +37,24 @@ type Backend interface {
 	ListRecords(ctx context.Context, zone string) ([]dnsimple.ZoneRecord, error)
 	GetRecord(ctx context.Context, zone string, recordID int64) (*dnsimple.ZoneRecord, error)
 	CheckRecordDistribution(ctx context.Context, zone string, recordID int64) (bool, error)
+	CreateRecord(ctx context.Context, zone string, record dnsimple.ZoneRecord) (*dnsimple.ZoneRecord, error)
 	DeleteRecord(ctx context.Context, zone string, recordID int64) error
+	UpdateZoneNameServers(ctx context.Context, zone string, names []string) ([]dnsimple.ZoneRecord, error)
+	GetDnssec(ctx context.Context, domain string) (bool, error)
+	EnableDnssec(ctx context.Context, domain string) error
+	DisableDnssec(ctx context.Context, domain string) error
+	ListDSRecords(ctx context.Context, domain string) ([]dnsimple.DelegationSignerRecord, error)
+	ListEmailForwards(ctx context.Context, domain string) ([]dnsimple.EmailForward, error)
+	CreateEmailForward(ctx context.Context, domain, alias, destination string) (*dnsimple.EmailForward, error)
+	DeleteEmailForward(ctx context.Context, domain string, forwardID int64) error
+	CheckDomain(ctx context.Context, name string) (*dnsimple.DomainCheck, error)
+	GetDomainPrices(ctx context.Context, name string) (*dnsimple.DomainPrice, error)
+	ListWebhooks(ctx context.Context) ([]dnsimple.Webhook, error)
+	CreateWebhook(ctx context.Context, url string) (*dnsimple.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID int64) error
+	ListTemplates(ctx context.Context) ([]dnsimple.Template, error)
+	ListTemplateRecords(ctx context.Context, template string) ([]dnsimple.TemplateRecord, error)
+	ApplyTemplate(ctx context.Context, template, domain string) error
 }
 
 var (
@@ -42,6 +69,7 @@ func setBackend(b Backend) {
 }
 
 func useRealBackend() {
+	client.InvalidateCurrent()
 	setBackend(&realBackend{})
 }
 
@@ -55,16 +83,161 @@ func getBackend() Backend {
 	return currentBackend
 }
 
-type realBackend struct{}
+// errNotFound marks demo-backend lookups that miss. isNotFound also
+// recognizes the equivalent 404 responses from the API.
+var errNotFound = errors.New("not found")
+
+func demoNotFound(kind string, key interface{}) error {
+	return fmt.Errorf("%s %w (demo): %v", kind, errNotFound, key)
+}
+
+func isNotFound(err error) bool {
+	if errors.Is(err, errNotFound) {
+		return true
+	}
+	var apiErr *dnsimple.ErrorResponse
+	return errors.As(err, &apiErr) &&
+		apiErr.HTTPResponse != nil &&
+		apiErr.HTTPResponse.StatusCode == http.StatusNotFound
+}
+
+// Both backends return lists in the same order so the TUI never depends on
+// whichever order the API happens to use.
+func sortAccounts(accounts []dnsimple.Account) {
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
+}
+
+func hasAccount(accounts []dnsimple.Account, id string) bool {
+	for _, a := range accounts {
+		if strconv.FormatInt(a.ID, 10) == id {
+			return true
+		}
+	}
+	return false
+}
+
+func sortDomains(domains []dnsimple.Domain) {
+	sort.Slice(domains, func(i, j int) bool { return domains[i].Name < domains[j].Name })
+}
+
+func sortZones(zones []dnsimple.Zone) {
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Name < zones[j].Name })
+}
+
+func sortRecords(records []dnsimple.ZoneRecord) {
+	sort.SliceStable(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+}
+
+func sortDSRecords(records []dnsimple.DelegationSignerRecord) {
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+}
+
+func sortWebhooks(webhooks []dnsimple.Webhook) {
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+}
+
+func sortEmailForwards(forwards []dnsimple.EmailForward) {
+	sort.Slice(forwards, func(i, j int) bool { return forwards[i].ID < forwards[j].ID })
+}
+
+func sortTemplates(list []dnsimple.Template) {
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+}
+
+func sortTemplateRecords(records []dnsimple.TemplateRecord) {
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+}
+
+type realBackend struct {
+	// newApp returns the API client for a call; nil means the shared
+	// session client.
+	newApp func(ctx context.Context) (*client.App, error)
+
+	// account and session are set once UseAccount switches away from the
+	// account the stored credentials resolve to.
+	mu      sync.RWMutex
+	account string
+	session *client.Session
+}
 
 func (b *realBackend) IsDemo() bool { return false }
 
+func (b *realBackend) app(ctx context.Context) (*client.App, error) {
+	b.mu.RLock()
+	account, session := b.account, b.session
+	b.mu.RUnlock()
+
+	switch {
+	case b.newApp != nil:
+		app, err := b.newApp(ctx)
+		if err != nil || account == "" {
+			return app, err
+		}
+		return &client.App{Client: app.Client, AccountID: account}, nil
+	case session != nil:
+		return session.App(ctx)
+	default:
+		return client.Current(ctx)
+	}
+}
+
+func (b *realBackend) ListAccounts(ctx context.Context) ([]dnsimple.Account, error) {
+	c, err := b.apiClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Accounts.ListAccounts(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	sortAccounts(resp.Data)
+	return resp.Data, nil
+}
+
+func (b *realBackend) AccountID(ctx context.Context) (string, error) {
+	app, err := b.app(ctx)
+	if err != nil {
+		return "", err
+	}
+	return app.AccountID, nil
+}
+
+func (b *realBackend) UseAccount(ctx context.Context, accountID string) error {
+	accounts, err := b.ListAccounts(ctx)
+	if err != nil {
+		return err
+	}
+	if !hasAccount(accounts, accountID) {
+		return fmt.Errorf("account %w: %s", errNotFound, accountID)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.account = accountID
+	b.session = client.NewSession(func(ctx context.Context) (*client.App, error) {
+		return client.NewFromFlags(ctx, accountID, false)
+	})
+	return nil
+}
+
+// apiClient returns a client for calls that are not scoped to an account.
+func (b *realBackend) apiClient(ctx context.Context) (*dnsimple.Client, error) {
+	if b.newApp != nil {
+		app, err := b.newApp(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return app.Client, nil
+	}
+	return client.CurrentAPIClient(ctx)
+}
+
 func (b *realBackend) Whoami(ctx context.Context) (*dnsimple.WhoamiData, error) {
-	app, err := client.New(ctx)
+	c, err := b.apiClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := app.Client.Identity.Whoami(ctx)
+	resp, err := c.Identity.Whoami(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("whoami failed: %w", err)
 	}
@@ -72,7 +245,7 @@ func (b *realBackend) Whoami(ctx context.Context) (*dnsimple.WhoamiData, error)
 }
 
 func (b *realBackend) ListDomains(ctx context.Context) ([]dnsimple.Domain, error) {
-	app, err := client.New(ctx)
+	app, err := b.app(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -80,11 +253,12 @@ func (b *realBackend) ListDomains(ctx context.Context) ([]dnsimple.Domain, error
 	if err != nil {
 		return nil, fmt.Errorf("failed to list domains: %w", err)
 	}
+	sortDomains(resp.Data)
 	return resp.Data, nil
 }
 
 func (b *realBackend) GetDomain(ctx context.Context, name string) (*dnsimple.Domain, error) {
-	app, err := client.New(ctx)
+	app, err := b.app(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +270,7 @@ func (b *realBackend) GetDomain(ctx context.Context, name string) (*dnsimple.Dom
 }
 
 func (b *realBackend) DeleteDomain(ctx context.Context, name string) error {
-	app, err := client.New(ctx)
+	app, err := b.app(ctx)
 	if err != nil {
 		return err
 	}
@@ -108,7 +282,7 @@ func (b *realBackend) DeleteDomain(ctx context.Context, name string) error {
 }
 
 func (b *realBackend) ListZones(ctx context.Context) ([]dnsimple.Zone, error) {
-	app, err := client.New(ctx)
+	app, err := b.app(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -116,11 +290,12 @@ func (b *realBackend) ListZones(ctx context.Context) ([]dnsimple.Zone, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to list zones: %w", err)
 	}
+	sortZones(resp.Data)
 	return resp.Data, nil
 }
 
 func (b *realBackend) GetZone(ctx context.Context, name string) (*dnsimple.Zone, error) {
-	app, err := client.New(ctx)
+	app, err := b.app(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +307,7 @@ func (b *realBackend) GetZone(ctx context.Context, name string) (*dnsimple.Zone,
 }
 
 func (b *realBackend) GetZoneFile(ctx context.Context, name string) (string, error) {
-	app, err := client.New(ctx)
+	app, err := b.app(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -144,7 +319,7 @@ func (b *realBackend) GetZoneFile(ctx context.Context, name string) (string, err
 }
 
 func (b *realBackend) CheckZoneDistribution(ctx context.Context, name string) (bool, error) {
-	app, err := client.New(ctx)
+	app, err := b.app(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -156,7 +331,7 @@ func (b *realBackend) CheckZoneDistribution(ctx context.Context, name string) (b
 }
 
 func (b *realBackend) ActivateZoneDNS(ctx context.Context, name string) error {
-	app, err := client.New(ctx)
+	app, err := b.app(ctx)
 	if err != nil {
 		return err
 	}
@@ -168,7 +343,7 @@ func (b *realBackend) ActivateZoneDNS(ctx context.Context, name string) error {
 }
 
 func (b *realBackend) DeactivateZoneDNS(ctx context.Context, name string) error {
-	app, err := client.New(ctx)
+	app, err := b.app(ctx)
 	if err != nil {
 		return err
 	}
@@ -180,7 +355,7 @@ func (b *realBackend) DeactivateZoneDNS(ctx context.Context, name string) error
 }
 
 func (b *realBackend) ListRecords(ctx context.Context, zone string) ([]dnsimple.ZoneRecord, error) {
-	app, err := client.New(ctx)
+	app, err := b.app(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -188,11 +363,12 @@ func (b *realBackend) ListRecords(ctx context.Context, zone string) ([]dnsimple.
 	if err != nil {
 		return nil, fmt.Errorf("failed to list records for %s: %w", zone, err)
 	}
+	sortRecords(resp.Data)
 	return resp.Data, nil
 }
 
 func (b *realBackend) GetRecord(ctx context.Context, zone string, recordID int64) (*dnsimple.ZoneRecord, error) {
-	app, err := client.New(ctx)
+	app, err := b.app(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -204,7 +380,7 @@ func (b *realBackend) GetRecord(ctx context.Context, zone string, recordID int64
 }
 
 func (b *realBackend) CheckRecordDistribution(ctx context.Context, zone string, recordID int64) (bool, error) {
-	app, err := client.New(ctx)
+	app, err := b.app(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -215,8 +391,27 @@ func (b *realBackend) CheckRecordDistribution(ctx context.Context, zone string,
 	return resp.Data.Distributed, nil
 }
 
+func (b *realBackend) CreateRecord(ctx context.Context, zone string, record dnsimple.ZoneRecord) (*dnsimple.ZoneRecord, error) {
+	app, err := b.app(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := record.Name
+	resp, err := app.Client.Zones.CreateRecord(ctx, app.AccountID, zone, dnsimple.ZoneRecordAttributes{
+		Type:     record.Type,
+		Name:     &name,
+		Content:  record.Content,
+		TTL:      record.TTL,
+		Priority: record.Priority,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create record: %w", err)
+	}
+	return resp.Data, nil
+}
+
 func (b *realBackend) DeleteRecord(ctx context.Context, zone string, recordID int64) error {
-	app, err := client.New(ctx)
+	app, err := b.app(ctx)
 	if err != nil {
 		return err
 	}
@@ -227,26 +422,449 @@ func (b *realBackend) DeleteRecord(ctx context.Context, zone string, recordID in
 	return nil
 }
 
+func (b *realBackend) UpdateZoneNameServers(ctx context.Context, zone string, names []string) ([]dnsimple.ZoneRecord, error) {
+	app, err := b.app(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records, err := nameservers.Update(ctx, app.Client, app.AccountID, zone, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update name servers: %w", err)
+	}
+	sortRecords(records)
+	return records, nil
+}
+
+func (b *realBackend) GetDnssec(ctx context.Context, domain string) (bool, error) {
+	app, err := b.app(ctx)
+	if err != nil {
+		return false, err
+	}
+	resp, err := app.Client.Domains.GetDnssec(ctx, app.AccountID, domain)
+	if err != nil {
+		return false, fmt.Errorf("failed to get DNSSEC status: %w", err)
+	}
+	return resp.Data.Enabled, nil
+}
+
+func (b *realBackend) EnableDnssec(ctx context.Context, domain string) error {
+	app, err := b.app(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = app.Client.Domains.EnableDnssec(ctx, app.AccountID, domain)
+	if err != nil {
+		return fmt.Errorf("failed to enable DNSSEC: %w", err)
+	}
+	return nil
+}
+
+func (b *realBackend) DisableDnssec(ctx context.Context, domain string) error {
+	app, err := b.app(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = app.Client.Domains.DisableDnssec(ctx, app.AccountID, domain)
+	if err != nil {
+		return fmt.Errorf("failed to disable DNSSEC: %w", err)
+	}
+	return nil
+}
+
+func (b *realBackend) ListDSRecords(ctx context.Context, domain string) ([]dnsimple.DelegationSignerRecord, error) {
+	app, err := b.app(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := app.Client.Domains.ListDelegationSignerRecords(ctx, app.AccountID, domain, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DS records: %w", err)
+	}
+	sortDSRecords(resp.Data)
+	return resp.Data, nil
+}
+
+func (b *realBackend) ListEmailForwards(ctx context.Context, domain string) ([]dnsimple.EmailForward, error) {
+	app, err := b.app(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := app.Client.Domains.ListEmailForwards(ctx, app.AccountID, domain, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email forwards: %w", err)
+	}
+	sortEmailForwards(resp.Data)
+	return resp.Data, nil
+}
+
+func (b *realBackend) CreateEmailForward(ctx context.Context, domain, alias, destination string) (*dnsimple.EmailForward, error) {
+	app, err := b.app(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := app.Client.Domains.CreateEmailForward(ctx, app.AccountID, domain, dnsimple.EmailForward{AliasName: alias, DestinationEmail: destination})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email forward: %w", err)
+	}
+	return resp.Data, nil
+}
+
+func (b *realBackend) DeleteEmailForward(ctx context.Context, domain string, forwardID int64) error {
+	app, err := b.app(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = app.Client.Domains.DeleteEmailForward(ctx, app.AccountID, domain, forwardID)
+	if err != nil {
+		return fmt.Errorf("failed to delete email forward: %w", err)
+	}
+	return nil
+}
+
+func (b *realBackend) CheckDomain(ctx context.Context, name string) (*dnsimple.DomainCheck, error) {
+	app, err := b.app(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := app.Client.Registrar.CheckDomain(ctx, app.AccountID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check domain: %w", err)
+	}
+	return resp.Data, nil
+}
+
+func (b *realBackend) GetDomainPrices(ctx context.Context, name string) (*dnsimple.DomainPrice, error) {
+	app, err := b.app(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := app.Client.Registrar.GetDomainPrices(ctx, app.AccountID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domain prices: %w", err)
+	}
+	return resp.Data, nil
+}
+
+func (b *realBackend) ListWebhooks(ctx context.Context) ([]dnsimple.Webhook, error) {
+	app, err := b.app(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := app.Client.Webhooks.ListWebhooks(ctx, app.AccountID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	sortWebhooks(resp.Data)
+	return resp.Data, nil
+}
+
+func (b *realBackend) CreateWebhook(ctx context.Context, url string) (*dnsimple.Webhook, error) {
+	app, err := b.app(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := app.Client.Webhooks.CreateWebhook(ctx, app.AccountID, dnsimple.Webhook{URL: url})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return resp.Data, nil
+}
+
+func (b *realBackend) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	app, err := b.app(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = app.Client.Webhooks.DeleteWebhook(ctx, app.AccountID, webhookID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+func (b *realBackend) ListTemplates(ctx context.Context) ([]dnsimple.Template, error) {
+	app, err := b.app(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := app.Client.Templates.ListTemplates(ctx, app.AccountID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	sortTemplates(resp.Data)
+	return resp.Data, nil
+}
+
+func (b *realBackend) ListTemplateRecords(ctx context.Context, template string) ([]dnsimple.TemplateRecord, error) {
+	app, err := b.app(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := app.Client.Templates.ListTemplateRecords(ctx, app.AccountID, template, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list template records: %w", err)
+	}
+	sortTemplateRecords(resp.Data)
+	return resp.Data, nil
+}
+
+func (b *realBackend) ApplyTemplate(ctx context.Context, template, domain string) error {
+	app, err := b.app(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = app.Client.Templates.ApplyTemplate(ctx, app.AccountID, template, domain)
+	if err != nil {
+		return fmt.Errorf("failed to apply template: %w", err)
+	}
+	return nil
+}
+
+// demoAccount holds one demo account's resources.
+type demoAccount struct {
+	domains  map[string]dnsimple.Domain
+	zones    map[string]dnsimple.Zone
+	records  map[string][]dnsimple.ZoneRecord
+	dnssec   map[string]bool
+	ds       map[string][]dnsimple.DelegationSignerRecord
+	webhooks map[int64]dnsimple.Webhook
+	forwards map[string][]dnsimple.EmailForward
+
+	// templates and templateRecords are keyed by template ID.
+	templates       map[int64]dnsimple.Template
+	templateRecords map[int64][]dnsimple.TemplateRecord
+}
+
+func newDemoAccount() *demoAccount {
+	return &demoAccount{
+		domains:  map[string]dnsimple.Domain{},
+		zones:    map[string]dnsimple.Zone{},
+		records:  map[string][]dnsimple.ZoneRecord{},
+		dnssec:   map[string]bool{},
+		ds:       map[string][]dnsimple.DelegationSignerRecord{},
+		webhooks: map[int64]dnsimple.Webhook{},
+		forwards: map[string][]dnsimple.EmailForward{},
+
+		templates:       map[int64]dnsimple.Template{},
+		templateRecords: map[int64][]dnsimple.TemplateRecord{},
+	}
+}
+
+// createForward adds an email forward from alias@domain, as the API does.
+func (a *demoAccount) createForward(domain, alias, destination string, nextForwardID *int64) (dnsimple.EmailForward, error) {
+	d, ok := a.domains[domain]
+	if !ok {
+		return dnsimple.EmailForward{}, demoNotFound("domain", domain)
+	}
+	if alias == "" || !strings.Contains(destination, "@") {
+		return dnsimple.EmailForward{}, fmt.Errorf("invalid email forward %q to %q", alias, destination)
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	*nextForwardID++
+	f := dnsimple.EmailForward{
+		ID:               *nextForwardID,
+		DomainID:         d.ID,
+		AliasEmail:       alias + "@" + domain,
+		DestinationEmail: destination,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	a.forwards[domain] = append(a.forwards[domain], f)
+	return f, nil
+}
+
+// deleteForward removes an email forward of domain.
+func (a *demoAccount) deleteForward(domain string, forwardID int64) error {
+	if _, ok := a.domains[domain]; !ok {
+		return demoNotFound("domain", domain)
+	}
+	list := a.forwards[domain]
+	for i, f := range list {
+		if f.ID == forwardID {
+			a.forwards[domain] = append(list[:i:i], list[i+1:]...)
+			return nil
+		}
+	}
+	return demoNotFound("email forward", forwardID)
+}
+
+// demoPrices are the demo registrar's registration, renewal and transfer
+// prices per TLD. Other TLDs are not supported.
+var demoPrices = map[string][3]float64{
+	"com":   {14, 14, 14},
+	"net":   {16, 16, 16},
+	"org":   {15, 15, 15},
+	"io":    {49, 49, 49},
+	"dev":   {15, 15, 15},
+	"app":   {17, 17, 17},
+	"ai":    {90, 90, 90},
+	"co":    {28, 28, 28},
+	"tools": {32, 32, 32},
+}
+
+// demoPremium reports whether the demo registrar prices a name as premium:
+// labels of three characters or fewer are.
+func demoPremium(name string) bool {
+	label, _, _ := strings.Cut(name, ".")
+	return len(label) <= 3
+}
+
+// demoTakenElsewhere reports whether a name outside the account is
+// registered to someone else. About one name in four is.
+func demoTakenElsewhere(name string) bool {
+	return sha256.Sum256([]byte(name))[0]%4 == 0
+}
+
+func demoTLDPrices(name string) ([3]float64, error) {
+	_, tld, _ := strings.Cut(name, ".")
+	if i := strings.LastIndex(tld, "."); i >= 0 {
+		tld = tld[i+1:]
+	}
+	p, ok := demoPrices[tld]
+	if !ok {
+		return p, fmt.Errorf("TLD .%s is not supported", tld)
+	}
+	return p, nil
+}
+
+// checkDomain answers as the registrar does: the account's own domains and
+// some others are taken, and short labels are premium.
+func (a *demoAccount) checkDomain(name string) (dnsimple.DomainCheck, error) {
+	if _, err := demoTLDPrices(name); err != nil {
+		return dnsimple.DomainCheck{}, err
+	}
+	_, owned := a.domains[name]
+	return dnsimple.DomainCheck{
+		Domain:    name,
+		Available: !owned && !demoTakenElsewhere(name),
+		Premium:   demoPremium(name),
+	}, nil
+}
+
+// demoDomainPrice prices a name by its TLD; premium names cost forty times
+// as much.
+func demoDomainPrice(name string) (dnsimple.DomainPrice, error) {
+	p, err := demoTLDPrices(name)
+	if err != nil {
+		return dnsimple.DomainPrice{}, err
+	}
+	premium := demoPremium(name)
+	if premium {
+		for i := range p {
+			p[i] *= 40
+		}
+	}
+	return dnsimple.DomainPrice{
+		Domain:            name,
+		Premium:           premium,
+		RegistrationPrice: p[0],
+		RenewalPrice:      p[1],
+		TransferPrice:     p[2],
+	}, nil
+}
+
+// findTemplate looks a template up by ID or sid, as the API does.
+func (a *demoAccount) findTemplate(ident string) (dnsimple.Template, bool) {
+	for _, t := range a.templates {
+		if t.SID == ident || strconv.FormatInt(t.ID, 10) == ident {
+			return t, true
+		}
+	}
+	return dnsimple.Template{}, false
+}
+
+// applyTemplate adds the template's records that the zone lacks. Like the
+// API, it refuses records that clash with the zone.
+func (a *demoAccount) applyTemplate(template, domain string, nextRecordID *int64) error {
+	t, ok := a.findTemplate(template)
+	if !ok {
+		return demoNotFound("template", template)
+	}
+	recs, ok := a.records[domain]
+	if !ok {
+		return demoNotFound("domain", domain)
+	}
+	plan := templates.Preview(t.SID, domain, a.templateRecords[t.ID], recs)
+	if len(plan.Conflicts) > 0 {
+		c := plan.Conflicts[0]
+		return fmt.Errorf("cannot apply template %s: %s", t.SID, c.Reason)
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, r := range plan.Add {
+		*nextRecordID++
+		r.ID = *nextRecordID
+		r.CreatedAt, r.UpdatedAt = now, now
+		if r.TTL == 0 {
+			r.TTL = 3600
+		}
+		recs = append(recs, r)
+	}
+	a.records[domain] = recs
+	return nil
+}
+
 type demoBackend struct {
-	mu      sync.RWMutex
-	whoami  *dnsimple.WhoamiData
-	domains map[string]dnsimple.Domain
-	zones   map[string]dnsimple.Zone
-	records map[string][]dnsimple.ZoneRecord
+	mu       sync.RWMutex
+	whoami   *dnsimple.WhoamiData
+	accounts []dnsimple.Account
+	data     map[int64]*demoAccount
+	current  int64
+
+	// account aliases the current account's data, as do domains, zones,
+	// records and webhooks.
+	account  *demoAccount
+	domains  map[string]dnsimple.Domain
+	zones    map[string]dnsimple.Zone
+	records  map[string][]dnsimple.ZoneRecord
+	webhooks map[int64]dnsimple.Webhook
+
+	// nextWebhookID, nextRecordID and nextForwardID number created
+	// webhooks, records and email forwards across all accounts.
+	nextWebhookID int64
+	nextRecordID  int64
+	nextForwardID int64
 }
 
 func newDemoBackend() *demoBackend {
-	b := &demoBackend{
-		domains: map[string]dnsimple.Domain{},
-		zones:   map[string]dnsimple.Zone{},
-		records: map[string][]dnsimple.ZoneRecord{},
-	}
+	b := &demoBackend{data: map[int64]*demoAccount{}}
 	b.seed()
 	return b
 }
 
 func (b *demoBackend) IsDemo() bool { return true }
 
+func (b *demoBackend) ListAccounts(ctx context.Context) ([]dnsimple.Account, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := append([]dnsimple.Account(nil), b.accounts...)
+	sortAccounts(out)
+	return out, nil
+}
+
+func (b *demoBackend) AccountID(ctx context.Context) (string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return strconv.FormatInt(b.current, 10), nil
+}
+
+func (b *demoBackend) UseAccount(ctx context.Context, accountID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id, err := strconv.ParseInt(accountID, 10, 64)
+	if err != nil || b.data[id] == nil {
+		return demoNotFound("account", accountID)
+	}
+	b.selectAccount(id)
+	return nil
+}
+
+func (b *demoBackend) selectAccount(id int64) {
+	acct := b.data[id]
+	b.current = id
+	b.account = acct
+	b.domains, b.zones, b.records, b.webhooks = acct.domains, acct.zones, acct.records, acct.webhooks
+}
+
 func (b *demoBackend) Whoami(ctx context.Context) (*dnsimple.WhoamiData, error) {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
@@ -269,7 +887,7 @@ func (b *demoBackend) ListDomains(ctx context.Context) ([]dnsimple.Domain, error
 	for _, d := range b.domains {
 		out = append(out, d)
 	}
-	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	sortDomains(out)
 	return out, nil
 }
 
@@ -278,7 +896,7 @@ func (b *demoBackend) GetDomain(ctx context.Context, name string) (*dnsimple.Dom
 	defer b.mu.RUnlock()
 	d, ok := b.domains[name]
 	if !ok {
-		return nil, fmt.Errorf("domain not found (demo): %s", name)
+		return nil, demoNotFound("domain", name)
 	}
 	cp := d
 	return &cp, nil
@@ -288,11 +906,14 @@ func (b *demoBackend) DeleteDomain(ctx context.Context, name string) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	if _, ok := b.domains[name]; !ok {
-		return fmt.Errorf("domain not found (demo): %s", name)
+		return demoNotFound("domain", name)
 	}
 	delete(b.domains, name)
 	delete(b.zones, name)
 	delete(b.records, name)
+	delete(b.account.dnssec, name)
+	delete(b.account.ds, name)
+	delete(b.account.forwards, name)
 	return nil
 }
 
@@ -303,7 +924,7 @@ func (b *demoBackend) ListZones(ctx context.Context) ([]dnsimple.Zone, error) {
 	for _, z := range b.zones {
 		out = append(out, z)
 	}
-	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	sortZones(out)
 	return out, nil
 }
 
@@ -312,7 +933,7 @@ func (b *demoBackend) GetZone(ctx context.Context, name string) (*dnsimple.Zone,
 	defer b.mu.RUnlock()
 	z, ok := b.zones[name]
 	if !ok {
-		return nil, fmt.Errorf("zone not found (demo): %s", name)
+		return nil, demoNotFound("zone", name)
 	}
 	cp := z
 	return &cp, nil
@@ -322,7 +943,7 @@ func (b *demoBackend) GetZoneFile(ctx context.Context, name string) (string, err
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 	if _, ok := b.zones[name]; !ok {
-		return "", fmt.Errorf("zone not found (demo): %s", name)
+		return "", demoNotFound("zone", name)
 	}
 	recs := b.records[name]
 	var lines []string
@@ -348,7 +969,7 @@ func (b *demoBackend) CheckZoneDistribution(ctx context.Context, name string) (b
 	if z, ok := b.zones[name]; ok {
 		return z.Active, nil
 	}
-	return false, fmt.Errorf("zone not found (demo): %s", name)
+	return false, demoNotFound("zone", name)
 }
 
 func (b *demoBackend) ActivateZoneDNS(ctx context.Context, name string) error {
@@ -356,7 +977,7 @@ func (b *demoBackend) ActivateZoneDNS(ctx context.Context, name string) error {
 	defer b.mu.Unlock()
 	z, ok := b.zones[name]
 	if !ok {
-		return fmt.Errorf("zone not found (demo): %s", name)
+		return demoNotFound("zone", name)
 	}
 	z.Active = true
 	b.zones[name] = z
@@ -368,7 +989,7 @@ func (b *demoBackend) DeactivateZoneDNS(ctx context.Context, name string) error
 	defer b.mu.Unlock()
 	z, ok := b.zones[name]
 	if !ok {
-		return fmt.Errorf("zone not found (demo): %s", name)
+		return demoNotFound("zone", name)
 	}
 	z.Active = false
 	b.zones[name] = z
@@ -380,10 +1001,10 @@ func (b *demoBackend) ListRecords(ctx context.Context, zone string) ([]dnsimple.
 	defer b.mu.RUnlock()
 	recs, ok := b.records[zone]
 	if !ok {
-		return nil, fmt.Errorf("zone not found (demo): %s", zone)
+		return nil, demoNotFound("zone", zone)
 	}
 	out := append([]dnsimple.ZoneRecord(nil), recs...)
-	sort.SliceStable(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	sortRecords(out)
 	return out, nil
 }
 
@@ -396,7 +1017,7 @@ func (b *demoBackend) GetRecord(ctx context.Context, zone string, recordID int64
 			return &cp, nil
 		}
 	}
-	return nil, fmt.Errorf("record not found (demo): %d", recordID)
+	return nil, demoNotFound("record", recordID)
 }
 
 func (b *demoBackend) CheckRecordDistribution(ctx context.Context, zone string, recordID int64) (bool, error) {
@@ -404,14 +1025,32 @@ func (b *demoBackend) CheckRecordDistribution(ctx context.Context, zone string,
 	defer b.mu.RUnlock()
 	recs, ok := b.records[zone]
 	if !ok {
-		return false, fmt.Errorf("zone not found (demo): %s", zone)
+		return false, demoNotFound("zone", zone)
 	}
 	for _, r := range recs {
 		if r.ID == recordID {
 			return r.ID%2 == 0, nil
 		}
 	}
-	return false, fmt.Errorf("record not found (demo): %d", recordID)
+	return false, demoNotFound("record", recordID)
+}
+
+func (b *demoBackend) CreateRecord(ctx context.Context, zone string, record dnsimple.ZoneRecord) (*dnsimple.ZoneRecord, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	recs, ok := b.records[zone]
+	if !ok {
+		return nil, demoNotFound("zone", zone)
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	b.nextRecordID++
+	record.ID = b.nextRecordID
+	record.CreatedAt, record.UpdatedAt = now, now
+	if record.TTL == 0 {
+		record.TTL = 3600
+	}
+	b.records[zone] = append(recs, record)
+	return &record, nil
 }
 
 func (b *demoBackend) DeleteRecord(ctx context.Context, zone string, recordID int64) error {
@@ -419,7 +1058,7 @@ func (b *demoBackend) DeleteRecord(ctx context.Context, zone string, recordID in
 	defer b.mu.Unlock()
 	recs, ok := b.records[zone]
 	if !ok {
-		return fmt.Errorf("zone not found (demo): %s", zone)
+		return demoNotFound("zone", zone)
 	}
 	for i, r := range recs {
 		if r.ID == recordID {
@@ -427,30 +1066,304 @@ func (b *demoBackend) DeleteRecord(ctx context.Context, zone string, recordID in
 			return nil
 		}
 	}
-	return fmt.Errorf("record not found (demo): %d", recordID)
+	return demoNotFound("record", recordID)
+}
+
+// UpdateZoneNameServers replaces the apex NS records with new ones, as the
+// API does.
+func (b *demoBackend) UpdateZoneNameServers(ctx context.Context, zone string, names []string) ([]dnsimple.ZoneRecord, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	recs, ok := b.records[zone]
+	if !ok {
+		return nil, demoNotFound("zone", zone)
+	}
+	kept := make([]dnsimple.ZoneRecord, 0, len(recs)+len(names))
+	for _, r := range recs {
+		if r.Type != "NS" || r.Name != "" {
+			kept = append(kept, r)
+		}
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	created := make([]dnsimple.ZoneRecord, 0, len(names))
+	for _, n := range names {
+		b.nextRecordID++
+		created = append(created, dnsimple.ZoneRecord{
+			ID:        b.nextRecordID,
+			Type:      "NS",
+			Content:   n,
+			TTL:       3600,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+	b.records[zone] = append(kept, created...)
+	return created, nil
+}
+
+func (b *demoBackend) GetDnssec(ctx context.Context, domain string) (bool, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if _, ok := b.domains[domain]; !ok {
+		return false, demoNotFound("domain", domain)
+	}
+	return b.account.dnssec[domain], nil
 }
 
+func (b *demoBackend) EnableDnssec(ctx context.Context, domain string) error {
+	return b.setDnssec(domain, true)
+}
+
+func (b *demoBackend) DisableDnssec(ctx context.Context, domain string) error {
+	return b.setDnssec(domain, false)
+}
+
+func (b *demoBackend) setDnssec(domain string, enabled bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.domains[domain]; !ok {
+		return demoNotFound("domain", domain)
+	}
+	b.account.dnssec[domain] = enabled
+	return nil
+}
+
+func (b *demoBackend) ListDSRecords(ctx context.Context, domain string) ([]dnsimple.DelegationSignerRecord, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if _, ok := b.domains[domain]; !ok {
+		return nil, demoNotFound("domain", domain)
+	}
+	out := append([]dnsimple.DelegationSignerRecord(nil), b.account.ds[domain]...)
+	sortDSRecords(out)
+	return out, nil
+}
+
+func (b *demoBackend) ListEmailForwards(ctx context.Context, domain string) ([]dnsimple.EmailForward, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if _, ok := b.domains[domain]; !ok {
+		return nil, demoNotFound("domain", domain)
+	}
+	out := append([]dnsimple.EmailForward(nil), b.account.forwards[domain]...)
+	sortEmailForwards(out)
+	return out, nil
+}
+
+func (b *demoBackend) CreateEmailForward(ctx context.Context, domain, alias, destination string) (*dnsimple.EmailForward, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	f, err := b.account.createForward(domain, alias, destination, &b.nextForwardID)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (b *demoBackend) DeleteEmailForward(ctx context.Context, domain string, forwardID int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.account.deleteForward(domain, forwardID)
+}
+
+func (b *demoBackend) CheckDomain(ctx context.Context, name string) (*dnsimple.DomainCheck, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	c, err := b.account.checkDomain(name)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (b *demoBackend) GetDomainPrices(ctx context.Context, name string) (*dnsimple.DomainPrice, error) {
+	p, err := demoDomainPrice(name)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (b *demoBackend) ListWebhooks(ctx context.Context) ([]dnsimple.Webhook, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := make([]dnsimple.Webhook, 0, len(b.webhooks))
+	for _, w := range b.webhooks {
+		out = append(out, w)
+	}
+	sortWebhooks(out)
+	return out, nil
+}
+
+func (b *demoBackend) CreateWebhook(ctx context.Context, url string) (*dnsimple.Webhook, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextWebhookID++
+	w := dnsimple.Webhook{ID: b.nextWebhookID, URL: url}
+	b.webhooks[w.ID] = w
+	return &w, nil
+}
+
+func (b *demoBackend) DeleteWebhook(ctx context.Context, webhookID int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.webhooks[webhookID]; !ok {
+		return demoNotFound("webhook", webhookID)
+	}
+	delete(b.webhooks, webhookID)
+	return nil
+}
+
+func (b *demoBackend) ListTemplates(ctx context.Context) ([]dnsimple.Template, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := make([]dnsimple.Template, 0, len(b.account.templates))
+	for _, t := range b.account.templates {
+		out = append(out, t)
+	}
+	sortTemplates(out)
+	return out, nil
+}
+
+func (b *demoBackend) ListTemplateRecords(ctx context.Context, template string) ([]dnsimple.TemplateRecord, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	t, ok := b.account.findTemplate(template)
+	if !ok {
+		return nil, demoNotFound("template", template)
+	}
+	out := append([]dnsimple.TemplateRecord(nil), b.account.templateRecords[t.ID]...)
+	sortTemplateRecords(out)
+	return out, nil
+}
+
+func (b *demoBackend) ApplyTemplate(ctx context.Context, template, domain string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.account.applyTemplate(template, domain, &b.nextRecordID)
+}
+
+// seed sets up a user token with three accounts. The first, which starts
+// selected, holds the fixtures the backend contract suite relies on.
 func (b *demoBackend) seed() {
-	now := time.Date(2026, 2, 26, 12, 0, 0, 0, time.UTC).Format(time.RFC3339)
 	b.whoami = &dnsimple.WhoamiData{
-		Account: &dnsimple.Account{
-			ID:             424242,
-			Email:          "demo@dnsimplectl.local",
-			PlanIdentifier: "professional",
+		User: &dnsimple.User{
+			ID:    1120,
+			Email: "demo@dnsimplectl.local",
+		},
+	}
+	b.accounts = []dnsimple.Account{
+		{ID: 424242, Email: "demo@dnsimplectl.local", PlanIdentifier: "professional"},
+		{ID: 515151, Email: "ops@northwind.example", PlanIdentifier: "teams"},
+		{ID: 626262, Email: "side-projects@dnsimplectl.local", PlanIdentifier: "solo"},
+	}
+
+	seeds := map[int64][]string{
+		424242: {
+			"absurdophile.com", "acme.dev", "alpha-example.net", "beta-labs.io", "bluebird.ai",
+			"canvasworks.co", "deltaops.com", "echovalley.org", "foxtrotapps.dev", "glaciermail.com",
+			"harborstack.io", "ivorypixel.net", "jupiterhub.app", "kineticdata.dev", "lighthouse.tools",
+			"mintorchard.com", "northfieldhq.com", "opalroute.io", "paperplane.dev", "quietforest.org",
+			"rangergrid.com", "signalpath.io", "tideline.app", "umbraworks.dev", "vectorlane.net",
+		},
+		515151: {
+			"northwind.example", "northwind-status.io", "nw-internal.net", "shipping-northwind.com",
+			"tradewinds.app", "windmill.tools",
+		},
+		626262: {
+			"hobbyhorse.dev", "pocketgarden.org", "weekendbuild.io",
 		},
 	}
 
-	demoNames := []string{
-		"absurdophile.com", "acme.dev", "alpha-example.net", "beta-labs.io", "bluebird.ai",
-		"canvasworks.co", "deltaops.com", "echovalley.org", "foxtrotapps.dev", "glaciermail.com",
-		"harborstack.io", "ivorypixel.net", "jupiterhub.app", "kineticdata.dev", "lighthouse.tools",
-		"mintorchard.com", "northfieldhq.com", "opalroute.io", "paperplane.dev", "quietforest.org",
-		"rangergrid.com", "signalpath.io", "tideline.app", "umbraworks.dev", "vectorlane.net",
+	hooks := map[int64][]string{
+		424242: {"https://hooks.dnsimplectl.local/dns-events", "https://ci.dnsimplectl.local/webhooks/dnsimple"},
+		515151: {"https://ops.northwind.example/hooks/dnsimple"},
 	}
 
-	var nextDomainID int64 = 1028000
-	var nextZoneID int64 = 972300
-	var nextRecordID int64 = 8800000
+	b.nextWebhookID = 3100
+	b.nextRecordID = 9900000
+	b.nextForwardID = 52000
+	for i, acct := range b.accounts {
+		data := newDemoAccount()
+		seedDemoDomains(data, seeds[acct.ID], int64(i)*1000)
+		seedDemoForwards(data, seeds[acct.ID], acct.Email, &b.nextForwardID)
+		for _, url := range hooks[acct.ID] {
+			b.nextWebhookID++
+			data.webhooks[b.nextWebhookID] = dnsimple.Webhook{ID: b.nextWebhookID, URL: url}
+		}
+		if i == 0 {
+			seedDemoTemplates(data)
+		}
+		b.data[acct.ID] = data
+	}
+	b.selectAccount(b.accounts[0].ID)
+}
+
+// seedDemoTemplates adds record templates. On the seeded zones brand-web
+// finds its www CNAME already present and acme-cname clashes with the
+// _acme-challenge TXT record, so previews show every outcome.
+func seedDemoTemplates(data *demoAccount) {
+	now := time.Date(2026, 2, 26, 12, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	seeds := []struct {
+		template dnsimple.Template
+		records  []dnsimple.TemplateRecord
+	}{
+		{
+			dnsimple.Template{ID: 268, SID: "google-workspace", Name: "Google Workspace", Description: "Gmail MX and SPF"},
+			[]dnsimple.TemplateRecord{
+				{ID: 3301, Type: "MX", Content: "smtp.google.com", TTL: 3600, Priority: 1},
+				{ID: 3302, Type: "TXT", Content: "v=spf1 include:_spf.google.com ~all", TTL: 3600},
+			},
+		},
+		{
+			dnsimple.Template{ID: 269, SID: "brand-web", Name: "Brand web", Description: "Apex A, www and DMARC"},
+			[]dnsimple.TemplateRecord{
+				{ID: 3311, Type: "A", Content: "203.0.113.80", TTL: 3600},
+				{ID: 3312, Type: "CNAME", Name: "www", Content: templates.DomainPlaceholder, TTL: 3600},
+				{ID: 3313, Type: "TXT", Name: "_dmarc", Content: "v=DMARC1; p=quarantine; rua=mailto:dmarc@" + templates.DomainPlaceholder, TTL: 3600},
+			},
+		},
+		{
+			dnsimple.Template{ID: 270, SID: "acme-cname", Name: "ACME delegation", Description: "Delegate ACME challenges to a validation zone"},
+			[]dnsimple.TemplateRecord{
+				{ID: 3321, Type: "CNAME", Name: "_acme-challenge", Content: "_acme-challenge.validation.example.net", TTL: 600},
+			},
+		},
+	}
+	for _, s := range seeds {
+		t := s.template
+		t.CreatedAt, t.UpdatedAt = now, now
+		data.templates[t.ID] = t
+		for _, r := range s.records {
+			r.TemplateID = t.ID
+			r.CreatedAt, r.UpdatedAt = now, now
+			data.templateRecords[t.ID] = append(data.templateRecords[t.ID], r)
+		}
+	}
+}
+
+// seedDemoForwards gives every domain a hello@ forward to the account's
+// email. Every third domain is parked and also has a catch-all.
+func seedDemoForwards(data *demoAccount, names []string, to string, nextForwardID *int64) {
+	for i, name := range names {
+		_, _ = data.createForward(name, "hello", to, nextForwardID)
+		if i%3 == 2 {
+			_, _ = data.createForward(name, forwarding.CatchAll, to, nextForwardID)
+		}
+	}
+}
+
+// demoNameServers is the NS set every demo zone starts with.
+var demoNameServers = []string{"ns1.dnsimple.com", "ns2.dnsimple-edge.net", "ns3.dnsimple.com", "ns4.dnsimple-edge.org"}
+
+// seedDemoDomains fills data with a domain, zone and records for each name.
+// offset keeps IDs unique across accounts.
+func seedDemoDomains(data *demoAccount, demoNames []string, offset int64) {
+	now := time.Date(2026, 2, 26, 12, 0, 0, 0, time.UTC).Format(time.RFC3339)
+
+	nextDomainID := 1028000 + offset
+	nextZoneID := 972300 + offset
+	nextRecordID := 8800000 + offset*10
 	for i, name := range demoNames {
 		d := dnsimple.Domain{
 			ID:           nextDomainID + int64(i),
@@ -463,7 +1376,7 @@ func (b *demoBackend) seed() {
 			CreatedAt:    now,
 			UpdatedAt:    now,
 		}
-		b.domains[name] = d
+		data.domains[name] = d
 
 		z := dnsimple.Zone{
 			ID:        nextZoneID + int64(i),
@@ -474,7 +1387,7 @@ func (b *demoBackend) seed() {
 			CreatedAt: now,
 			UpdatedAt: now,
 		}
-		b.zones[name] = z
+		data.zones[name] = z
 
 		txt := "v=spf1 include:_spf.google.com include:mailgun.org include:amazonses.com ip4:192.0.2.42 ip4:198.51.100.17 ~all"
 		if i%5 == 0 {
@@ -534,6 +1447,33 @@ func (b *demoBackend) seed() {
 				UpdatedAt:    now,
 			},
 		}
-		b.records[name] = recs
+		for j, ns := range demoNameServers {
+			recs = append(recs, dnsimple.ZoneRecord{
+				ID:           nextRecordID + int64(i*10) + 6 + int64(j),
+				Type:         "NS",
+				Name:         "",
+				Content:      ns,
+				TTL:          3600,
+				SystemRecord: true,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			})
+		}
+		data.records[name] = recs
+
+		// Every fourth domain is signed, with a DS record at the registry.
+		if i%4 == 1 {
+			data.dnssec[name] = true
+			data.ds[name] = []dnsimple.DelegationSignerRecord{{
+				ID:         nextDomainID + int64(i),
+				DomainID:   d.ID,
+				Algorithm:  "13",
+				DigestType: "2",
+				Digest:     fmt.Sprintf("%X", sha256.Sum256([]byte(name))),
+				Keytag:     strconv.Itoa(2371 + i*97),
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			}}
+		}
 	}
 }
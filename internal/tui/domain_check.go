@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dorkitude/simple/internal/registrar"
+)
+
+type domainCheckMsg struct {
+	gen     int
+	results []registrar.Result
+	err     error
+}
+
+// domainCheck is the modal that checks whether names can be registered.
+// A bare label is tried across registrar.DefaultTLDs.
+type domainCheck struct {
+	visible bool
+	loading bool
+	input   textinput.Model
+	results []registrar.Result
+	errMsg  string
+	spinner spinner.Model
+	req     requestScope
+}
+
+func newDomainCheck() domainCheck {
+	spin := spinner.New()
+	spin.Spinner = spinner.Line
+	spin.Style = subtitleStyle
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Placeholder = "acme, or acme.io rocketco.dev"
+	ti.CharLimit = 255
+	ti.Width = 48
+	return domainCheck{input: ti, spinner: spin}
+}
+
+func (c *domainCheck) open() tea.Cmd {
+	c.visible = true
+	c.errMsg = ""
+	c.input.Focus()
+	return textinput.Blink
+}
+
+func (c *domainCheck) close() {
+	c.visible = false
+	c.loading = false
+	c.input.Blur()
+	c.req.stop()
+}
+
+func (c *domainCheck) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			c.close()
+			return nil
+		case "enter":
+			names, err := registrar.Candidates(strings.FieldsFunc(c.input.Value(), func(r rune) bool {
+				return r == ',' || r == ' '
+			}), nil)
+			if err != nil {
+				c.errMsg = err.Error()
+				return nil
+			}
+			c.loading = true
+			c.errMsg = ""
+			return tea.Batch(c.spinner.Tick, c.checkCmd(names))
+		}
+		var cmd tea.Cmd
+		c.input, cmd = c.input.Update(msg)
+		return cmd
+	case domainCheckMsg:
+		if !c.req.current(msg.gen) {
+			return nil
+		}
+		c.loading = false
+		if msg.err != nil {
+			c.errMsg = msg.err.Error()
+			return nil
+		}
+		c.results = msg.results
+	case spinner.TickMsg:
+		if c.loading {
+			var cmd tea.Cmd
+			c.spinner, cmd = c.spinner.Update(msg)
+			return cmd
+		}
+	}
+	return nil
+}
+
+func (c *domainCheck) checkCmd(names []string) tea.Cmd {
+	ctx, gen := c.req.begin()
+	return func() tea.Msg {
+		results, err := registrar.Check(ctx, getBackend(), names)
+		return domainCheckMsg{gen: gen, results: results, err: err}
+	}
+}
+
+// domainCheckLine shows one result: availability, then the registration,
+// renewal and transfer prices.
+func domainCheckLine(r registrar.Result) string {
+	name := fmt.Sprintf("%-28s", truncateText(r.Domain, 28))
+	if r.Error != "" {
+		return errorStyle.Render("✗ "+name) + subtitleStyle.Render(" "+truncateText(r.Error, 40))
+	}
+	status := successStyle.Render("● " + name + " available")
+	if !r.Available {
+		status = subtitleStyle.Render("○ " + name + " taken    ")
+	}
+	prices := fmt.Sprintf("  %8.2f %8.2f %8.2f", r.RegistrationPrice, r.RenewalPrice, r.TransferPrice)
+	if r.Premium {
+		prices += "  premium"
+	}
+	return status + prices
+}
+
+func (c domainCheck) View(width, height int) string {
+	lines := []string{
+		panelTitleStyle.Render("Check Availability"),
+		"",
+		subtitleStyle.Render("A name, or a bare label to try across " + strings.Join(registrar.DefaultTLDs, ", ") + "."),
+		c.input.View(),
+		"",
+	}
+	switch {
+	case c.loading:
+		lines = append(lines, c.spinner.View()+" Checking...")
+	case len(c.results) > 0:
+		lines = append(lines, subtitleStyle.Render(fmt.Sprintf("  %-38s  %8s %8s %8s", "", "register", "renew", "transfer")))
+		start, end := windowRange(len(c.results), 0, maxInt(5, minInt(16, height-14)))
+		for _, r := range c.results[start:end] {
+			lines = append(lines, domainCheckLine(r))
+		}
+	}
+	if c.errMsg != "" {
+		lines = append(lines, "", errorStyle.Render(c.errMsg))
+	}
+	lines = append(lines, "", footerStyle.Render("enter: check   esc: close"))
+	box := modalPanelStyle.Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(maxInt(70, width), maxInt(20, height), lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dorkitude/simple/internal/registrar"
+)
+
+func TestShellDomainCheckAcrossTLDs(t *testing.T) {
+	withDemoBackend(t)
+
+	m := newTestShell(t)
+	drain(t, m, m.activate(tabDomains))
+
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'$'}}))
+	if !m.check.visible || !m.BlocksGlobalKeys() {
+		t.Fatal("$ did not open the availability check")
+	}
+	// Letters go to the input rather than switching tabs.
+	typeText(m, "acme")
+	if m.active != tabDomains || m.check.input.Value() != "acme" {
+		t.Fatalf("typing leaked to the shell: tab=%v input=%q", m.active, m.check.input.Value())
+	}
+
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if m.check.loading || len(m.check.results) != len(registrar.DefaultTLDs) {
+		t.Fatalf("got %d results, err=%q", len(m.check.results), m.check.errMsg)
+	}
+	byName := map[string]registrar.Result{}
+	for _, r := range m.check.results {
+		byName[r.Domain] = r
+	}
+	if byName[contractFreeName].Domain != "" {
+		t.Errorf("%s checked for a bare acme", contractFreeName)
+	}
+	if byName["acme.dev"].Available || !byName["acme.com"].Available {
+		t.Errorf("acme.dev = %+v, acme.com = %+v", byName["acme.dev"], byName["acme.com"])
+	}
+	view := m.View()
+	for _, want := range []string{"Check Availability", "acme.com", "available", "taken", "14.00"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	m.check.input.SetValue("bad name!")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.check.errMsg, "not a valid domain name") {
+		t.Errorf("invalid input accepted: err=%q", m.check.errMsg)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.check.visible || m.BlocksGlobalKeys() {
+		t.Fatal("esc did not close the availability check")
+	}
+}
@@ -11,6 +11,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/forwarding"
+	"github.com/dorkitude/simple/internal/nameservers"
+	"github.com/dorkitude/simple/internal/templates"
 )
 
 type domainDashSection int
@@ -19,6 +22,8 @@ const (
 	domainSectionOverview domainDashSection = iota
 	domainSectionRecords
 	domainSectionZone
+	domainSectionDnssec
+	domainSectionEmail
 	domainSectionDiagnostics
 	domainSectionActions
 )
@@ -30,29 +35,37 @@ type domainDashboardDeletedMsg struct {
 }
 
 type domainDashboardLoadedMsg struct {
+	gen      int
 	domain   *dnsimple.Domain
 	zone     *dnsimple.Zone
 	records  []dnsimple.ZoneRecord
+	dnssec   *bool
+	ds       []dnsimple.DelegationSignerRecord
+	forwards []dnsimple.EmailForward
 	warnings []string
 	err      error
 }
 
 type domainDashboardRecordDetailMsg struct {
+	gen    int
 	record *dnsimple.ZoneRecord
 	err    error
 }
 
 type domainDashboardZoneFileMsg struct {
+	gen      int
 	zoneFile string
 	err      error
 }
 
 type domainDashboardZoneDistributionMsg struct {
+	gen         int
 	distributed bool
 	err         error
 }
 
 type domainDashboardRecordDistributionMsg struct {
+	gen         int
 	recordID    int64
 	distributed bool
 	err         error
@@ -67,26 +80,6 @@ type domainDashboardMutationMsg struct {
 	err    error
 }
 
-type confirmMutation int
-
-const (
-	mutationNone confirmMutation = iota
-	mutationZoneActivate
-	mutationZoneDeactivate
-	mutationDeleteRecord
-	mutationDeleteDomain
-)
-
-type confirmModal struct {
-	visible bool
-	busy    bool
-	action  confirmMutation
-	title   string
-	body    string
-	input   textinput.Model
-	errMsg  string
-}
-
 type DomainDashboardModel struct {
 	width    int
 	height   int
@@ -101,15 +94,34 @@ type DomainDashboardModel struct {
 	dataDomain *dnsimple.Domain
 	dataZone   *dnsimple.Zone
 	records    []dnsimple.ZoneRecord
+	// dnssec is nil when the DNSSEC status could not be loaded.
+	dnssec    *bool
+	dsRecords []dnsimple.DelegationSignerRecord
 
 	selectedRecord int
 	recordDetail   *dnsimple.ZoneRecord
 
+	forwards        []dnsimple.EmailForward
+	selectedForward int
+
 	diagTitle string
 	diagBody  string
 
 	selectedAction int
 	modal          confirmModal
+	nsForm         nsForm
+	fwdForm        forwardForm
+	tmplPicker     templatePicker
+	recipeForm     recipeForm
+	// pendingNS is the NS set awaiting confirmation.
+	pendingNS []string
+	// pendingForward is the email forward awaiting confirmation.
+	pendingForward pendingForward
+	// pendingPlan is the template or recipe preview awaiting confirmation.
+	pendingPlan *templates.Plan
+
+	req         requestScope
+	interrupted bool
 }
 
 func NewDomainDashboardModel(domain string) DomainDashboardModel {
@@ -117,18 +129,12 @@ func NewDomainDashboardModel(domain string) DomainDashboardModel {
 	spin.Spinner = spinner.Line
 	spin.Style = subtitleStyle
 
-	ti := textinput.New()
-	ti.Prompt = "> "
-	ti.Placeholder = "type confirm"
-	ti.CharLimit = 64
-	ti.Width = 24
-
 	return DomainDashboardModel{
 		domain:  domain,
 		spinner: spin,
-		modal: confirmModal{
-			input: ti,
-		},
+		modal:   newConfirmModal(),
+		nsForm:  nsForm{input: newNSInput()},
+		fwdForm: newForwardForm(),
 	}
 }
 
@@ -151,6 +157,33 @@ func (m *DomainDashboardModel) Update(msg tea.Msg) tea.Cmd {
 			return m.updateModal(msg)
 		}
 	}
+	if m.nsForm.visible {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			return m.updateNSForm(key)
+		}
+	}
+	if m.fwdForm.visible {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			return m.updateForwardForm(key)
+		}
+	}
+	if m.recipeForm.visible {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			return m.updateRecipeForm(key)
+		}
+	}
+	if m.tmplPicker.visible {
+		switch msg.(type) {
+		case tea.KeyMsg, domainDashboardTemplatesMsg, domainDashboardTemplatePlanMsg:
+			return m.updateTemplatePicker(msg)
+		case spinner.TickMsg:
+			if m.tmplPicker.loading {
+				var cmd tea.Cmd
+				m.spinner, cmd = m.spinner.Update(msg)
+				return cmd
+			}
+		}
+	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -168,6 +201,12 @@ func (m *DomainDashboardModel) Update(msg tea.Msg) tea.Cmd {
 		case "z", "Z":
 			m.section = domainSectionZone
 			return nil
+		case "s", "S":
+			m.section = domainSectionDnssec
+			return nil
+		case "m", "M":
+			m.section = domainSectionEmail
+			return nil
 		case "g", "G":
 			m.section = domainSectionDiagnostics
 			return nil
@@ -193,15 +232,34 @@ func (m *DomainDashboardModel) Update(msg tea.Msg) tea.Cmd {
 			m.section = domainSectionDiagnostics
 			m.loading = true
 			return tea.Batch(m.spinner.Tick, m.loadZoneDistributionCmd())
+		case "n":
+			if m.section == domainSectionZone && m.dataZone != nil {
+				m.openNSForm()
+				return textinput.Blink
+			}
+			if m.section == domainSectionEmail {
+				m.openForwardForm()
+				return textinput.Blink
+			}
+		case "e":
+			if m.section == domainSectionDnssec && m.dnssec != nil {
+				m.openDnssecConfirm()
+				return textinput.Blink
+			}
 		case "D":
 			if m.section == domainSectionRecords && m.selectedRecordPtr() != nil {
 				m.openConfirm(mutationDeleteRecord, "Delete Record", "This will permanently delete the selected record from the zone.")
 				return textinput.Blink
 			}
+			if m.section == domainSectionEmail && m.selectedForwardPtr() != nil {
+				m.openConfirm(mutationDeleteForward, "Delete Email Forward", "This will stop forwarding mail for the alias.")
+				return textinput.Blink
+			}
 		}
 
 		switch {
 		case matches(msg, keys.Back):
+			m.req.stop()
 			return func() tea.Msg { return domainDashboardExitMsg{} }
 		case matches(msg, keys.Up):
 			m.moveSelection(-1)
@@ -214,6 +272,9 @@ func (m *DomainDashboardModel) Update(msg tea.Msg) tea.Cmd {
 		}
 
 	case domainDashboardLoadedMsg:
+		if !m.req.current(msg.gen) {
+			return nil
+		}
 		m.loading = false
 		if msg.err != nil {
 			m.errMsg = msg.err.Error()
@@ -224,11 +285,20 @@ func (m *DomainDashboardModel) Update(msg tea.Msg) tea.Cmd {
 		m.dataDomain = msg.domain
 		m.dataZone = msg.zone
 		m.records = msg.records
+		m.dnssec = msg.dnssec
+		m.dsRecords = msg.ds
+		m.forwards = msg.forwards
 		if m.selectedRecord >= len(m.records) {
 			m.selectedRecord = maxInt(0, len(m.records)-1)
 		}
+		if m.selectedForward >= len(m.forwards) {
+			m.selectedForward = maxInt(0, len(m.forwards)-1)
+		}
 		return nil
 	case domainDashboardRecordDetailMsg:
+		if !m.req.current(msg.gen) {
+			return nil
+		}
 		m.loading = false
 		if msg.err != nil {
 			m.errMsg = msg.err.Error()
@@ -239,6 +309,9 @@ func (m *DomainDashboardModel) Update(msg tea.Msg) tea.Cmd {
 		m.section = domainSectionRecords
 		return nil
 	case domainDashboardZoneFileMsg:
+		if !m.req.current(msg.gen) {
+			return nil
+		}
 		m.loading = false
 		if msg.err != nil {
 			m.errMsg = msg.err.Error()
@@ -250,6 +323,9 @@ func (m *DomainDashboardModel) Update(msg tea.Msg) tea.Cmd {
 		m.diagBody = msg.zoneFile
 		return nil
 	case domainDashboardZoneDistributionMsg:
+		if !m.req.current(msg.gen) {
+			return nil
+		}
 		m.loading = false
 		if msg.err != nil {
 			m.errMsg = msg.err.Error()
@@ -265,6 +341,9 @@ func (m *DomainDashboardModel) Update(msg tea.Msg) tea.Cmd {
 		m.diagBody = "Distributed: " + strconv.FormatBool(msg.distributed) + "\nStatus: " + status
 		return nil
 	case domainDashboardRecordDistributionMsg:
+		if !m.req.current(msg.gen) {
+			return nil
+		}
 		m.loading = false
 		if msg.err != nil {
 			m.errMsg = msg.err.Error()
@@ -310,23 +389,10 @@ func (m *DomainDashboardModel) Update(msg tea.Msg) tea.Cmd {
 func (m *DomainDashboardModel) updateModal(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if matches(msg, keys.Back) && !m.modal.busy {
-			m.modal.visible = false
-			m.modal.errMsg = ""
-			m.modal.input.Blur()
-			return nil
-		}
-		if matches(msg, keys.Enter) && !m.modal.busy {
-			if strings.TrimSpace(m.modal.input.Value()) != "confirm" {
-				m.modal.errMsg = "Type confirm to proceed"
-				return nil
-			}
-			m.modal.busy = true
-			m.modal.errMsg = ""
+		confirmed, cmd := m.modal.update(msg)
+		if confirmed {
 			return tea.Batch(m.spinner.Tick, m.mutationCmd())
 		}
-		var cmd tea.Cmd
-		m.modal.input, cmd = m.modal.input.Update(msg)
 		return cmd
 	case spinner.TickMsg:
 		if m.modal.busy {
@@ -351,6 +417,14 @@ func (m *DomainDashboardModel) View() string {
 	content := frame(m.width, strings.Join(body, "\n"))
 	if m.modal.visible {
 		content = overlayDialog(content, m.confirmDialogView())
+	} else if m.nsForm.visible {
+		content = overlayDialog(content, m.nsFormView())
+	} else if m.fwdForm.visible {
+		content = overlayDialog(content, m.forwardFormView())
+	} else if m.tmplPicker.visible {
+		content = overlayDialog(content, m.templatePickerView())
+	} else if m.recipeForm.visible {
+		content = overlayDialog(content, m.recipeFormView())
 	}
 	return content
 }
@@ -364,6 +438,8 @@ func (m *DomainDashboardModel) domainSectionTabs() string {
 		{domainSectionOverview, "Overview", "o"},
 		{domainSectionRecords, "Records", "c"}, // reCords; r is refresh
 		{domainSectionZone, "Zone", "z"},
+		{domainSectionDnssec, "DNSSEC", "s"},           // dnsSec; d is the Domains tab
+		{domainSectionEmail, "Email", "m"},             // eMail; e toggles DNSSEC
 		{domainSectionDiagnostics, "Diagnostics", "g"}, // diaGnostics
 		{domainSectionActions, "Actions", "a"},
 	}
@@ -391,6 +467,10 @@ func (m *DomainDashboardModel) dashboardBody() string {
 		return m.recordsSection()
 	case domainSectionZone:
 		return m.zoneSection()
+	case domainSectionDnssec:
+		return m.dnssecSection()
+	case domainSectionEmail:
+		return m.emailSection()
 	case domainSectionDiagnostics:
 		return m.diagnosticsSection()
 	case domainSectionActions:
@@ -516,7 +596,18 @@ func (m *DomainDashboardModel) zoneSection() string {
 		"Secondary: "+strconv.FormatBool(z.Secondary),
 		"Created: "+z.CreatedAt,
 		"Updated: "+z.UpdatedAt,
+	)
+	ns := m.currentNameServers()
+	lines = append(lines, "", panelTitleStyle.Render(fmt.Sprintf("Name Servers (%d)", len(ns))), "")
+	if len(ns) == 0 {
+		lines = append(lines, subtitleStyle.Render("No NS records at the zone apex."))
+	}
+	for _, n := range ns {
+		lines = append(lines, n)
+	}
+	lines = append(lines,
 		"",
+		subtitleStyle.Render("n: edit name servers (confirm required)"),
 		subtitleStyle.Render("Use f for zone file, x for zone distribution, and the Actions tab for mutations."),
 	)
 	if m.status != "" {
@@ -528,6 +619,41 @@ func (m *DomainDashboardModel) zoneSection() string {
 	return clipMultilineText(strings.Join(lines, "\n"), m.contentLineBudget())
 }
 
+func (m *DomainDashboardModel) dnssecSection() string {
+	lines := []string{panelTitleStyle.Render("DNSSEC"), ""}
+	switch {
+	case m.dnssec == nil:
+		lines = append(lines, warningStyle.Render("DNSSEC status not available for this domain."))
+	case *m.dnssec:
+		lines = append(lines, "Status: "+successStyle.Render("enabled"), "", subtitleStyle.Render("e: disable DNSSEC (confirm required)"))
+	default:
+		lines = append(lines, "Status: "+warningStyle.Render("disabled"), "", subtitleStyle.Render("e: enable DNSSEC (confirm required)"))
+	}
+
+	lines = append(lines, "", panelTitleStyle.Render(fmt.Sprintf("DS Records (%d)", len(m.dsRecords))), "")
+	if len(m.dsRecords) == 0 {
+		lines = append(lines, subtitleStyle.Render("No delegation signer records."))
+	}
+	for _, ds := range m.dsRecords {
+		lines = append(lines,
+			fmt.Sprintf("ID %d  keytag %s  algorithm %s  digest type %s", ds.ID, ds.Keytag, ds.Algorithm, ds.DigestType))
+		if ds.Digest != "" {
+			lines = append(lines, wrapLabelValue("  digest: ", ds.Digest, 72)...)
+		}
+		if ds.PublicKey != "" {
+			lines = append(lines, wrapLabelValue("  public key: ", ds.PublicKey, 72)...)
+		}
+	}
+	lines = append(lines, "", subtitleStyle.Render("Manage DS records with simple ds list|create|delete."))
+	if m.status != "" {
+		lines = append(lines, "", successStyle.Render(m.status))
+	}
+	if m.errMsg != "" {
+		lines = append(lines, "", errorStyle.Render(m.errMsg))
+	}
+	return clipMultilineText(strings.Join(lines, "\n"), m.contentLineBudget())
+}
+
 func (m *DomainDashboardModel) diagnosticsSection() string {
 	lines := []string{panelTitleStyle.Render("Diagnostics"), ""}
 	if m.diagTitle == "" && m.diagBody == "" {
@@ -583,8 +709,23 @@ func (m *DomainDashboardModel) actionsSection() string {
 }
 
 func (m *DomainDashboardModel) footerText() string {
-	base := "esc: domains list   /: global domain search   R: refresh dashboard   o/c/z/g/a: section"
+	base := "esc: domains list   /: global domain search   R: refresh dashboard   o/c/z/s/m/g/a: section"
 	switch m.section {
+	case domainSectionZone:
+		if m.dataZone != nil {
+			return base + "   n: edit name servers"
+		}
+		return base
+	case domainSectionDnssec:
+		if m.dnssec != nil {
+			return base + "   e: enable/disable DNSSEC"
+		}
+		return base
+	case domainSectionEmail:
+		if m.selectedForwardPtr() != nil {
+			return base + "   n: add forward   D: delete forward"
+		}
+		return base + "   n: add forward"
 	case domainSectionRecords:
 		return base + "   enter: record details   x: record distribution   D: delete record"
 	case domainSectionDiagnostics:
@@ -596,12 +737,32 @@ func (m *DomainDashboardModel) footerText() string {
 	}
 }
 
+// CancelRequests aborts in-flight loads when the dashboard is left or hidden.
+func (m *DomainDashboardModel) CancelRequests() {
+	if m.loading {
+		m.loading = false
+		m.interrupted = true
+	}
+	m.req.stop()
+}
+
+// Resume reloads the dashboard if CancelRequests interrupted a load.
+func (m *DomainDashboardModel) Resume() tea.Cmd {
+	if !m.interrupted {
+		return nil
+	}
+	m.interrupted = false
+	m.errMsg = ""
+	m.loading = true
+	return tea.Batch(m.spinner.Tick, m.loadDashboardCmd())
+}
+
 func (m *DomainDashboardModel) BlocksGlobalKeys() bool {
-	return m.modal.visible
+	return m.ModalVisible()
 }
 
 func (m *DomainDashboardModel) ModalVisible() bool {
-	return m.modal.visible
+	return m.modal.visible || m.nsForm.visible || m.fwdForm.visible || m.tmplPicker.visible || m.recipeForm.visible
 }
 
 func (m *DomainDashboardModel) contentLineBudget() int {
@@ -618,6 +779,11 @@ func (m *DomainDashboardModel) moveSelection(delta int) {
 			return
 		}
 		m.selectedRecord = minInt(maxInt(0, m.selectedRecord+delta), len(m.records)-1)
+	case domainSectionEmail:
+		if len(m.forwards) == 0 {
+			return
+		}
+		m.selectedForward = minInt(maxInt(0, m.selectedForward+delta), len(m.forwards)-1)
 	case domainSectionActions:
 		actions := m.availableActions()
 		if len(actions) == 0 {
@@ -668,6 +834,24 @@ func (m *DomainDashboardModel) handleEnter() tea.Cmd {
 		case "delete_record":
 			m.openConfirm(mutationDeleteRecord, "Delete Record", "This will permanently delete the selected record.")
 			return textinput.Blink
+		case "edit_ns":
+			m.section = domainSectionZone
+			m.openNSForm()
+			return textinput.Blink
+		case "dnssec_toggle":
+			m.openDnssecConfirm()
+			return textinput.Blink
+		case "add_forward":
+			m.openForwardForm()
+			return textinput.Blink
+		case "delete_forward":
+			m.openConfirm(mutationDeleteForward, "Delete Email Forward", "This will stop forwarding mail for the alias.")
+			return textinput.Blink
+		case "apply_template":
+			return m.openTemplatePicker()
+		case "apply_recipe":
+			m.openRecipeForm()
+			return nil
 		case "delete_domain":
 			m.openConfirm(mutationDeleteDomain, "Delete Domain", "This will permanently delete the domain from your account.")
 			return textinput.Blink
@@ -693,11 +877,34 @@ func (m *DomainDashboardModel) availableActions() []dashboardAction {
 		{ID: "zone_distribution", Label: "Check zone distribution", Hint: "Read-only", Enabled: zoneAvailable, DisabledReason: "Zone unavailable"},
 		{ID: "zone_activate", Label: "Activate DNS for zone", Hint: "Mutation (confirm required)", Enabled: zoneAvailable, DisabledReason: "Zone unavailable"},
 		{ID: "zone_deactivate", Label: "Deactivate DNS for zone", Hint: "Mutation (confirm required)", Enabled: zoneAvailable, DisabledReason: "Zone unavailable"},
+		{ID: "edit_ns", Label: "Edit zone name servers", Hint: "Mutation (confirm required)", Enabled: zoneAvailable, DisabledReason: "Zone unavailable"},
 		{ID: "delete_record", Label: "Delete selected record", Hint: "Mutation (confirm required)", Enabled: recAvailable, DisabledReason: "Select a record first"},
+		m.dnssecAction(),
+		{ID: "add_forward", Label: "Add an email forward", Hint: "Mutation (confirm required)", Enabled: true},
+		{ID: "delete_forward", Label: "Delete selected email forward", Hint: "Mutation (confirm required)", Enabled: m.selectedForwardPtr() != nil, DisabledReason: "Select a forward in the Email section first"},
+		{ID: "apply_template", Label: "Apply a template", Hint: "Preview, then mutation (confirm required)", Enabled: zoneAvailable, DisabledReason: "Zone unavailable"},
+		{ID: "apply_recipe", Label: "Apply a recipe", Hint: "Parameters and preview, then mutation (confirm required)", Enabled: zoneAvailable, DisabledReason: "Zone unavailable"},
 		{ID: "delete_domain", Label: "Delete domain", Hint: "Mutation (confirm required)", Enabled: true},
 	}
 }
 
+func (m *DomainDashboardModel) dnssecAction() dashboardAction {
+	label := "Enable DNSSEC"
+	if m.dnssec != nil && *m.dnssec {
+		label = "Disable DNSSEC"
+	}
+	return dashboardAction{ID: "dnssec_toggle", Label: label, Hint: "Mutation (confirm required)", Enabled: m.dnssec != nil, DisabledReason: "DNSSEC status unavailable"}
+}
+
+// openDnssecConfirm asks to flip DNSSEC to the opposite of its loaded state.
+func (m *DomainDashboardModel) openDnssecConfirm() {
+	if *m.dnssec {
+		m.openConfirm(mutationDnssecDisable, "Disable DNSSEC", "This will stop signing the zone. Remove the DS records at the registry first, or resolvers will fail to validate the domain.")
+		return
+	}
+	m.openConfirm(mutationDnssecEnable, "Enable DNSSEC", "This will sign the zone. DNSSEC only takes effect once a DS record is published at the registry.")
+}
+
 func (m *DomainDashboardModel) selectedRecordPtr() *dnsimple.ZoneRecord {
 	if m.selectedRecord < 0 || m.selectedRecord >= len(m.records) {
 		return nil
@@ -706,47 +913,33 @@ func (m *DomainDashboardModel) selectedRecordPtr() *dnsimple.ZoneRecord {
 }
 
 func (m *DomainDashboardModel) openConfirm(action confirmMutation, title, body string) {
-	m.modal.visible = true
-	m.modal.busy = false
-	m.modal.action = action
-	m.modal.title = title
-	m.modal.body = body
 	if target := m.mutationTargetSummary(action); target != "" {
-		m.modal.body += "\n\n" + target
+		body += "\n\n" + target
 	}
-	m.modal.errMsg = ""
-	m.modal.input.SetValue("")
-	m.modal.input.Focus()
+	m.modal.open(action, title, body)
 }
 
 func (m *DomainDashboardModel) confirmDialogView() string {
-	lines := []string{
-		panelTitleStyle.Render(m.modal.title),
-		"",
-		m.modal.body,
-		"",
-		warningStyle.Render("Type 'confirm' to proceed."),
-		m.modal.input.View(),
-	}
-	if m.modal.errMsg != "" {
-		lines = append(lines, "", errorStyle.Render(m.modal.errMsg))
-	}
-	if m.modal.busy {
-		lines = append(lines, "", m.spinner.View()+" Working...")
-	}
-	lines = append(lines, "", footerStyle.Render("Enter: confirm   esc: cancel"))
-	box := modalPanelStyle.Render(strings.Join(lines, "\n"))
-	return lipgloss.Place(maxInt(60, m.width), maxInt(18, m.height), lipgloss.Center, lipgloss.Center, box)
+	return m.modal.view(m.spinner, m.width, m.height)
 }
 
 func (m *DomainDashboardModel) mutationCmd() tea.Cmd {
 	action := m.modal.action
 	domain := m.domain
+	names := m.pendingNS
+	plan := m.pendingPlan
+	fwd := m.pendingForward
+	var forwardID int64
+	if f := m.selectedForwardPtr(); f != nil {
+		forwardID = f.ID
+	}
 	var recordID int64
 	if rec := m.selectedRecordPtr(); rec != nil {
 		recordID = rec.ID
 	}
 	return func() tea.Msg {
+		// Confirmed mutations are not tied to the request scope: once sent
+		// they run to completion so the outcome is always reported.
 		ctx := context.Background()
 		backend := getBackend()
 		switch action {
@@ -766,6 +959,62 @@ func (m *DomainDashboardModel) mutationCmd() tea.Cmd {
 				reload: true,
 				err:    wrapErr("failed to deactivate zone", err),
 			}
+		case mutationZoneNameServers:
+			_, err := backend.UpdateZoneNameServers(ctx, domain, names)
+			return domainDashboardMutationMsg{
+				kind:   "zone_ns",
+				status: "Name servers updated.",
+				reload: true,
+				err:    wrapErr("failed to update name servers", err),
+			}
+		case mutationApplyTemplate:
+			if plan == nil {
+				return domainDashboardMutationMsg{err: fmt.Errorf("no template chosen")}
+			}
+			err := backend.ApplyTemplate(ctx, plan.Template, domain)
+			return domainDashboardMutationMsg{
+				kind:   "apply_template",
+				status: fmt.Sprintf("Template %s applied (%d records added).", plan.Template, len(plan.Add)),
+				reload: true,
+				err:    wrapErr("failed to apply template", err),
+			}
+		case mutationApplyRecipe:
+			if plan == nil {
+				return domainDashboardMutationMsg{err: fmt.Errorf("no recipe chosen")}
+			}
+			for i, r := range plan.Add {
+				if _, err := backend.CreateRecord(ctx, domain, r); err != nil {
+					return domainDashboardMutationMsg{
+						kind: "apply_recipe",
+						err:  fmt.Errorf("failed to apply recipe after %d of %d records: %w", i, len(plan.Add), err),
+					}
+				}
+			}
+			return domainDashboardMutationMsg{
+				kind:   "apply_recipe",
+				status: fmt.Sprintf("Recipe %s applied (%d records added).", plan.Template, len(plan.Add)),
+				reload: true,
+			}
+		case mutationCreateForward:
+			created, err := backend.CreateEmailForward(ctx, domain, fwd.alias, fwd.destination)
+			status := ""
+			if err == nil {
+				status = fmt.Sprintf("Email forward %s created.", forwardLabel(*created))
+			}
+			return domainDashboardMutationMsg{
+				kind:   "create_forward",
+				status: status,
+				reload: true,
+				err:    wrapErr("failed to create email forward", err),
+			}
+		case mutationDeleteForward:
+			err := backend.DeleteEmailForward(ctx, domain, forwardID)
+			return domainDashboardMutationMsg{
+				kind:   "delete_forward",
+				status: fmt.Sprintf("Email forward %d deleted.", forwardID),
+				reload: true,
+				err:    wrapErr("failed to delete email forward", err),
+			}
 		case mutationDeleteRecord:
 			err := backend.DeleteRecord(ctx, domain, recordID)
 			return domainDashboardMutationMsg{
@@ -774,6 +1023,22 @@ func (m *DomainDashboardModel) mutationCmd() tea.Cmd {
 				reload: true,
 				err:    wrapErr("failed to delete record", err),
 			}
+		case mutationDnssecEnable:
+			err := backend.EnableDnssec(ctx, domain)
+			return domainDashboardMutationMsg{
+				kind:   "dnssec_enable",
+				status: "DNSSEC enabled.",
+				reload: true,
+				err:    wrapErr("failed to enable DNSSEC", err),
+			}
+		case mutationDnssecDisable:
+			err := backend.DisableDnssec(ctx, domain)
+			return domainDashboardMutationMsg{
+				kind:   "dnssec_disable",
+				status: "DNSSEC disabled.",
+				reload: true,
+				err:    wrapErr("failed to disable DNSSEC", err),
+			}
 		case mutationDeleteDomain:
 			err := backend.DeleteDomain(ctx, domain)
 			return domainDashboardMutationMsg{
@@ -791,13 +1056,13 @@ func (m *DomainDashboardModel) mutationCmd() tea.Cmd {
 
 func (m *DomainDashboardModel) loadDashboardCmd() tea.Cmd {
 	domain := m.domain
+	ctx, gen := m.req.begin()
 	return func() tea.Msg {
-		ctx := context.Background()
 		backend := getBackend()
 
 		dataDomain, err := backend.GetDomain(ctx, domain)
 		if err != nil {
-			return domainDashboardLoadedMsg{err: err}
+			return domainDashboardLoadedMsg{gen: gen, err: err}
 		}
 
 		var zone *dnsimple.Zone
@@ -805,7 +1070,9 @@ func (m *DomainDashboardModel) loadDashboardCmd() tea.Cmd {
 		var warnings []string
 
 		respZone, err := backend.GetZone(ctx, domain)
-		if err != nil {
+		if isNotFound(err) {
+			warnings = append(warnings, "no zone exists for this domain")
+		} else if err != nil {
 			warnings = append(warnings, fmt.Sprintf("zone unavailable: %v", err))
 		} else {
 			zone = respZone
@@ -817,10 +1084,30 @@ func (m *DomainDashboardModel) loadDashboardCmd() tea.Cmd {
 			}
 		}
 
+		forwards, err := backend.ListEmailForwards(ctx, domain)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("email forwards unavailable: %v", err))
+		}
+
+		var dnssec *bool
+		var ds []dnsimple.DelegationSignerRecord
+		if enabled, err := backend.GetDnssec(ctx, domain); err != nil {
+			warnings = append(warnings, fmt.Sprintf("DNSSEC status unavailable: %v", err))
+		} else {
+			dnssec = &enabled
+			if ds, err = backend.ListDSRecords(ctx, domain); err != nil {
+				warnings = append(warnings, fmt.Sprintf("DS records unavailable: %v", err))
+			}
+		}
+
 		return domainDashboardLoadedMsg{
+			gen:      gen,
 			domain:   dataDomain,
 			zone:     zone,
 			records:  records,
+			dnssec:   dnssec,
+			ds:       ds,
+			forwards: forwards,
 			warnings: warnings,
 		}
 	}
@@ -828,45 +1115,49 @@ func (m *DomainDashboardModel) loadDashboardCmd() tea.Cmd {
 
 func (m *DomainDashboardModel) loadRecordDetailCmd(recordID int64) tea.Cmd {
 	domain := m.domain
+	ctx, gen := m.req.begin()
 	return func() tea.Msg {
-		resp, err := getBackend().GetRecord(context.Background(), domain, recordID)
+		resp, err := getBackend().GetRecord(ctx, domain, recordID)
 		if err != nil {
-			return domainDashboardRecordDetailMsg{err: err}
+			return domainDashboardRecordDetailMsg{gen: gen, err: err}
 		}
-		return domainDashboardRecordDetailMsg{record: resp}
+		return domainDashboardRecordDetailMsg{gen: gen, record: resp}
 	}
 }
 
 func (m *DomainDashboardModel) loadZoneFileCmd() tea.Cmd {
 	domain := m.domain
+	ctx, gen := m.req.begin()
 	return func() tea.Msg {
-		resp, err := getBackend().GetZoneFile(context.Background(), domain)
+		resp, err := getBackend().GetZoneFile(ctx, domain)
 		if err != nil {
-			return domainDashboardZoneFileMsg{err: err}
+			return domainDashboardZoneFileMsg{gen: gen, err: err}
 		}
-		return domainDashboardZoneFileMsg{zoneFile: resp}
+		return domainDashboardZoneFileMsg{gen: gen, zoneFile: resp}
 	}
 }
 
 func (m *DomainDashboardModel) loadZoneDistributionCmd() tea.Cmd {
 	domain := m.domain
+	ctx, gen := m.req.begin()
 	return func() tea.Msg {
-		resp, err := getBackend().CheckZoneDistribution(context.Background(), domain)
+		resp, err := getBackend().CheckZoneDistribution(ctx, domain)
 		if err != nil {
-			return domainDashboardZoneDistributionMsg{err: err}
+			return domainDashboardZoneDistributionMsg{gen: gen, err: err}
 		}
-		return domainDashboardZoneDistributionMsg{distributed: resp}
+		return domainDashboardZoneDistributionMsg{gen: gen, distributed: resp}
 	}
 }
 
 func (m *DomainDashboardModel) loadRecordDistributionCmd(recordID int64) tea.Cmd {
 	domain := m.domain
+	ctx, gen := m.req.begin()
 	return func() tea.Msg {
-		resp, err := getBackend().CheckRecordDistribution(context.Background(), domain, recordID)
+		resp, err := getBackend().CheckRecordDistribution(ctx, domain, recordID)
 		if err != nil {
-			return domainDashboardRecordDistributionMsg{err: err}
+			return domainDashboardRecordDistributionMsg{gen: gen, err: err}
 		}
-		return domainDashboardRecordDistributionMsg{recordID: recordID, distributed: resp}
+		return domainDashboardRecordDistributionMsg{gen: gen, recordID: recordID, distributed: resp}
 	}
 }
 
@@ -885,6 +1176,59 @@ func (m *DomainDashboardModel) mutationTargetSummary(action confirmMutation) str
 			"Target zone:",
 			"  "+m.domain,
 		)
+	case mutationZoneNameServers:
+		lines = append(lines,
+			"Target zone:",
+			"  "+m.domain,
+			"",
+		)
+		lines = append(lines, nsChangeLines(nameservers.NewChange(m.domain, m.currentNameServers(), m.pendingNS))...)
+	case mutationApplyTemplate, mutationApplyRecipe:
+		if m.pendingPlan == nil {
+			break
+		}
+		label := "Template:"
+		if action == mutationApplyRecipe {
+			label = "Recipe:"
+		}
+		lines = append(lines,
+			label,
+			"  "+m.pendingPlan.Template,
+			"Target zone:",
+			"  "+m.domain,
+			"",
+		)
+		lines = append(lines, templatePlanLines(*m.pendingPlan)...)
+	case mutationCreateForward:
+		alias := m.pendingForward.alias
+		if alias == forwarding.CatchAll {
+			alias += " (catch-all)"
+		}
+		lines = append(lines,
+			"Target domain:",
+			"  "+m.domain,
+			"Alias:",
+			"  "+alias,
+			"Forward to:",
+			"  "+m.pendingForward.destination,
+		)
+	case mutationDeleteForward:
+		f := m.selectedForwardPtr()
+		if f == nil {
+			lines = append(lines, "Target forward: (none selected)")
+			break
+		}
+		lines = append(lines,
+			"Target forward:",
+			fmt.Sprintf("  ID %d", f.ID),
+			"  "+forwardLabel(*f),
+		)
+	case mutationDnssecEnable, mutationDnssecDisable:
+		lines = append(lines,
+			"Target domain:",
+			"  "+m.domain,
+			fmt.Sprintf("  DS records: %d", len(m.dsRecords)),
+		)
 	case mutationDeleteDomain:
 		lines = append(lines,
 			"Target domain:",
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dorkitude/simple/internal/nameservers"
+)
+
+func TestDashboardDnssecToggleNeedsConfirm(t *testing.T) {
+	backend := withDemoBackend(t)
+
+	m, dash := openDashboard(t, contractSignedDomain)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if dash.section != domainSectionDnssec || dash.dnssec == nil || !*dash.dnssec {
+		t.Fatalf("section=%v dnssec=%v, want the DNSSEC section showing enabled", dash.section, dash.dnssec)
+	}
+	if view := dash.dnssecSection(); !strings.Contains(view, "DS Records (1)") {
+		t.Errorf("DNSSEC section does not list the seeded DS record:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if !dash.modal.visible || dash.modal.action != mutationDnssecDisable {
+		t.Fatal("e did not open the disable confirm dialog")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if on, _ := backend.GetDnssec(context.Background(), contractSignedDomain); !on {
+		t.Fatal("DNSSEC disabled without typing confirm")
+	}
+
+	typeText(m, "confirm")
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if on, _ := backend.GetDnssec(context.Background(), contractSignedDomain); on {
+		t.Fatal("DNSSEC still enabled after confirming")
+	}
+	if dash.modal.visible || dash.dnssec == nil || *dash.dnssec {
+		t.Errorf("after disable: dialog visible=%v, dashboard dnssec=%v", dash.modal.visible, dash.dnssec)
+	}
+}
+
+func TestDashboardNameServersFormConfirmsDiff(t *testing.T) {
+	backend := withDemoBackend(t)
+
+	m, dash := openDashboard(t, contractActiveZone)
+
+	// z is also the global Zones tab key, so pick the section directly.
+	dash.section = domainSectionZone
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if !dash.nsForm.visible || !m.BlocksGlobalKeys() {
+		t.Fatal("n did not open the name server form")
+	}
+	if got := dash.nsForm.input.Value(); got != strings.Join(demoNameServers, " ") {
+		t.Fatalf("form starts with %q, want the current name servers", got)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(dash.nsForm.errMsg, "current") || dash.modal.visible {
+		t.Fatalf("unchanged set accepted: err=%q", dash.nsForm.errMsg)
+	}
+	dash.nsForm.input.SetValue("ns1.dr.example.net 192.0.2.53")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(dash.nsForm.errMsg, "192.0.2.53") {
+		t.Fatalf("address accepted: err=%q", dash.nsForm.errMsg)
+	}
+
+	dash.nsForm.input.SetValue("ns1.dr.example.net, ns1.dnsimple.com")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if dash.nsForm.visible || !dash.modal.visible || dash.modal.action != mutationZoneNameServers {
+		t.Fatal("valid set did not move on to the confirm dialog")
+	}
+	for _, want := range []string{"+ ns1.dr.example.net", "- ns2.dnsimple-edge.net", "    ns1.dnsimple.com"} {
+		if !strings.Contains(dash.modal.body, want) {
+			t.Errorf("confirm dialog missing %q:\n%s", want, dash.modal.body)
+		}
+	}
+
+	typeText(m, "confirm")
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	records, _ := backend.ListRecords(context.Background(), contractActiveZone)
+	if got := strings.Join(nameservers.FromRecords(records), " "); got != "ns1.dnsimple.com ns1.dr.example.net" {
+		t.Fatalf("backend name servers = %q", got)
+	}
+	if dash.modal.visible || !strings.Contains(dash.status, "updated") || len(dash.currentNameServers()) != 2 {
+		t.Errorf("after update: dialog visible=%v, status=%q, dashboard shows %v", dash.modal.visible, dash.status, dash.currentNameServers())
+	}
+}
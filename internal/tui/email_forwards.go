@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/forwarding"
+)
+
+// forwardForm is the dialog for adding an email forward. Submitting it
+// opens the confirm dialog.
+type forwardForm struct {
+	visible bool
+	alias   textinput.Model
+	dest    textinput.Model
+	errMsg  string
+}
+
+// pendingForward is the forward awaiting confirmation.
+type pendingForward struct {
+	alias       string
+	destination string
+}
+
+func newForwardForm() forwardForm {
+	alias := textinput.New()
+	alias.Prompt = "> "
+	alias.Placeholder = "hello, or * for a catch-all"
+	alias.CharLimit = 64
+	alias.Width = 40
+	dest := textinput.New()
+	dest.Prompt = "> "
+	dest.Placeholder = "you@example.net"
+	dest.CharLimit = 254
+	dest.Width = 40
+	return forwardForm{alias: alias, dest: dest}
+}
+
+func (m *DomainDashboardModel) selectedForwardPtr() *dnsimple.EmailForward {
+	if m.selectedForward < 0 || m.selectedForward >= len(m.forwards) {
+		return nil
+	}
+	return &m.forwards[m.selectedForward]
+}
+
+func (m *DomainDashboardModel) openForwardForm() {
+	m.section = domainSectionEmail
+	m.fwdForm.visible = true
+	m.fwdForm.errMsg = ""
+	m.fwdForm.alias.SetValue("")
+	m.fwdForm.dest.SetValue("")
+	m.fwdForm.dest.Blur()
+	m.fwdForm.alias.Focus()
+}
+
+func (m *DomainDashboardModel) closeForwardForm() {
+	m.fwdForm.visible = false
+	m.fwdForm.errMsg = ""
+	m.fwdForm.alias.Blur()
+	m.fwdForm.dest.Blur()
+}
+
+func (m *DomainDashboardModel) updateForwardForm(msg tea.KeyMsg) tea.Cmd {
+	f := &m.fwdForm
+	switch msg.String() {
+	case "esc":
+		m.closeForwardForm()
+		return nil
+	case "tab", "shift+tab", "up", "down":
+		if f.alias.Focused() {
+			f.alias.Blur()
+			f.dest.Focus()
+		} else {
+			f.dest.Blur()
+			f.alias.Focus()
+		}
+		return textinput.Blink
+	case "enter":
+		alias, err := forwarding.Alias(f.alias.Value(), m.domain)
+		if err != nil {
+			f.errMsg = err.Error()
+			return nil
+		}
+		dest, err := forwarding.Destination(f.dest.Value())
+		if err != nil {
+			f.errMsg = err.Error()
+			return nil
+		}
+		m.closeForwardForm()
+		m.pendingForward = pendingForward{alias: alias, destination: dest}
+		m.openConfirm(mutationCreateForward, "Add Email Forward", "This will forward mail for the alias to the destination.")
+		return textinput.Blink
+	}
+
+	var cmd tea.Cmd
+	if f.alias.Focused() {
+		f.alias, cmd = f.alias.Update(msg)
+	} else {
+		f.dest, cmd = f.dest.Update(msg)
+	}
+	f.errMsg = ""
+	return cmd
+}
+
+func (m *DomainDashboardModel) forwardFormView() string {
+	f := m.fwdForm
+	lines := []string{
+		panelTitleStyle.Render("Add Email Forward"),
+		"",
+		"Alias at " + m.domain,
+		f.alias.View(),
+		"",
+		"Forward to",
+		f.dest.View(),
+	}
+	if f.errMsg != "" {
+		lines = append(lines, "", errorStyle.Render(f.errMsg))
+	}
+	lines = append(lines, "", footerStyle.Render("tab: next field   enter: review   esc: cancel"))
+	box := modalPanelStyle.Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(maxInt(70, m.width), maxInt(18, m.height), lipgloss.Center, lipgloss.Center, box)
+}
+
+// forwardLabel describes a forward on one line, marking catch-alls.
+func forwardLabel(f dnsimple.EmailForward) string {
+	s := forwarding.From(f) + " → " + forwarding.To(f)
+	if forwarding.IsCatchAll(f) {
+		s += "  (catch-all)"
+	}
+	return s
+}
+
+func (m *DomainDashboardModel) emailSection() string {
+	lines := []string{panelTitleStyle.Render(fmt.Sprintf("Email Forwards (%d)", len(m.forwards))), ""}
+	if len(m.forwards) == 0 {
+		lines = append(lines, subtitleStyle.Render("No email forwards."))
+	}
+	for i, f := range m.forwards {
+		prefix, style := "  ", itemStyle
+		if i == m.selectedForward {
+			prefix, style = "› ", selectedItemStyle
+		}
+		lines = append(lines, style.Render(fmt.Sprintf("%s%-8d %s", prefix, f.ID, truncateText(forwardLabel(f), 72))))
+	}
+	lines = append(lines,
+		"",
+		subtitleStyle.Render("n: add a forward, D: delete the selected one (confirm required)"),
+		subtitleStyle.Render("An alias of * forwards every address at the domain."),
+	)
+	if m.status != "" {
+		lines = append(lines, "", successStyle.Render(m.status))
+	}
+	if m.errMsg != "" {
+		lines = append(lines, "", errorStyle.Render(m.errMsg))
+	}
+	return clipMultilineText(strings.Join(lines, "\n"), m.contentLineBudget())
+}
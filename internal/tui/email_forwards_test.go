@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dorkitude/simple/internal/forwarding"
+)
+
+func TestDashboardEmailForwards(t *testing.T) {
+	backend := withDemoBackend(t)
+
+	m, dash := openDashboard(t, contractParkedDomain)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	if dash.section != domainSectionEmail || len(dash.forwards) != 2 {
+		t.Fatalf("section=%v with %d forwards", dash.section, len(dash.forwards))
+	}
+	if view := m.View(); !strings.Contains(view, ".*@"+contractParkedDomain) || !strings.Contains(view, "(catch-all)") {
+		t.Errorf("catch-all not shown:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if !dash.fwdForm.visible || !m.BlocksGlobalKeys() {
+		t.Fatal("n did not open the forward form")
+	}
+	typeText(m, "*")
+	m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(m, "not-an-address")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(dash.fwdForm.errMsg, "not an email address") || dash.modal.visible {
+		t.Fatalf("bad destination accepted: err=%q", dash.fwdForm.errMsg)
+	}
+	dash.fwdForm.dest.SetValue("parked@example.net")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if dash.fwdForm.visible || !dash.modal.visible || dash.modal.action != mutationCreateForward {
+		t.Fatal("valid forward did not move on to the confirm dialog")
+	}
+	if !strings.Contains(dash.modal.body, forwarding.CatchAll+" (catch-all)") {
+		t.Errorf("confirm dialog does not name the catch-all:\n%s", dash.modal.body)
+	}
+	typeText(m, "confirm")
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if len(dash.forwards) != 3 || !strings.Contains(dash.status, "created") {
+		t.Fatalf("after create: %d forwards, status=%q, err=%q", len(dash.forwards), dash.status, dash.errMsg)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	target := dash.forwards[dash.selectedForward]
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	if !dash.modal.visible || dash.modal.action != mutationDeleteForward || !strings.Contains(dash.modal.body, "parked@example.net") {
+		t.Fatalf("D did not confirm deleting the selected forward:\n%s", dash.modal.body)
+	}
+	typeText(m, "confirm")
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	left, _ := backend.ListEmailForwards(context.Background(), contractParkedDomain)
+	if len(left) != 2 || len(dash.forwards) != 2 {
+		t.Fatalf("after delete: backend has %d forwards, dashboard %d", len(left), len(dash.forwards))
+	}
+	for _, f := range left {
+		if f.ID == target.ID {
+			t.Fatalf("forward %d still listed", target.ID)
+		}
+	}
+}
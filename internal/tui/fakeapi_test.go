@@ -0,0 +1,558 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/client"
+)
+
+// fakeAPI is an in-process stand-in for the DNSimple v2 API covering the
+// endpoints realBackend calls. It is seeded from the demo fixtures so both
+// backends can be exercised against the same names and IDs.
+type fakeAPI struct {
+	mu        sync.Mutex
+	server    *httptest.Server
+	accountID string
+	whoami    *dnsimple.WhoamiData
+	accounts  []dnsimple.Account
+	data      map[string]*demoAccount
+
+	// account, and its domains, zones, records and webhooks, alias the data
+	// of the account in the request path while a request is served.
+	account  *demoAccount
+	domains  map[string]dnsimple.Domain
+	zones    map[string]dnsimple.Zone
+	records  map[string][]dnsimple.ZoneRecord
+	webhooks map[int64]dnsimple.Webhook
+
+	nextWebhookID int64
+	nextRecordID  int64
+	nextForwardID int64
+}
+
+func newFakeAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+
+	seed := newDemoBackend()
+	f := &fakeAPI{
+		accountID:     strconv.FormatInt(seed.current, 10),
+		whoami:        seed.whoami,
+		data:          map[string]*demoAccount{},
+		nextWebhookID: seed.nextWebhookID,
+		nextRecordID:  seed.nextRecordID,
+		nextForwardID: seed.nextForwardID,
+	}
+	// The API makes no ordering promise; list accounts and store records
+	// newest-first so the backend's own ordering is what the contract
+	// observes.
+	for i := len(seed.accounts) - 1; i >= 0; i-- {
+		f.accounts = append(f.accounts, seed.accounts[i])
+	}
+	for id, src := range seed.data {
+		dst := newDemoAccount()
+		for name, d := range src.domains {
+			dst.domains[name] = d
+		}
+		for name, z := range src.zones {
+			dst.zones[name] = z
+		}
+		for name, recs := range src.records {
+			rev := make([]dnsimple.ZoneRecord, 0, len(recs))
+			for i := len(recs) - 1; i >= 0; i-- {
+				rev = append(rev, recs[i])
+			}
+			dst.records[name] = rev
+		}
+		for name, on := range src.dnssec {
+			dst.dnssec[name] = on
+		}
+		for name, ds := range src.ds {
+			dst.ds[name] = append([]dnsimple.DelegationSignerRecord(nil), ds...)
+		}
+		for name, list := range src.forwards {
+			rev := make([]dnsimple.EmailForward, 0, len(list))
+			for i := len(list) - 1; i >= 0; i-- {
+				rev = append(rev, list[i])
+			}
+			dst.forwards[name] = rev
+		}
+		for id, w := range src.webhooks {
+			dst.webhooks[id] = w
+		}
+		for id, t := range src.templates {
+			dst.templates[id] = t
+			dst.templateRecords[id] = append([]dnsimple.TemplateRecord(nil), src.templateRecords[id]...)
+		}
+		f.data[strconv.FormatInt(id, 10)] = dst
+	}
+
+	acct := "/v2/{account}"
+	enable, disable := true, false
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/whoami", f.handleWhoami)
+	mux.HandleFunc("GET /v2/accounts", f.handleListAccounts)
+	mux.HandleFunc("GET "+acct+"/domains", f.handleListDomains)
+	mux.HandleFunc("GET "+acct+"/domains/{domain}", f.handleGetDomain)
+	mux.HandleFunc("DELETE "+acct+"/domains/{domain}", f.handleDeleteDomain)
+	mux.HandleFunc("GET "+acct+"/domains/{domain}/dnssec", f.handleDnssec(nil))
+	mux.HandleFunc("POST "+acct+"/domains/{domain}/dnssec", f.handleDnssec(&enable))
+	mux.HandleFunc("DELETE "+acct+"/domains/{domain}/dnssec", f.handleDnssec(&disable))
+	mux.HandleFunc("GET "+acct+"/domains/{domain}/ds_records", f.handleListDSRecords)
+	mux.HandleFunc("GET "+acct+"/domains/{domain}/email_forwards", f.handleListEmailForwards)
+	mux.HandleFunc("POST "+acct+"/domains/{domain}/email_forwards", f.handleCreateEmailForward)
+	mux.HandleFunc("DELETE "+acct+"/domains/{domain}/email_forwards/{id}", f.handleDeleteEmailForward)
+	mux.HandleFunc("POST "+acct+"/domains/{domain}/templates/{template}", f.handleApplyTemplate)
+	mux.HandleFunc("GET "+acct+"/zones", f.handleListZones)
+	mux.HandleFunc("GET "+acct+"/zones/{zone}", f.handleGetZone)
+	mux.HandleFunc("GET "+acct+"/zones/{zone}/file", f.handleZoneFile)
+	mux.HandleFunc("GET "+acct+"/zones/{zone}/distribution", f.handleZoneDistribution)
+	mux.HandleFunc("PUT "+acct+"/zones/{zone}/activation", f.handleActivation(true))
+	mux.HandleFunc("DELETE "+acct+"/zones/{zone}/activation", f.handleActivation(false))
+	mux.HandleFunc("PUT "+acct+"/zones/{zone}/ns_records", f.handleUpdateNameServers)
+	mux.HandleFunc("GET "+acct+"/zones/{zone}/records", f.handleListRecords)
+	mux.HandleFunc("POST "+acct+"/zones/{zone}/records", f.handleCreateRecord)
+	mux.HandleFunc("GET "+acct+"/zones/{zone}/records/{id}", f.handleGetRecord)
+	mux.HandleFunc("DELETE "+acct+"/zones/{zone}/records/{id}", f.handleDeleteRecord)
+	mux.HandleFunc("GET "+acct+"/zones/{zone}/records/{id}/distribution", f.handleRecordDistribution)
+	mux.HandleFunc("GET "+acct+"/registrar/domains/{domain}/check", f.handleCheckDomain)
+	mux.HandleFunc("GET "+acct+"/registrar/domains/{domain}/prices", f.handleDomainPrices)
+	mux.HandleFunc("GET "+acct+"/webhooks", f.handleListWebhooks)
+	mux.HandleFunc("POST "+acct+"/webhooks", f.handleCreateWebhook)
+	mux.HandleFunc("DELETE "+acct+"/webhooks/{id}", f.handleDeleteWebhook)
+	mux.HandleFunc("GET "+acct+"/templates", f.handleListTemplates)
+	mux.HandleFunc("GET "+acct+"/templates/{template}/records", f.handleListTemplateRecords)
+
+	f.server = httptest.NewServer(f.requireAccount(mux))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// backend returns a realBackend whose client talks to the fake server.
+func (f *fakeAPI) backend() Backend {
+	return &realBackend{newApp: func(ctx context.Context) (*client.App, error) {
+		c := dnsimple.NewClient(f.server.Client())
+		c.BaseURL = f.server.URL
+		return &client.App{Client: c, AccountID: f.accountID}, nil
+	}}
+}
+
+func (f *fakeAPI) requireAccount(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/v2/"), "/", 2)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if parts[0] != "whoami" && parts[0] != "accounts" {
+			data, ok := f.data[parts[0]]
+			if !ok {
+				writeAPIError(w, http.StatusNotFound, "Account `"+parts[0]+"` not found")
+				return
+			}
+			f.account = data
+			f.domains, f.zones, f.records, f.webhooks = data.domains, data.zones, data.records, data.webhooks
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeAPIData(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
+
+func (f *fakeAPI) handleWhoami(w http.ResponseWriter, r *http.Request) {
+	writeAPIData(w, http.StatusOK, f.whoami)
+}
+
+func (f *fakeAPI) handleListAccounts(w http.ResponseWriter, r *http.Request) {
+	writeAPIData(w, http.StatusOK, f.accounts)
+}
+
+func (f *fakeAPI) handleListDomains(w http.ResponseWriter, r *http.Request) {
+	out := make([]dnsimple.Domain, 0, len(f.domains))
+	for _, d := range f.domains {
+		out = append(out, d)
+	}
+	writeAPIData(w, http.StatusOK, out)
+}
+
+func (f *fakeAPI) handleGetDomain(w http.ResponseWriter, r *http.Request) {
+	d, ok := f.domains[r.PathValue("domain")]
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "Domain `"+r.PathValue("domain")+"` not found")
+		return
+	}
+	writeAPIData(w, http.StatusOK, d)
+}
+
+func (f *fakeAPI) handleDeleteDomain(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("domain")
+	if _, ok := f.domains[name]; !ok {
+		writeAPIError(w, http.StatusNotFound, "Domain `"+name+"` not found")
+		return
+	}
+	delete(f.domains, name)
+	delete(f.zones, name)
+	delete(f.records, name)
+	delete(f.account.dnssec, name)
+	delete(f.account.ds, name)
+	delete(f.account.forwards, name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleDnssec reads the DNSSEC state, or sets it when set is non-nil.
+func (f *fakeAPI) handleDnssec(set *bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("domain")
+		if _, ok := f.domains[name]; !ok {
+			writeAPIError(w, http.StatusNotFound, "Domain `"+name+"` not found")
+			return
+		}
+		switch {
+		case set == nil:
+			writeAPIData(w, http.StatusOK, dnsimple.Dnssec{Enabled: f.account.dnssec[name]})
+		case *set:
+			f.account.dnssec[name] = true
+			writeAPIData(w, http.StatusCreated, dnsimple.Dnssec{Enabled: true})
+		default:
+			f.account.dnssec[name] = false
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
+
+func (f *fakeAPI) handleListDSRecords(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("domain")
+	if _, ok := f.domains[name]; !ok {
+		writeAPIError(w, http.StatusNotFound, "Domain `"+name+"` not found")
+		return
+	}
+	// Newest first, as with records, so the backend's ordering is what the
+	// contract observes.
+	ds := f.account.ds[name]
+	out := make([]dnsimple.DelegationSignerRecord, 0, len(ds))
+	for i := len(ds) - 1; i >= 0; i-- {
+		out = append(out, ds[i])
+	}
+	writeAPIData(w, http.StatusOK, out)
+}
+
+func (f *fakeAPI) handleListEmailForwards(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("domain")
+	if _, ok := f.domains[name]; !ok {
+		writeAPIError(w, http.StatusNotFound, "Domain `"+name+"` not found")
+		return
+	}
+	writeAPIData(w, http.StatusOK, append([]dnsimple.EmailForward{}, f.account.forwards[name]...))
+}
+
+func (f *fakeAPI) handleCreateEmailForward(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("domain")
+	if _, ok := f.domains[name]; !ok {
+		writeAPIError(w, http.StatusNotFound, "Domain `"+name+"` not found")
+		return
+	}
+	var attrs dnsimple.EmailForward
+	if err := json.NewDecoder(r.Body).Decode(&attrs); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "Validation failed")
+		return
+	}
+	fwd, err := f.account.createForward(name, attrs.AliasName, attrs.DestinationEmail, &f.nextForwardID)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "Validation failed")
+		return
+	}
+	// Keep the newest-first storage order.
+	list := f.account.forwards[name]
+	f.account.forwards[name] = append([]dnsimple.EmailForward{fwd}, list[:len(list)-1]...)
+	writeAPIData(w, http.StatusCreated, fwd)
+}
+
+func (f *fakeAPI) handleDeleteEmailForward(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("domain")
+	if _, ok := f.domains[name]; !ok {
+		writeAPIError(w, http.StatusNotFound, "Domain `"+name+"` not found")
+		return
+	}
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || f.account.deleteForward(name, id) != nil {
+		writeAPIError(w, http.StatusNotFound, "Email forward `"+r.PathValue("id")+"` not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeAPI) handleListZones(w http.ResponseWriter, r *http.Request) {
+	out := make([]dnsimple.Zone, 0, len(f.zones))
+	for _, z := range f.zones {
+		out = append(out, z)
+	}
+	writeAPIData(w, http.StatusOK, out)
+}
+
+func (f *fakeAPI) handleGetZone(w http.ResponseWriter, r *http.Request) {
+	z, ok := f.zones[r.PathValue("zone")]
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "Zone `"+r.PathValue("zone")+"` not found")
+		return
+	}
+	writeAPIData(w, http.StatusOK, z)
+}
+
+func (f *fakeAPI) handleZoneFile(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("zone")
+	if _, ok := f.zones[name]; !ok {
+		writeAPIError(w, http.StatusNotFound, "Zone `"+name+"` not found")
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s.\n$TTL 1h\n", name)
+	fmt.Fprintf(&b, "%s. 3600 IN SOA ns1.dnsimple.com. admin.dnsimple.com. 1 86400 7200 604800 300\n", name)
+	for _, rec := range f.records[name] {
+		owner := name + "."
+		if rec.Name != "" {
+			owner = rec.Name + "." + owner
+		}
+		if rec.Priority != 0 {
+			fmt.Fprintf(&b, "%s %d IN %s %d %s\n", owner, rec.TTL, rec.Type, rec.Priority, rec.Content)
+			continue
+		}
+		fmt.Fprintf(&b, "%s %d IN %s %s\n", owner, rec.TTL, rec.Type, rec.Content)
+	}
+	writeAPIData(w, http.StatusOK, dnsimple.ZoneFile{Zone: b.String()})
+}
+
+func (f *fakeAPI) handleZoneDistribution(w http.ResponseWriter, r *http.Request) {
+	z, ok := f.zones[r.PathValue("zone")]
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "Zone `"+r.PathValue("zone")+"` not found")
+		return
+	}
+	writeAPIData(w, http.StatusOK, dnsimple.ZoneDistribution{Distributed: z.Active})
+}
+
+func (f *fakeAPI) handleActivation(active bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("zone")
+		z, ok := f.zones[name]
+		if !ok {
+			writeAPIError(w, http.StatusNotFound, "Zone `"+name+"` not found")
+			return
+		}
+		z.Active = active
+		f.zones[name] = z
+		writeAPIData(w, http.StatusOK, z)
+	}
+}
+
+func (f *fakeAPI) handleListRecords(w http.ResponseWriter, r *http.Request) {
+	recs, ok := f.records[r.PathValue("zone")]
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "Zone `"+r.PathValue("zone")+"` not found")
+		return
+	}
+	writeAPIData(w, http.StatusOK, recs)
+}
+
+func (f *fakeAPI) handleUpdateNameServers(w http.ResponseWriter, r *http.Request) {
+	zone := r.PathValue("zone")
+	recs, ok := f.records[zone]
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "Zone `"+zone+"` not found")
+		return
+	}
+	var body struct {
+		NSNames []string `json:"ns_names"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.NSNames) == 0 {
+		writeAPIError(w, http.StatusBadRequest, "Validation failed")
+		return
+	}
+	// Keep the newest-first storage order: new records go in front.
+	created := make([]dnsimple.ZoneRecord, 0, len(body.NSNames))
+	for _, n := range body.NSNames {
+		f.nextRecordID++
+		created = append([]dnsimple.ZoneRecord{{ID: f.nextRecordID, Type: "NS", Content: n, TTL: 3600}}, created...)
+	}
+	next := created
+	for _, rec := range recs {
+		if rec.Type != "NS" || rec.Name != "" {
+			next = append(next, rec)
+		}
+	}
+	f.records[zone] = next
+	writeAPIData(w, http.StatusOK, created)
+}
+
+func (f *fakeAPI) handleCreateRecord(w http.ResponseWriter, r *http.Request) {
+	zone := r.PathValue("zone")
+	recs, ok := f.records[zone]
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "Zone `"+zone+"` not found")
+		return
+	}
+	var attrs dnsimple.ZoneRecordAttributes
+	if err := json.NewDecoder(r.Body).Decode(&attrs); err != nil || attrs.Type == "" || attrs.Content == "" {
+		writeAPIError(w, http.StatusBadRequest, "Validation failed")
+		return
+	}
+	f.nextRecordID++
+	rec := dnsimple.ZoneRecord{ID: f.nextRecordID, Type: attrs.Type, Content: attrs.Content, TTL: attrs.TTL, Priority: attrs.Priority}
+	if attrs.Name != nil {
+		rec.Name = *attrs.Name
+	}
+	if rec.TTL == 0 {
+		rec.TTL = 3600
+	}
+	f.records[zone] = append([]dnsimple.ZoneRecord{rec}, recs...)
+	writeAPIData(w, http.StatusCreated, rec)
+}
+
+// findRecord writes a 404 and returns -1 when the zone or record is missing.
+func (f *fakeAPI) findRecord(w http.ResponseWriter, r *http.Request) (string, int) {
+	zone := r.PathValue("zone")
+	recs, ok := f.records[zone]
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "Zone `"+zone+"` not found")
+		return zone, -1
+	}
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err == nil {
+		for i, rec := range recs {
+			if rec.ID == id {
+				return zone, i
+			}
+		}
+	}
+	writeAPIError(w, http.StatusNotFound, "Record `"+r.PathValue("id")+"` not found")
+	return zone, -1
+}
+
+func (f *fakeAPI) handleGetRecord(w http.ResponseWriter, r *http.Request) {
+	zone, i := f.findRecord(w, r)
+	if i < 0 {
+		return
+	}
+	writeAPIData(w, http.StatusOK, f.records[zone][i])
+}
+
+func (f *fakeAPI) handleDeleteRecord(w http.ResponseWriter, r *http.Request) {
+	zone, i := f.findRecord(w, r)
+	if i < 0 {
+		return
+	}
+	recs := f.records[zone]
+	f.records[zone] = append(recs[:i:i], recs[i+1:]...)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeAPI) handleRecordDistribution(w http.ResponseWriter, r *http.Request) {
+	zone, i := f.findRecord(w, r)
+	if i < 0 {
+		return
+	}
+	writeAPIData(w, http.StatusOK, dnsimple.ZoneDistribution{Distributed: f.records[zone][i].ID%2 == 0})
+}
+
+func (f *fakeAPI) handleCheckDomain(w http.ResponseWriter, r *http.Request) {
+	c, err := f.account.checkDomain(r.PathValue("domain"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeAPIData(w, http.StatusOK, c)
+}
+
+func (f *fakeAPI) handleDomainPrices(w http.ResponseWriter, r *http.Request) {
+	p, err := demoDomainPrice(r.PathValue("domain"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeAPIData(w, http.StatusOK, p)
+}
+
+func (f *fakeAPI) handleListWebhooks(w http.ResponseWriter, r *http.Request) {
+	out := make([]dnsimple.Webhook, 0, len(f.webhooks))
+	for _, wh := range f.webhooks {
+		out = append(out, wh)
+	}
+	writeAPIData(w, http.StatusOK, out)
+}
+
+func (f *fakeAPI) handleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var attrs dnsimple.Webhook
+	if err := json.NewDecoder(r.Body).Decode(&attrs); err != nil || attrs.URL == "" {
+		writeAPIError(w, http.StatusBadRequest, "Validation failed")
+		return
+	}
+	f.nextWebhookID++
+	wh := dnsimple.Webhook{ID: f.nextWebhookID, URL: attrs.URL}
+	f.webhooks[wh.ID] = wh
+	writeAPIData(w, http.StatusCreated, wh)
+}
+
+func (f *fakeAPI) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if _, ok := f.webhooks[id]; err != nil || !ok {
+		writeAPIError(w, http.StatusNotFound, "Webhook `"+r.PathValue("id")+"` not found")
+		return
+	}
+	delete(f.webhooks, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeAPI) handleListTemplates(w http.ResponseWriter, r *http.Request) {
+	out := make([]dnsimple.Template, 0, len(f.account.templates))
+	for _, t := range f.account.templates {
+		out = append(out, t)
+	}
+	writeAPIData(w, http.StatusOK, out)
+}
+
+func (f *fakeAPI) handleListTemplateRecords(w http.ResponseWriter, r *http.Request) {
+	t, ok := f.account.findTemplate(r.PathValue("template"))
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "Template `"+r.PathValue("template")+"` not found")
+		return
+	}
+	// Newest first, so the backend's ordering is what the contract observes.
+	recs := f.account.templateRecords[t.ID]
+	out := make([]dnsimple.TemplateRecord, 0, len(recs))
+	for i := len(recs) - 1; i >= 0; i-- {
+		out = append(out, recs[i])
+	}
+	writeAPIData(w, http.StatusOK, out)
+}
+
+func (f *fakeAPI) handleApplyTemplate(w http.ResponseWriter, r *http.Request) {
+	domain, template := r.PathValue("domain"), r.PathValue("template")
+	if _, ok := f.domains[domain]; !ok {
+		writeAPIError(w, http.StatusNotFound, "Domain `"+domain+"` not found")
+		return
+	}
+	if _, ok := f.account.findTemplate(template); !ok {
+		writeAPIError(w, http.StatusNotFound, "Template `"+template+"` not found")
+		return
+	}
+	if err := f.account.applyTemplate(template, domain, &f.nextRecordID); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
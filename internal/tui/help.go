@@ -29,6 +29,7 @@ func (m *HelpModel) Update(msg tea.Msg) tea.Cmd { return nil }
 func (m HelpModel) View() string {
 	cfgDir, _ := config.ResolveConfigDir()
 	tokenPath, _ := config.TokenPath()
+	profile := config.CurrentProfileName()
 	configPath, _ := config.ConfigPath()
 
 	left := panelStyle.Render(strings.Join([]string{
@@ -38,14 +39,19 @@ func (m HelpModel) View() string {
 		"2 / d   Domains",
 		"3 / z   Zones",
 		"4 / R   Records",
-		"5 / ?   Help",
+		"5 / w   Webhooks",
+		"6 / t   Templates",
+		"7 / ?   Help",
 		"",
 		"tab / shift+tab   Next/Prev tab",
 		"/                 Domain search (global; jumps to Domains)",
+		"@                 Switch account",
+		"$                 Check domain availability and prices",
 		"j/k or arrows     Move selection",
 		"enter             Open / inspect selected item",
 		"esc               Back (or return home)",
 		"r                 Refresh current list",
+		"F                 Filter list (same syntax as --where)",
 		"q                 Quit",
 	}, "\n"))
 
@@ -55,13 +61,14 @@ func (m HelpModel) View() string {
 		"Config dir:",
 		codeStyle.Render(cfgDir),
 		"",
-		"Token file:",
+		"Token file (profile " + profile + "):",
 		codeStyle.Render(tokenPath),
 		"",
 		"Config file:",
 		codeStyle.Render(configPath),
 		"",
 		subtitleStyle.Render("Override config dir with DNSIMPLE_CONFIG_DIR"),
+		subtitleStyle.Render("Pick a profile with --profile or SIMPLE_PROFILE"),
 	}, "\n"))
 
 	body := []string{
@@ -73,8 +80,13 @@ func (m HelpModel) View() string {
 		panelStyle.Render(strings.Join([]string{
 			panelTitleStyle.Render("Tab-Specific Actions"),
 			"",
+			"Domain dashboard: s (DNSSEC), e (enable/disable, type confirm), n in Zone (edit NS)",
+			"Domain dashboard: m (email forwards), n (add) and D (delete) there, type confirm",
 			"Zones tab: f (zone file), x (distribution status)",
 			"Records tab: enter on a zone first, then x (record distribution status)",
+			"Webhooks tab: n (new webhook), D (delete, type confirm)",
+			"Templates tab: enter (template records); apply from a domain dashboard's Actions",
+			"Recipes: apply from a domain dashboard's Actions (parameters, preview, type confirm)",
 			"",
 			subtitleStyle.Render("Mutating operations (create/update/delete) remain available via CLI commands."),
 		}, "\n")),
@@ -1,7 +1,6 @@
 package tui
 
 import (
-	"context"
 	"strconv"
 	"strings"
 
@@ -11,8 +10,11 @@ import (
 )
 
 type homeWhoamiMsg struct {
-	data *dnsimple.WhoamiData
-	err  error
+	gen        int
+	data       *dnsimple.WhoamiData
+	account    *dnsimple.Account
+	accountErr error
+	err        error
 }
 
 type HomeModel struct {
@@ -21,9 +23,12 @@ type HomeModel struct {
 	items    []category
 	selected int
 	whoami   *dnsimple.WhoamiData
+	account  *dnsimple.Account
+	acctErr  string
 	loading  bool
 	errMsg   string
 	spinner  spinner.Model
+	req      requestScope
 }
 
 func NewHomeModel(whoami *dnsimple.WhoamiData) HomeModel {
@@ -32,16 +37,17 @@ func NewHomeModel(whoami *dnsimple.WhoamiData) HomeModel {
 	spin.Style = subtitleStyle
 
 	return HomeModel{
-		items:   []category{categoryDomains, categoryZones, categoryRecords},
-		whoami:  whoami,
-		loading: whoami == nil,
+		items:  []category{categoryDomains, categoryZones, categoryRecords, categoryWebhooks, categoryTemplates},
+		whoami: whoami,
+		// User tokens still need a fetch to resolve the account in use.
+		loading: whoami == nil || whoami.Account == nil,
 		spinner: spin,
 	}
 }
 
 func (m *HomeModel) Init() tea.Cmd {
 	if m.loading {
-		return tea.Batch(m.spinner.Tick, fetchWhoamiCmd())
+		return tea.Batch(m.spinner.Tick, m.fetchWhoamiCmd())
 	}
 	return nil
 }
@@ -67,14 +73,24 @@ func (m *HomeModel) Update(msg tea.Msg) tea.Cmd {
 			if len(m.items) > 0 {
 				return func() tea.Msg { return navigateCategoryMsg{category: m.items[m.selected]} }
 			}
+		case msg.String() == "a":
+			return func() tea.Msg { return openAccountSwitcherMsg{} }
 		}
 	case homeWhoamiMsg:
+		if !m.req.current(msg.gen) {
+			return nil
+		}
 		m.loading = false
 		if msg.err != nil {
 			m.errMsg = msg.err.Error()
 			return nil
 		}
 		m.whoami = msg.data
+		m.account = msg.account
+		m.acctErr = ""
+		if msg.accountErr != nil {
+			m.acctErr = msg.accountErr.Error()
+		}
 		m.errMsg = ""
 	case spinner.TickMsg:
 		if m.loading {
@@ -95,7 +111,7 @@ func (m HomeModel) View() string {
 		"",
 		panelStyle.Render(m.menuPanel()),
 		"",
-		footerStyle.Render("j/k or arrows: navigate   enter: open tab   /: global domain search   1-5/hdzR?: tabs   q: quit"),
+		footerStyle.Render("j/k or arrows: navigate   enter: open tab   a/@: switch account   /: global domain search   1-7/hdzRwt?: tabs   q: quit"),
 	}
 	return frame(m.width, strings.Join(body, "\n"))
 }
@@ -132,6 +148,17 @@ func (m HomeModel) accountPanel() string {
 			labelStyle.Render("User: ")+u.Email,
 			labelStyle.Render("User ID: ")+strconv.FormatInt(u.ID, 10),
 		)
+		switch {
+		case m.account != nil:
+			lines = append(lines,
+				labelStyle.Render("Account: ")+m.account.Email,
+				labelStyle.Render("Account ID: ")+strconv.FormatInt(m.account.ID, 10),
+				labelStyle.Render("Plan: ")+m.account.PlanIdentifier,
+			)
+		case m.acctErr != "":
+			lines = append(lines, "", warningStyle.Render("No account selected. Press a to choose one."))
+		}
+		lines = append(lines, "", subtitleStyle.Render("a: switch account"))
 	}
 	return strings.Join(lines, "\n")
 }
@@ -147,13 +174,50 @@ func (m HomeModel) menuPanel() string {
 		}
 		lines = append(lines, style.Render(prefix+item.Label()))
 	}
-	lines = append(lines, "", subtitleStyle.Render("Press Enter to switch to the selected tab (or use 2/3/4)."))
+	lines = append(lines, "", subtitleStyle.Render("Press Enter to switch to the selected tab (or use 2-6)."))
 	return strings.Join(lines, "\n")
 }
 
-func fetchWhoamiCmd() tea.Cmd {
+// CancelRequests aborts the account lookup while the tab is hidden. The
+// loading flag is left set so Resume knows to retry.
+func (m *HomeModel) CancelRequests() {
+	m.req.stop()
+}
+
+// Resume retries the account lookup if it never completed.
+func (m *HomeModel) Resume() tea.Cmd {
+	if !m.loading {
+		return nil
+	}
+	return tea.Batch(m.spinner.Tick, m.fetchWhoamiCmd())
+}
+
+func (m *HomeModel) fetchWhoamiCmd() tea.Cmd {
+	ctx, gen := m.req.begin()
 	return func() tea.Msg {
-		data, err := getBackend().Whoami(context.Background())
-		return homeWhoamiMsg{data: data, err: err}
+		backend := getBackend()
+		data, err := backend.Whoami(ctx)
+		if err != nil || data.Account != nil {
+			return homeWhoamiMsg{gen: gen, data: data, err: err}
+		}
+
+		// User token: show which of the user's accounts is in use.
+		msg := homeWhoamiMsg{gen: gen, data: data}
+		id, err := backend.AccountID(ctx)
+		if err != nil {
+			msg.accountErr = err
+			return msg
+		}
+		accounts, err := backend.ListAccounts(ctx)
+		if err != nil {
+			msg.accountErr = err
+			return msg
+		}
+		for i := range accounts {
+			if strconv.FormatInt(accounts[i].ID, 10) == id {
+				msg.account = &accounts[i]
+			}
+		}
+		return msg
 	}
 }
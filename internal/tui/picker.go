@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ErrPickCancelled is returned by Pick when the user backs out.
+var ErrPickCancelled = errors.New("selection cancelled")
+
+// PickItem is one choice in an inline picker. Label and Detail are both
+// searched; Value is what Pick returns.
+type PickItem struct {
+	Value  string
+	Label  string
+	Detail string
+}
+
+// pickerRows is how many matches the inline picker shows at once.
+const pickerRows = 10
+
+// Pick shows an inline fuzzy picker on stderr, so stdout stays clean for
+// the command's own output, and returns the chosen item's Value.
+func Pick(title string, items []PickItem) (string, error) {
+	if len(items) == 0 {
+		return "", fmt.Errorf("nothing to choose from")
+	}
+	p := tea.NewProgram(newPickerModel(title, items), tea.WithOutput(os.Stderr))
+	final, err := p.Run()
+	if err != nil {
+		return "", err
+	}
+	m := final.(pickerModel)
+	if m.chosen < 0 {
+		return "", ErrPickCancelled
+	}
+	return m.items[m.chosen].Value, nil
+}
+
+type pickerModel struct {
+	title    string
+	items    []PickItem
+	input    textinput.Model
+	matches  []int
+	selected int
+	chosen   int
+	done     bool
+	width    int
+}
+
+func newPickerModel(title string, items []PickItem) pickerModel {
+	ti := textinput.New()
+	ti.Prompt = "› "
+	ti.Placeholder = "type to filter"
+	ti.CharLimit = 200
+	ti.Focus()
+	m := pickerModel{title: title, items: items, input: ti, chosen: -1, width: 80}
+	m.updateMatches()
+	return m
+}
+
+func (m pickerModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.done = true
+			return m, tea.Quit
+		case "enter":
+			if len(m.matches) == 0 {
+				return m, nil
+			}
+			m.chosen = m.matches[m.selected]
+			m.done = true
+			return m, tea.Quit
+		case "up", "ctrl+p", "shift+tab":
+			if m.selected > 0 {
+				m.selected--
+			}
+			return m, nil
+		case "down", "ctrl+n", "tab":
+			if m.selected < len(m.matches)-1 {
+				m.selected++
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.updateMatches()
+	return m, cmd
+}
+
+// updateMatches ranks items with the same scoring as the domain search
+// modal, keeping the original order for ties.
+func (m *pickerModel) updateMatches() {
+	query := m.input.Value()
+	type scored struct{ index, score int }
+	var found []scored
+	for i, item := range m.items {
+		if score, ok := fuzzyScore(query, item.Label+" "+item.Detail); ok {
+			found = append(found, scored{i, score})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].score > found[j].score })
+
+	m.matches = make([]int, 0, len(found))
+	for _, f := range found {
+		m.matches = append(m.matches, f.index)
+	}
+	m.selected = minInt(m.selected, maxInt(0, len(m.matches)-1))
+}
+
+func (m pickerModel) View() string {
+	if m.done {
+		return ""
+	}
+	width := maxInt(20, m.width-2)
+	lines := []string{panelTitleStyle.Render(m.title), m.input.View()}
+	if len(m.matches) == 0 {
+		lines = append(lines, subtitleStyle.Render("  No matches"))
+	}
+	start, end := windowRange(len(m.matches), m.selected, pickerRows)
+	for i := start; i < end; i++ {
+		item := m.items[m.matches[i]]
+		prefix, style := "  ", itemStyle
+		if i == m.selected {
+			prefix, style = "› ", selectedItemStyle
+		}
+		label := truncateText(prefix+item.Label, width)
+		detail := truncateText(detailSuffix(item), maxInt(0, width-lipgloss.Width(label)))
+		lines = append(lines, style.Render(label)+subtitleStyle.Render(detail))
+	}
+	lines = append(lines, footerStyle.Render(fmt.Sprintf("%d of %d   ↑/↓: move   enter: choose   esc: cancel", len(m.matches), len(m.items))))
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func detailSuffix(item PickItem) string {
+	if item.Detail == "" {
+		return ""
+	}
+	return "  " + item.Detail
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPickerFiltersAndChooses(t *testing.T) {
+	m := newPickerModel("Choose a record", []PickItem{
+		{Value: "1", Label: "A      www", Detail: "203.0.113.10"},
+		{Value: "2", Label: "MX     @", Detail: "mx.example.net"},
+		{Value: "3", Label: "TXT    @", Detail: "v=spf1 -all"},
+	})
+	if len(m.matches) != 3 {
+		t.Fatalf("empty query matched %d items, want 3", len(m.matches))
+	}
+
+	// The query matches details too.
+	var model tea.Model = m
+	for _, r := range "spf" {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m = model.(pickerModel)
+	if len(m.matches) != 1 || m.items[m.matches[0]].Value != "3" {
+		t.Fatalf("matches = %v", m.matches)
+	}
+
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(pickerModel)
+	if cmd == nil || m.chosen != 2 || m.View() != "" {
+		t.Errorf("enter: chosen=%d quit=%v", m.chosen, cmd != nil)
+	}
+}
+
+func TestPickerEscCancels(t *testing.T) {
+	model, _ := newPickerModel("Choose a zone", []PickItem{{Value: "example.com", Label: "example.com"}}).
+		Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m := model.(pickerModel); m.chosen != -1 || !m.done {
+		t.Errorf("esc: chosen=%d done=%v", m.chosen, m.done)
+	}
+}
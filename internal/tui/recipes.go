@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dorkitude/simple/internal/recipes"
+	"github.com/dorkitude/simple/internal/templates"
+)
+
+// recipeForm is the dashboard dialog for applying a recipe: first a list of
+// the built-in and user recipes, then one input per parameter. Submitting
+// the parameters renders the recipe and, when the preview is clean, opens
+// the confirm dialog with the records that would be added.
+type recipeForm struct {
+	visible  bool
+	items    []recipes.Recipe
+	selected int
+	// recipe is the chosen recipe while its parameters are filled in.
+	recipe *recipes.Recipe
+	inputs []textinput.Model
+	focus  int
+	errMsg string
+	// preview explains why the rendered recipe cannot be applied.
+	preview []string
+}
+
+func (m *DomainDashboardModel) openRecipeForm() {
+	m.recipeForm = recipeForm{visible: true}
+	dir, err := recipes.UserDir()
+	if err == nil {
+		m.recipeForm.items, err = recipes.Load(dir)
+	}
+	if err != nil {
+		m.recipeForm.errMsg = err.Error()
+	}
+}
+
+// chooseRecipe moves on to the parameter step for the selected recipe.
+func (m *DomainDashboardModel) chooseRecipe() {
+	f := &m.recipeForm
+	if f.selected < 0 || f.selected >= len(f.items) {
+		return
+	}
+	r := f.items[f.selected]
+	f.recipe = &r
+	f.inputs = make([]textinput.Model, len(r.Params))
+	for i, p := range r.Params {
+		ti := textinput.New()
+		ti.Prompt = "> "
+		ti.Placeholder = p.Type
+		if p.Default != "" {
+			ti.Placeholder = p.Default
+		}
+		ti.CharLimit = 255
+		ti.Width = 48
+		f.inputs[i] = ti
+	}
+	f.focus = 0
+	f.errMsg, f.preview = "", nil
+	m.focusRecipeInput(0)
+}
+
+func (m *DomainDashboardModel) focusRecipeInput(i int) {
+	f := &m.recipeForm
+	if len(f.inputs) == 0 {
+		return
+	}
+	f.inputs[f.focus].Blur()
+	f.focus = (i + len(f.inputs)) % len(f.inputs)
+	f.inputs[f.focus].Focus()
+}
+
+func (m *DomainDashboardModel) updateRecipeForm(msg tea.KeyMsg) tea.Cmd {
+	f := &m.recipeForm
+	if f.recipe == nil {
+		switch {
+		case matches(msg, keys.Back):
+			m.recipeForm = recipeForm{}
+		case matches(msg, keys.Up):
+			f.selected = maxInt(0, f.selected-1)
+		case matches(msg, keys.Down):
+			f.selected = minInt(f.selected+1, maxInt(0, len(f.items)-1))
+		case matches(msg, keys.Enter):
+			m.chooseRecipe()
+			return textinput.Blink
+		}
+		return nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		f.recipe, f.inputs = nil, nil
+		f.errMsg, f.preview = "", nil
+		return nil
+	case "tab", "down":
+		m.focusRecipeInput(f.focus + 1)
+		return textinput.Blink
+	case "shift+tab", "up":
+		m.focusRecipeInput(f.focus - 1)
+		return textinput.Blink
+	case "enter":
+		return m.previewRecipe()
+	}
+	if len(f.inputs) == 0 {
+		return nil
+	}
+	var cmd tea.Cmd
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	f.errMsg, f.preview = "", nil
+	return cmd
+}
+
+// previewRecipe renders the chosen recipe with the entered parameters and
+// compares it with the loaded zone.
+func (m *DomainDashboardModel) previewRecipe() tea.Cmd {
+	f := &m.recipeForm
+	r := *f.recipe
+	values := map[string]string{}
+	for i, p := range r.Params {
+		if v := strings.TrimSpace(f.inputs[i].Value()); v != "" {
+			values[p.Name] = v
+		}
+	}
+	records, err := recipes.Render(r, m.domain, values)
+	if err != nil {
+		f.errMsg, f.preview = err.Error(), nil
+		return nil
+	}
+	plan := templates.Compare(r.Name, m.domain, records, m.records)
+	switch {
+	case len(plan.Conflicts) > 0:
+		f.errMsg = fmt.Sprintf("%s conflicts with %d records in the zone", r.Name, len(plan.Conflicts))
+		f.preview = templatePlanLines(plan)
+		return nil
+	case len(plan.Add) == 0:
+		f.errMsg = m.domain + " already has every record in " + r.Name
+		return nil
+	}
+	m.recipeForm = recipeForm{}
+	m.pendingPlan = &plan
+	m.openConfirm(mutationApplyRecipe, "Apply Recipe",
+		fmt.Sprintf("This will add %d records to the zone.", len(plan.Add)))
+	return textinput.Blink
+}
+
+func (m *DomainDashboardModel) recipeFormView() string {
+	f := m.recipeForm
+	var lines []string
+	var footer string
+	if f.recipe == nil {
+		lines = []string{panelTitleStyle.Render("Apply Recipe"), ""}
+		for i, r := range f.items {
+			prefix, style := "  ", itemStyle
+			if i == f.selected {
+				prefix, style = "› ", selectedItemStyle
+			}
+			lines = append(lines, style.Render(prefix+r.Name)+subtitleStyle.Render("  "+truncateText(r.Description, 56)))
+		}
+		if len(f.items) == 0 && f.errMsg == "" {
+			lines = append(lines, subtitleStyle.Render("No recipes found."))
+		}
+		footer = "enter: choose   esc: cancel"
+	} else {
+		lines = []string{panelTitleStyle.Render("Apply Recipe: " + f.recipe.Name), ""}
+		if f.recipe.Description != "" {
+			lines = append(lines, subtitleStyle.Render(f.recipe.Description), "")
+		}
+		for i, p := range f.recipe.Params {
+			label := p.Name
+			if p.Required {
+				label += " (required)"
+			}
+			lines = append(lines, label)
+			if p.Description != "" {
+				lines = append(lines, subtitleStyle.Render(p.Description))
+			}
+			lines = append(lines, f.inputs[i].View(), "")
+		}
+		if len(f.recipe.Params) == 0 {
+			lines = append(lines, subtitleStyle.Render("This recipe takes no parameters."), "")
+		}
+		footer = "tab: next field   enter: preview and apply   esc: back"
+	}
+	if f.errMsg != "" {
+		lines = append(lines, "", errorStyle.Render(f.errMsg))
+	}
+	if len(f.preview) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, f.preview...)
+	}
+	lines = append(lines, "", footerStyle.Render(footer))
+	box := modalPanelStyle.Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(maxInt(70, m.width), maxInt(18, m.height), lipgloss.Center, lipgloss.Center, box)
+}
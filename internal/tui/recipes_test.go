@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const testRecipe = `description: Mail host with a verification token
+params:
+  - name: token
+    required: true
+  - name: host
+    type: hostname
+    default: mx.example.net
+records:
+  - type: MX
+    name: "@"
+    content: "{{ .host }}"
+    priority: 5
+  - type: TXT
+    name: _verify
+    content: "token={{ .token }}"
+`
+
+func TestDashboardApplyRecipeAsksForParameters(t *testing.T) {
+	backend := withDemoBackend(t)
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "recipes"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "recipes", "mailhost.yaml"), []byte(testRecipe), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DNSIMPLE_CONFIG_DIR", dir)
+
+	m, dash := openDashboard(t, contractActiveZone)
+	before, _ := backend.ListRecords(context.Background(), contractActiveZone)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	for i, a := range dash.availableActions() {
+		if a.ID == "apply_recipe" {
+			dash.selectedAction = i
+		}
+	}
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	form := &dash.recipeForm
+	if !form.visible || !m.BlocksGlobalKeys() || len(form.items) != 5 {
+		t.Fatalf("recipe list visible=%v with %d recipes, err=%q", form.visible, len(form.items), form.errMsg)
+	}
+
+	// google-workspace adds an SPF policy, and the seeded zone has one.
+	for form.items[form.selected].Name != "google-workspace" {
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if dash.modal.visible || !strings.Contains(form.errMsg, "conflicts") {
+		t.Fatalf("conflicting recipe not refused: err=%q", form.errMsg)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if form.recipe != nil || !form.visible {
+		t.Fatal("esc did not go back to the recipe list")
+	}
+
+	for form.items[form.selected].Name != "mailhost" {
+		m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if !strings.Contains(form.errMsg, "token is required") {
+		t.Fatalf("missing parameter accepted: err=%q", form.errMsg)
+	}
+	typeText(m, "abc123")
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if form.visible || !dash.modal.visible || dash.modal.action != mutationApplyRecipe {
+		t.Fatalf("recipe did not move on to the confirm dialog: err=%q", form.errMsg)
+	}
+	for _, want := range []string{"Recipe:", "+ MX    @  mx.example.net  pri 5", "+ TXT   _verify  token=abc123"} {
+		if !strings.Contains(dash.modal.body, want) {
+			t.Errorf("confirm dialog missing %q:\n%s", want, dash.modal.body)
+		}
+	}
+
+	typeText(m, "confirm")
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	after, _ := backend.ListRecords(context.Background(), contractActiveZone)
+	if len(after) != len(before)+2 {
+		t.Fatalf("want 2 records added, got %d -> %d", len(before), len(after))
+	}
+	if dash.modal.visible || !strings.Contains(dash.status, "mailhost applied") {
+		t.Errorf("after apply: dialog visible=%v, status=%q", dash.modal.visible, dash.status)
+	}
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// requestScope owns the context for a screen's in-flight backend calls.
+// Starting a request cancels the previous one, and each request carries a
+// generation number so responses that arrive after the screen moved on can be
+// recognized and dropped.
+type requestScope struct {
+	cancel context.CancelFunc
+	gen    int
+}
+
+// begin cancels any outstanding request and returns the context and
+// generation for a new one.
+func (s *requestScope) begin() (context.Context, int) {
+	s.stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	return ctx, s.gen
+}
+
+// stop cancels any outstanding request and marks its response as stale.
+func (s *requestScope) stop() {
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+	s.gen++
+}
+
+// current reports whether a response with generation gen is still wanted.
+func (s *requestScope) current(gen int) bool {
+	return gen == s.gen
+}
+
+// requestCanceller is implemented by tabs that can abort their in-flight
+// requests while hidden and pick the interrupted load back up later.
+type requestCanceller interface {
+	CancelRequests()
+	Resume() tea.Cmd
+}
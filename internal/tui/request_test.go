@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBrowserIgnoresResponsesFromBeforeNavigation(t *testing.T) {
+	withDemoBackend(t)
+
+	m := newTestShell(t)
+	drain(t, m, m.activate(tabRecords))
+	if m.records.screen != browserRecordsZones || len(m.records.items) == 0 {
+		t.Fatalf("records tab opened on screen %v with %d zones", m.records.screen, len(m.records.items))
+	}
+
+	// Reload the zone list, then pick a zone before the reload answers.
+	stale := m.records.loadListCmd()
+	drain(t, m, m.records.handleEnter())
+	if m.records.screen != browserRecordsList {
+		t.Fatalf("enter left the records tab on screen %v", m.records.screen)
+	}
+	zone, records := m.records.recordsZone, len(m.records.items)
+
+	m.Update(stale())
+	m.Update(browserListLoadedMsg{gen: m.records.req.gen - 1, screen: browserRecordsZones, err: errors.New("stale")})
+	if m.records.screen != browserRecordsList || m.records.recordsZone != zone || len(m.records.items) != records || m.records.errMsg != "" {
+		t.Errorf("stale zone list was applied: screen %v, zone %q, %d items, err %q",
+			m.records.screen, m.records.recordsZone, len(m.records.items), m.records.errMsg)
+	}
+
+}
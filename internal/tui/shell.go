@@ -1,10 +1,15 @@
 package tui
 
 import (
+	"errors"
+	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/client"
+	"github.com/dorkitude/simple/internal/config"
 )
 
 type shellTab int
@@ -14,6 +19,8 @@ const (
 	tabDomains
 	tabZones
 	tabRecords
+	tabWebhooks
+	tabTemplates
 	tabHelp
 )
 
@@ -28,7 +35,9 @@ var shellTabs = []shellTabDef{
 	{id: tabDomains, label: "Domains", shortcut: "2/d"},
 	{id: tabZones, label: "Zones", shortcut: "3/z"},
 	{id: tabRecords, label: "Records", shortcut: "4/R"},
-	{id: tabHelp, label: "Help", shortcut: "5/?"},
+	{id: tabWebhooks, label: "Webhooks", shortcut: "5/w"},
+	{id: tabTemplates, label: "Templates", shortcut: "6/t"},
+	{id: tabHelp, label: "Help", shortcut: "7/?"},
 }
 
 type tabContent interface {
@@ -47,7 +56,21 @@ type ShellModel struct {
 	domains     BrowserModel
 	zones       BrowserModel
 	records     BrowserModel
+	webhooks    BrowserModel
+	templates   BrowserModel
 	help        HelpModel
+	profile     string
+	switcher    accountSwitcher
+	check       domainCheck
+	account     string
+	memory      map[string]accountMemory
+}
+
+// accountMemory is where the user was in an account when they switched away
+// from it.
+type accountMemory struct {
+	tab    shellTab
+	domain string
 }
 
 func NewShellModel(whoami *dnsimple.WhoamiData) ShellModel {
@@ -58,8 +81,35 @@ func NewShellModel(whoami *dnsimple.WhoamiData) ShellModel {
 		domains:     NewBrowserModel(categoryDomains),
 		zones:       NewBrowserModel(categoryZones),
 		records:     NewBrowserModel(categoryRecords),
+		webhooks:    NewBrowserModel(categoryWebhooks),
+		templates:   NewBrowserModel(categoryTemplates),
 		help:        NewHelpModel(),
+		profile:     profileBadge(),
+		switcher:    newAccountSwitcher(),
+		check:       newDomainCheck(),
+		memory:      map[string]accountMemory{},
+	}
+}
+
+// profileBadge describes the credentials behind the current backend for the
+// shell header.
+func profileBadge() string {
+	if _, ok := getBackend().(*demoBackend); ok {
+		return "demo"
 	}
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+	name, _ := cfg.ProfileName()
+	badge := "profile: " + name
+	if cfg.Profiles[name].IsSandbox() {
+		badge += " (sandbox)"
+	}
+	if config.TokenSource() == config.SourceEnv {
+		badge += " · token from " + config.TokenEnv
+	}
+	return badge
 }
 
 func (m *ShellModel) Init() tea.Cmd {
@@ -73,10 +123,18 @@ func (m *ShellModel) SetSize(width, height int) {
 	m.domains.SetSize(width, height)
 	m.zones.SetSize(width, height)
 	m.records.SetSize(width, height)
+	m.webhooks.SetSize(width, height)
+	m.templates.SetSize(width, height)
 	m.help.SetSize(width, height)
 }
 
 func (m *ShellModel) Update(msg tea.Msg) tea.Cmd {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.switcher.visible {
+		return m.switcher.Update(keyMsg)
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.check.visible {
+		return m.check.Update(keyMsg)
+	}
 	if keyMsg, ok := msg.(tea.KeyMsg); ok && !m.BlocksGlobalKeys() {
 		if cmd := m.handleGlobalKeys(keyMsg); cmd != nil {
 			return cmd
@@ -92,23 +150,113 @@ func (m *ShellModel) Update(msg tea.Msg) tea.Cmd {
 			return m.activate(tabZones)
 		case categoryRecords:
 			return m.activate(tabRecords)
+		case categoryWebhooks:
+			return m.activate(tabWebhooks)
+		case categoryTemplates:
+			return m.activate(tabTemplates)
 		}
 	case browserBackMsg:
 		return m.activate(tabHome)
+	case openAccountSwitcherMsg:
+		return m.switcher.open()
+	case accountsLoadedMsg:
+		if m.account == "" {
+			m.account = msg.current
+		}
+		return m.switcher.Update(msg)
+	case accountSwitchedMsg:
+		return m.switchAccount(msg)
+	case domainCheckMsg:
+		return m.check.Update(msg)
+	case homeWhoamiMsg:
+		cmd := m.home.Update(msg)
+		if msg.account != nil {
+			m.account = strconv.FormatInt(msg.account.ID, 10)
+		} else if msg.data != nil && msg.data.Account != nil {
+			m.account = strconv.FormatInt(msg.data.Account.ID, 10)
+		}
+		var multi *client.MultipleAccountsError
+		if errors.As(msg.accountErr, &multi) && !m.switcher.visible {
+			return tea.Batch(cmd, m.switcher.open())
+		}
+		return cmd
+	case spinner.TickMsg:
+		if m.switcher.visible {
+			return tea.Batch(m.switcher.Update(msg), m.activeModel().Update(msg))
+		}
+		if m.check.visible {
+			return tea.Batch(m.check.Update(msg), m.activeModel().Update(msg))
+		}
 	}
 
 	return m.activeModel().Update(msg)
 }
 
+// switchAccount rebuilds every tab against the newly selected account and
+// restores the tab and domain last used there.
+func (m *ShellModel) switchAccount(msg accountSwitchedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.switcher.busy = false
+		m.switcher.errMsg = msg.err.Error()
+		return nil
+	}
+
+	from := msg.from
+	if from == "" {
+		from = m.account
+	}
+	if from != "" {
+		m.memory[from] = accountMemory{tab: m.active, domain: m.domains.SelectedKey()}
+	}
+
+	for _, tab := range []shellTab{tabHome, tabDomains, tabZones, tabRecords, tabWebhooks, tabTemplates} {
+		if c, ok := m.modelFor(tab).(requestCanceller); ok {
+			c.CancelRequests()
+		}
+	}
+	m.home = NewHomeModel(nil)
+	m.domains = NewBrowserModel(categoryDomains)
+	m.zones = NewBrowserModel(categoryZones)
+	m.records = NewBrowserModel(categoryRecords)
+	m.webhooks = NewBrowserModel(categoryWebhooks)
+	m.templates = NewBrowserModel(categoryTemplates)
+	m.initialized = map[shellTab]bool{}
+	m.SetSize(m.width, m.height)
+
+	m.account = strconv.FormatInt(msg.account.ID, 10)
+	m.switcher.close()
+
+	mem, ok := m.memory[m.account]
+	if !ok {
+		mem = accountMemory{tab: tabHome}
+	}
+	if mem.domain != "" {
+		m.domains.SelectKey(mem.domain)
+	}
+	m.active = mem.tab
+	return m.initTab(mem.tab)
+}
+
 func (m *ShellModel) View() string {
+	badge := m.profile
+	if m.account != "" {
+		badge += " · account " + m.account
+	}
 	parts := []string{
-		titleStyle.Render("Simple - a TUI for DNSimple.com"),
+		titleStyle.Render("Simple - a TUI for DNSimple.com") + "  " + tabHintStyle.Render(badge),
 		"",
 		tabBarStyle.Render(m.tabBar()),
 		"",
 		m.activeModel().View(),
 	}
-	return strings.Join(parts, "\n")
+	base := strings.Join(parts, "\n")
+	if m.switcher.visible {
+		return overlayDialog(base, m.switcher.View(m.width, m.height))
+	}
+	if m.check.visible {
+		return overlayDialog(base, m.check.View(m.width, m.height))
+	}
+	return base
 }
 
 func (m *ShellModel) handleGlobalKeys(msg tea.KeyMsg) tea.Cmd {
@@ -122,6 +270,10 @@ func (m *ShellModel) handleGlobalKeys(msg tea.KeyMsg) tea.Cmd {
 	switch msg.String() {
 	case "/":
 		return m.openGlobalDomainSearch()
+	case "@":
+		return m.switcher.open()
+	case "$":
+		return m.check.open()
 	case "1", "h":
 		return m.activate(tabHome)
 	case "2", "d":
@@ -130,7 +282,11 @@ func (m *ShellModel) handleGlobalKeys(msg tea.KeyMsg) tea.Cmd {
 		return m.activate(tabZones)
 	case "4", "R":
 		return m.activate(tabRecords)
-	case "5", "?":
+	case "5", "w":
+		return m.activate(tabWebhooks)
+	case "6", "t":
+		return m.activate(tabTemplates)
+	case "7", "?":
 		return m.activate(tabHelp)
 	}
 
@@ -138,8 +294,8 @@ func (m *ShellModel) handleGlobalKeys(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *ShellModel) openGlobalDomainSearch() tea.Cmd {
-	m.active = tabDomains
-	return tea.Batch(m.initTab(tabDomains), m.domains.OpenSearch())
+	cmd := m.activate(tabDomains)
+	return tea.Batch(cmd, m.domains.OpenSearch())
 }
 
 func (m *ShellModel) nextTab() shellTab {
@@ -152,8 +308,20 @@ func (m *ShellModel) prevTab() shellTab {
 }
 
 func (m *ShellModel) activate(tab shellTab) tea.Cmd {
+	if tab == m.active {
+		return m.initTab(tab)
+	}
+	if c, ok := m.activeModel().(requestCanceller); ok {
+		c.CancelRequests()
+	}
 	m.active = tab
-	return m.initTab(tab)
+	if cmd := m.initTab(tab); cmd != nil {
+		return cmd
+	}
+	if c, ok := m.activeModel().(requestCanceller); ok {
+		return c.Resume()
+	}
+	return nil
 }
 
 func (m *ShellModel) initTab(tab shellTab) tea.Cmd {
@@ -179,6 +347,10 @@ func (m *ShellModel) modelFor(tab shellTab) tabContent {
 		return &m.zones
 	case tabRecords:
 		return &m.records
+	case tabWebhooks:
+		return &m.webhooks
+	case tabTemplates:
+		return &m.templates
 	case tabHelp:
 		return &m.help
 	default:
@@ -200,6 +372,9 @@ func (m *ShellModel) tabBar() string {
 }
 
 func (m *ShellModel) BlocksGlobalKeys() bool {
+	if m.switcher.visible || m.check.visible {
+		return true
+	}
 	if blocker, ok := m.activeModel().(interface{ BlocksGlobalKeys() bool }); ok {
 		return blocker.BlocksGlobalKeys()
 	}
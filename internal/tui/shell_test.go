@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// drain runs cmd and feeds its messages back into the shell until nothing is
+// left, skipping spinner ticks so the test never sleeps.
+func drain(t *testing.T, m *ShellModel, cmd tea.Cmd) {
+	t.Helper()
+	queue := []tea.Cmd{cmd}
+	for steps := 0; len(queue) > 0; steps++ {
+		if steps > 500 {
+			t.Fatal("shell did not settle")
+		}
+		next := queue[0]
+		queue = queue[1:]
+		if next == nil {
+			continue
+		}
+		switch msg := next().(type) {
+		case tea.BatchMsg:
+			queue = append(queue, msg...)
+		case spinner.TickMsg, nil:
+		default:
+			queue = append(queue, m.Update(msg))
+		}
+	}
+}
+
+// withDemoBackend makes a fresh demo backend current for the length of the
+// test and returns it for seeding and assertions.
+func withDemoBackend(t *testing.T) *demoBackend {
+	t.Helper()
+	prev := getBackend()
+	backend := newDemoBackend()
+	setBackend(backend)
+	t.Cleanup(func() { setBackend(prev) })
+	return backend
+}
+
+// newTestShell boots a sized shell on the current backend.
+func newTestShell(t *testing.T) *ShellModel {
+	t.Helper()
+	m := NewShellModel(nil)
+	m.SetSize(120, 40)
+	drain(t, &m, m.Init())
+	return &m
+}
+
+// openDashboard boots a shell on the current backend and opens the domain
+// dashboard for domain from the Domains tab.
+func openDashboard(t *testing.T, domain string) (*ShellModel, *DomainDashboardModel) {
+	t.Helper()
+	m := newTestShell(t)
+	drain(t, m, m.activate(tabDomains))
+	m.domains.selectKeyNow(domain)
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if m.domains.screen != browserDomainDashboard || m.domains.domainDash.domain != domain {
+		t.Fatalf("dashboard not open on %s", domain)
+	}
+	return m, &m.domains.domainDash
+}
+
+func TestShellAccountSwitchRemembersTabAndDomain(t *testing.T) {
+	withDemoBackend(t)
+
+	m := newTestShell(t)
+	if m.account != "424242" {
+		t.Fatalf("account = %q, want 424242", m.account)
+	}
+
+	// Pick a domain in the first account, then switch away.
+	drain(t, m, m.activate(tabDomains))
+	m.domains.selected = 2
+	first := m.domains.SelectedKey()
+	if first == "" {
+		t.Fatal("no domain selected in first account")
+	}
+
+	drain(t, m, m.switcher.open())
+	for i, a := range m.switcher.accounts {
+		if a.ID == 515151 {
+			m.switcher.selected = i
+		}
+	}
+	drain(t, m, m.switcher.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if m.account != "515151" || m.active != tabHome || m.switcher.visible {
+		t.Fatalf("after switch: account=%q tab=%v visible=%v", m.account, m.active, m.switcher.visible)
+	}
+
+	// Switching back restores the domains tab and the selected domain.
+	drain(t, m, m.switcher.open())
+	for i, a := range m.switcher.accounts {
+		if a.ID == 424242 {
+			m.switcher.selected = i
+		}
+	}
+	drain(t, m, m.switcher.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if m.account != "424242" || m.active != tabDomains {
+		t.Fatalf("after switching back: account=%q tab=%v", m.account, m.active)
+	}
+	if got := m.domains.SelectedKey(); got != first {
+		t.Errorf("selected domain = %q, want %q", got, first)
+	}
+}
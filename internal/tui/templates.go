@@ -0,0 +1,216 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dorkitude/simple/internal/templates"
+)
+
+// templatePicker is the dashboard dialog for choosing a template to apply.
+// Choosing one loads its records and, when the preview is clean, opens the
+// confirm dialog with the records that would be added.
+type templatePicker struct {
+	visible  bool
+	loading  bool
+	items    []dnsimple.Template
+	selected int
+	errMsg   string
+	// preview explains why the chosen template cannot be applied.
+	preview []string
+}
+
+type domainDashboardTemplatesMsg struct {
+	gen       int
+	templates []dnsimple.Template
+	err       error
+}
+
+type domainDashboardTemplatePlanMsg struct {
+	gen     int
+	records []dnsimple.TemplateRecord
+	err     error
+}
+
+func (m *DomainDashboardModel) openTemplatePicker() tea.Cmd {
+	m.tmplPicker = templatePicker{visible: true, loading: true}
+	ctx, gen := m.req.begin()
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		list, err := getBackend().ListTemplates(ctx)
+		return domainDashboardTemplatesMsg{gen: gen, templates: list, err: err}
+	})
+}
+
+func (m *DomainDashboardModel) selectedTemplate() *dnsimple.Template {
+	p := &m.tmplPicker
+	if p.selected < 0 || p.selected >= len(p.items) {
+		return nil
+	}
+	return &p.items[p.selected]
+}
+
+func (m *DomainDashboardModel) updateTemplatePicker(msg tea.Msg) tea.Cmd {
+	p := &m.tmplPicker
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case matches(msg, keys.Back):
+			m.req.stop()
+			m.tmplPicker = templatePicker{}
+			return nil
+		case p.loading:
+			return nil
+		case matches(msg, keys.Up):
+			p.selected = maxInt(0, p.selected-1)
+			p.errMsg, p.preview = "", nil
+		case matches(msg, keys.Down):
+			p.selected = minInt(p.selected+1, maxInt(0, len(p.items)-1))
+			p.errMsg, p.preview = "", nil
+		case matches(msg, keys.Enter):
+			t := m.selectedTemplate()
+			if t == nil {
+				return nil
+			}
+			p.loading = true
+			p.errMsg, p.preview = "", nil
+			sid := t.SID
+			ctx, gen := m.req.begin()
+			return tea.Batch(m.spinner.Tick, func() tea.Msg {
+				records, err := getBackend().ListTemplateRecords(ctx, sid)
+				return domainDashboardTemplatePlanMsg{gen: gen, records: records, err: err}
+			})
+		}
+	case domainDashboardTemplatesMsg:
+		if !m.req.current(msg.gen) {
+			return nil
+		}
+		p.loading = false
+		if msg.err != nil {
+			p.errMsg = msg.err.Error()
+			return nil
+		}
+		p.items = msg.templates
+		if len(p.items) == 0 {
+			p.errMsg = "No templates in this account. Create one with simple templates create."
+		}
+	case domainDashboardTemplatePlanMsg:
+		if !m.req.current(msg.gen) {
+			return nil
+		}
+		p.loading = false
+		if msg.err != nil {
+			p.errMsg = msg.err.Error()
+			return nil
+		}
+		t := m.selectedTemplate()
+		if t == nil {
+			return nil
+		}
+		plan := templates.Preview(t.SID, m.domain, msg.records, m.records)
+		switch {
+		case len(plan.Conflicts) > 0:
+			p.errMsg = fmt.Sprintf("%s conflicts with %d records in the zone", t.SID, len(plan.Conflicts))
+			p.preview = templatePlanLines(plan)
+			return nil
+		case len(plan.Add) == 0:
+			p.errMsg = m.domain + " already has every record in " + t.SID
+			return nil
+		}
+		m.tmplPicker = templatePicker{}
+		m.pendingPlan = &plan
+		m.openConfirm(mutationApplyTemplate, "Apply Template",
+			fmt.Sprintf("This will add %d records to the zone.", len(plan.Add)))
+		return textinput.Blink
+	}
+	return nil
+}
+
+func (m *DomainDashboardModel) templatePickerView() string {
+	p := m.tmplPicker
+	lines := []string{panelTitleStyle.Render("Apply Template"), ""}
+	if p.loading && len(p.items) == 0 {
+		lines = append(lines, m.spinner.View()+" Loading templates...")
+	}
+	for i, t := range p.items {
+		prefix, style := "  ", itemStyle
+		if i == p.selected {
+			prefix, style = "› ", selectedItemStyle
+		}
+		lines = append(lines, style.Render(prefix+t.Name)+subtitleStyle.Render("  "+t.SID))
+	}
+	if p.loading && len(p.items) > 0 {
+		lines = append(lines, "", m.spinner.View()+" Comparing with the zone...")
+	}
+	if p.errMsg != "" {
+		lines = append(lines, "", errorStyle.Render(p.errMsg))
+	}
+	if len(p.preview) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, p.preview...)
+	}
+	lines = append(lines, "", footerStyle.Render("enter: preview and apply   esc: cancel"))
+	box := modalPanelStyle.Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(maxInt(70, m.width), maxInt(18, m.height), lipgloss.Center, lipgloss.Center, box)
+}
+
+// templatePlanLines renders a template preview: records to add (+), those
+// the zone already has (=) and conflicts (!) with the reason.
+func templatePlanLines(p templates.Plan) []string {
+	line := func(mark string, r dnsimple.ZoneRecord) string {
+		name := r.Name
+		if name == "" {
+			name = "@"
+		}
+		s := fmt.Sprintf("  %s %-5s %s  %s", mark, r.Type, name, truncateText(r.Content, 48))
+		if r.Priority != 0 {
+			s += "  pri " + strconv.Itoa(r.Priority)
+		}
+		return s
+	}
+	var lines []string
+	for _, r := range p.Add {
+		lines = append(lines, successStyle.Render(line("+", r)))
+	}
+	for _, r := range p.Existing {
+		lines = append(lines, subtitleStyle.Render(line("=", r)+"  (already present)"))
+	}
+	for _, c := range p.Conflicts {
+		lines = append(lines, errorStyle.Render(line("!", c.Record)), subtitleStyle.Render("      "+c.Reason))
+	}
+	return lines
+}
+
+// templateDetail is the Templates tab detail view: the template and the
+// records it creates.
+func templateDetail(t dnsimple.Template, records []dnsimple.TemplateRecord) string {
+	lines := []string{
+		"ID: " + strconv.FormatInt(t.ID, 10),
+		"SID: " + t.SID,
+		"Name: " + t.Name,
+	}
+	if t.Description != "" {
+		lines = append(lines, "Description: "+t.Description)
+	}
+	lines = append(lines, "Created: "+t.CreatedAt, "Updated: "+t.UpdatedAt, "", fmt.Sprintf("Records (%d):", len(records)))
+	for _, r := range records {
+		name := r.Name
+		if name == "" {
+			name = "@"
+		}
+		line := fmt.Sprintf("  %-5s %-16s %s", r.Type, name, r.Content)
+		if r.Priority != 0 {
+			line += "  pri " + strconv.Itoa(r.Priority)
+		}
+		lines = append(lines, line)
+	}
+	if len(records) == 0 {
+		lines = append(lines, subtitleStyle.Render("  (none)"))
+	}
+	lines = append(lines, "", subtitleStyle.Render(templates.DomainPlaceholder+" is replaced with the domain the template is applied to."))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDashboardApplyTemplatePreviewsFirst(t *testing.T) {
+	backend := withDemoBackend(t)
+
+	m, dash := openDashboard(t, contractActiveZone)
+	before, _ := backend.ListRecords(context.Background(), contractActiveZone)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	for i, a := range dash.availableActions() {
+		if a.ID == "apply_template" {
+			dash.selectedAction = i
+		}
+	}
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if !dash.tmplPicker.visible || !m.BlocksGlobalKeys() || len(dash.tmplPicker.items) != 3 {
+		t.Fatalf("picker visible=%v with %d templates", dash.tmplPicker.visible, len(dash.tmplPicker.items))
+	}
+
+	// The first template by name clashes with the seeded _acme-challenge TXT.
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if dash.modal.visible || !strings.Contains(dash.tmplPicker.errMsg, "conflicts") {
+		t.Fatalf("conflicting template not refused: err=%q", dash.tmplPicker.errMsg)
+	}
+	if view := strings.Join(dash.tmplPicker.preview, "\n"); !strings.Contains(view, "! CNAME _acme-challenge") {
+		t.Errorf("conflict preview:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if dash.tmplPicker.visible || !dash.modal.visible || dash.modal.action != mutationApplyTemplate {
+		t.Fatal("clean template did not move on to the confirm dialog")
+	}
+	for _, want := range []string{"+ A     @  203.0.113.80", "+ TXT   _dmarc", "= CNAME www"} {
+		if !strings.Contains(dash.modal.body, want) {
+			t.Errorf("confirm dialog missing %q:\n%s", want, dash.modal.body)
+		}
+	}
+
+	typeText(m, "confirm")
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	after, _ := backend.ListRecords(context.Background(), contractActiveZone)
+	if len(after) != len(before)+2 {
+		t.Fatalf("want 2 records added, got %d -> %d", len(before), len(after))
+	}
+	if dash.modal.visible || !strings.Contains(dash.status, "brand-web applied") || len(dash.records) != len(after) {
+		t.Errorf("after apply: dialog visible=%v, status=%q, dashboard has %d records", dash.modal.visible, dash.status, len(dash.records))
+	}
+}
@@ -1,9 +1,15 @@
 package tui
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dorkitude/simple/internal/config"
+)
 
 // Run launches the interactive TUI.
 func Run() error {
+	// A terminal prompt would corrupt the alt screen; the auth screen asks
+	// for the passphrase instead.
+	config.SetPassphrasePrompt(nil)
 	useRealBackend()
 	p := tea.NewProgram(NewAppModel(), tea.WithAltScreen())
 	_, err := p.Run()
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWebhooksCreateAndConfirmDelete(t *testing.T) {
+	backend := withDemoBackend(t)
+
+	m := newTestShell(t)
+	drain(t, m, m.handleGlobalKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}}))
+	if m.active != tabWebhooks {
+		t.Fatalf("w opened tab %v", m.active)
+	}
+	seeded := len(m.webhooks.items)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if !m.webhooks.hookForm.visible || !m.BlocksGlobalKeys() {
+		t.Fatal("n did not open the new webhook form")
+	}
+	typeText(m, "not a url")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.webhooks.hookForm.errMsg, "http") {
+		t.Fatalf("bad URL accepted: err=%q", m.webhooks.hookForm.errMsg)
+	}
+
+	const url = "https://hooks.example.net/new"
+	m.webhooks.hookForm.input.SetValue(url)
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if m.webhooks.hookForm.visible || len(m.webhooks.items) != seeded+1 {
+		t.Fatalf("after create: form visible=%v, %d webhooks", m.webhooks.hookForm.visible, len(m.webhooks.items))
+	}
+	created := m.webhooks.items[m.webhooks.selected]
+	if created.Title != url || !strings.Contains(m.webhooks.statusMsg, "created") {
+		t.Fatalf("selected %q, status %q", created.Title, m.webhooks.statusMsg)
+	}
+
+	// Deleting needs the typed confirmation.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	if !m.webhooks.confirm.visible || !strings.Contains(m.webhooks.confirm.body, url) {
+		t.Fatalf("D did not open the confirm dialog for %s", url)
+	}
+	typeText(m, "yes")
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if !m.webhooks.confirm.visible || len(backend.webhooks) != seeded+1 {
+		t.Fatal("delete ran without typing confirm")
+	}
+	m.webhooks.confirm.input.SetValue("confirm")
+	drain(t, m, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	if m.webhooks.confirm.visible || len(m.webhooks.items) != seeded {
+		t.Fatalf("after delete: dialog visible=%v, %d webhooks", m.webhooks.confirm.visible, len(m.webhooks.items))
+	}
+	hooks, _ := backend.ListWebhooks(context.Background())
+	for _, w := range hooks {
+		if w.URL == url {
+			t.Errorf("webhook %d still exists", w.ID)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dorkitude/simple/internal/nameservers"
+)
+
+// nsForm is the dialog for editing a zone's name servers. Submitting it
+// opens the confirm dialog with the before/after diff.
+type nsForm struct {
+	visible bool
+	input   textinput.Model
+	errMsg  string
+}
+
+func newNSInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Placeholder = "ns1.example.net ns2.example.net"
+	ti.CharLimit = 1000
+	ti.Width = 60
+	return ti
+}
+
+// currentNameServers lists the apex NS records of the loaded zone.
+func (m *DomainDashboardModel) currentNameServers() []string {
+	return nameservers.FromRecords(m.records)
+}
+
+func (m *DomainDashboardModel) openNSForm() {
+	m.nsForm.visible = true
+	m.nsForm.errMsg = ""
+	m.nsForm.input.SetValue(strings.Join(m.currentNameServers(), " "))
+	m.nsForm.input.CursorEnd()
+	m.nsForm.input.Focus()
+}
+
+func (m *DomainDashboardModel) closeNSForm() {
+	m.nsForm.visible = false
+	m.nsForm.errMsg = ""
+	m.nsForm.input.Blur()
+}
+
+func (m *DomainDashboardModel) updateNSForm(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case msg.String() == "esc":
+		m.closeNSForm()
+		return nil
+	case matches(msg, keys.Enter):
+		names, err := nameservers.Normalize(nameservers.Split(m.nsForm.input.Value()))
+		if err != nil {
+			m.nsForm.errMsg = err.Error()
+			return nil
+		}
+		if nameservers.NewChange(m.domain, m.currentNameServers(), names).Empty() {
+			m.nsForm.errMsg = "Those are the current name servers"
+			return nil
+		}
+		m.closeNSForm()
+		m.pendingNS = names
+		m.openConfirm(mutationZoneNameServers, "Replace Name Servers", "This will replace the NS records at the zone apex.")
+		return textinput.Blink
+	}
+
+	var cmd tea.Cmd
+	m.nsForm.input, cmd = m.nsForm.input.Update(msg)
+	m.nsForm.errMsg = ""
+	return cmd
+}
+
+func (m *DomainDashboardModel) nsFormView() string {
+	lines := []string{
+		panelTitleStyle.Render("Edit Name Servers"),
+		"",
+		subtitleStyle.Render("Host names separated by spaces or commas."),
+		"",
+		m.nsForm.input.View(),
+	}
+	if m.nsForm.errMsg != "" {
+		lines = append(lines, "", errorStyle.Render(m.nsForm.errMsg))
+	}
+	lines = append(lines, "", footerStyle.Render("enter: review change   esc: cancel"))
+	box := modalPanelStyle.Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(maxInt(70, m.width), maxInt(18, m.height), lipgloss.Center, lipgloss.Center, box)
+}
+
+// nsChangeLines renders the NS change for the confirm dialog: the old set
+// with removals marked -, then the new set with additions marked +.
+func nsChangeLines(c nameservers.Change) []string {
+	removed := map[string]bool{}
+	for _, n := range c.Removed {
+		removed[n] = true
+	}
+	added := map[string]bool{}
+	for _, n := range c.Added {
+		added[n] = true
+	}
+	lines := []string{"Before:"}
+	for _, n := range c.Before {
+		if removed[n] {
+			lines = append(lines, errorStyle.Render("  - "+n))
+		} else {
+			lines = append(lines, "    "+n)
+		}
+	}
+	if len(c.Before) == 0 {
+		lines = append(lines, subtitleStyle.Render("    (none)"))
+	}
+	lines = append(lines, "After:")
+	for _, n := range c.After {
+		if added[n] {
+			lines = append(lines, successStyle.Render("  + "+n))
+		} else {
+			lines = append(lines, "    "+n)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
+)
+
+// Options controls how styled output is rendered.
+type Options struct {
+	NoColor bool // --no-color
+	Plain   bool // --plain: no color and no emoji
+}
+
+var plain bool
+
+// Configure applies the display options to every style in this package
+// (and to the TUI, which shares lipgloss's default renderer). Color is off
+// when requested, when NO_COLOR is set, when TERM is dumb, or when stdout is
+// not a terminal.
+func Configure(opts Options) {
+	plain = opts.Plain
+	tty := term.IsTerminal(os.Stdout.Fd())
+	if ColorDisabled(opts, os.Getenv, tty) {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// ColorDisabled reports whether styles should render without ANSI
+// sequences.
+func ColorDisabled(opts Options, getenv func(string) string, tty bool) bool {
+	return opts.NoColor || opts.Plain ||
+		getenv("NO_COLOR") != "" ||
+		getenv("TERM") == "dumb" ||
+		!tty
+}
+
+// Plain reports whether --plain is in effect.
+func Plain() bool {
+	return plain
+}
+
+// Text returns s as it should be shown: unchanged normally, with emoji
+// removed in plain mode.
+func Text(s string) string {
+	if !plain {
+		return s
+	}
+	return StripEmoji(s)
+}
+
+// StripEmoji removes emoji, along with the spacing that separated them
+// from the surrounding words.
+func StripEmoji(s string) string {
+	var b strings.Builder
+	skipSpace := false
+	for _, r := range s {
+		if isEmoji(r) {
+			skipSpace = true
+			continue
+		}
+		if skipSpace && r == ' ' {
+			continue
+		}
+		skipSpace = false
+		b.WriteRune(r)
+	}
+	return strings.TrimRightFunc(b.String(), unicode.IsSpace)
+}
+
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // pictographs, emoticons, symbols
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // misc symbols and dingbats (✨ ✓ ✗ ⚠)
+		return true
+	case r == 0x2139: // ℹ
+		return true
+	case r == 0xFE0F || r == 0x200D: // variation selector, zero-width joiner
+		return true
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package ui
+
+import "testing"
+
+func TestColorDisabled(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+	for _, tc := range []struct {
+		name string
+		opts Options
+		vars map[string]string
+		tty  bool
+		want bool
+	}{
+		{"terminal", Options{}, nil, true, false},
+		{"piped", Options{}, nil, false, true},
+		{"flag", Options{NoColor: true}, nil, true, true},
+		{"plain", Options{Plain: true}, nil, true, true},
+		{"NO_COLOR", Options{}, map[string]string{"NO_COLOR": "1"}, true, true},
+		{"empty NO_COLOR", Options{}, map[string]string{"NO_COLOR": ""}, true, false},
+		{"dumb terminal", Options{}, map[string]string{"TERM": "dumb"}, true, true},
+	} {
+		if got := ColorDisabled(tc.opts, env(tc.vars), tc.tty); got != tc.want {
+			t.Errorf("%s: ColorDisabled = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestStripEmoji(t *testing.T) {
+	for in, want := range map[string]string{
+		"🌐 simple":                         "simple",
+		"🗂️  3 zones":                      "3 zones",
+		"Zone 'a.com' is distributed ✨":    "Zone 'a.com' is distributed",
+		"Created A record 'www' → 1.2.3.4": "Created A record 'www' → 1.2.3.4",
+		"no emoji here":                    "no emoji here",
+	} {
+		if got := StripEmoji(in); got != want {
+			t.Errorf("StripEmoji(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
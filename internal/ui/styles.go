@@ -66,24 +66,37 @@ var (
 			Foreground(Accent)
 )
 
-// Helper functions for common output patterns
+// Helper functions for common output patterns. In plain mode the symbols
+// become words so status still reads without emoji.
 
 func Success(msg string) string {
+	if plain {
+		return Text(msg)
+	}
 	return SuccessStyle.Render("✓ " + msg)
 }
 
 func Err(msg string) string {
+	if plain {
+		return "error: " + Text(msg)
+	}
 	return ErrorStyle.Render("✗ " + msg)
 }
 
 func Warn(msg string) string {
+	if plain {
+		return "warning: " + Text(msg)
+	}
 	return WarningStyle.Render("⚠ " + msg)
 }
 
 func Info(msg string) string {
+	if plain {
+		return Text(msg)
+	}
 	return SubtleStyle.Render("ℹ " + msg)
 }
 
 func Title(msg string) string {
-	return TitleStyle.Render(msg)
+	return TitleStyle.Render(Text(msg))
 }
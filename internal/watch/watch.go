@@ -0,0 +1,151 @@
+// Package watch compares successive snapshots of a zone and reports what
+// changed between polls.
+package watch
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+// Kind names a change.
+type Kind string
+
+const (
+	RecordAdded     Kind = "record_added"
+	RecordChanged   Kind = "record_changed"
+	RecordRemoved   Kind = "record_removed"
+	ZoneActivated   Kind = "zone_activated"
+	ZoneDeactivated Kind = "zone_deactivated"
+	ZoneAdded       Kind = "zone_added"
+	ZoneRemoved     Kind = "zone_removed"
+)
+
+// Change is one difference between two polls. Record is the record as it
+// is now (or was, when removed); Previous is set for record_changed.
+type Change struct {
+	Time     time.Time            `json:"time"`
+	Kind     Kind                 `json:"kind"`
+	Zone     string               `json:"zone"`
+	Record   *dnsimple.ZoneRecord `json:"record,omitempty"`
+	Previous *dnsimple.ZoneRecord `json:"previous,omitempty"`
+	Fields   []string             `json:"fields,omitempty"`
+}
+
+// Snapshot is the state of one zone at a poll.
+type Snapshot struct {
+	Zone    string
+	Active  bool
+	Records map[int64]dnsimple.ZoneRecord
+}
+
+// NewSnapshot builds a snapshot from a zone and its records.
+func NewSnapshot(zone dnsimple.Zone, records []dnsimple.ZoneRecord) Snapshot {
+	s := Snapshot{Zone: zone.Name, Active: zone.Active, Records: make(map[int64]dnsimple.ZoneRecord, len(records))}
+	for _, r := range records {
+		s.Records[r.ID] = r
+	}
+	return s
+}
+
+// Diff lists the changes from prev to next: the zone's active state first,
+// then records in ID order.
+func Diff(prev, next Snapshot, at time.Time) []Change {
+	var changes []Change
+	if prev.Active != next.Active {
+		kind := ZoneDeactivated
+		if next.Active {
+			kind = ZoneActivated
+		}
+		changes = append(changes, Change{Time: at, Kind: kind, Zone: next.Zone})
+	}
+
+	for _, id := range sortedIDs(prev.Records, next.Records) {
+		old, hadOld := prev.Records[id]
+		cur, hasCur := next.Records[id]
+		switch {
+		case !hadOld:
+			changes = append(changes, Change{Time: at, Kind: RecordAdded, Zone: next.Zone, Record: &cur})
+		case !hasCur:
+			changes = append(changes, Change{Time: at, Kind: RecordRemoved, Zone: next.Zone, Record: &old})
+		default:
+			if fields := changedFields(old, cur); len(fields) > 0 {
+				changes = append(changes, Change{Time: at, Kind: RecordChanged, Zone: next.Zone, Record: &cur, Previous: &old, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+// changedFields compares the fields a person edits. Timestamps are left
+// out; they move with every edit anyway.
+func changedFields(a, b dnsimple.ZoneRecord) []string {
+	var fields []string
+	if a.Type != b.Type {
+		fields = append(fields, "type")
+	}
+	if a.Name != b.Name {
+		fields = append(fields, "name")
+	}
+	if a.Content != b.Content {
+		fields = append(fields, "content")
+	}
+	if a.TTL != b.TTL {
+		fields = append(fields, "ttl")
+	}
+	if a.Priority != b.Priority {
+		fields = append(fields, "priority")
+	}
+	if strings.Join(a.Regions, ",") != strings.Join(b.Regions, ",") {
+		fields = append(fields, "regions")
+	}
+	return fields
+}
+
+// FieldChange renders one changed field as "old → new".
+func (c Change) FieldChange(field string) string {
+	if c.Previous == nil || c.Record == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s %s → %s", field, fieldValue(*c.Previous, field), fieldValue(*c.Record, field))
+}
+
+func fieldValue(r dnsimple.ZoneRecord, field string) string {
+	switch field {
+	case "type":
+		return r.Type
+	case "name":
+		if r.Name == "" {
+			return "@"
+		}
+		return r.Name
+	case "content":
+		return r.Content
+	case "ttl":
+		return strconv.Itoa(r.TTL)
+	case "priority":
+		return strconv.Itoa(r.Priority)
+	case "regions":
+		return strings.Join(r.Regions, ",")
+	}
+	return ""
+}
+
+func sortedIDs(a, b map[int64]dnsimple.ZoneRecord) []int64 {
+	seen := make(map[int64]bool, len(a)+len(b))
+	ids := make([]int64, 0, len(a)+len(b))
+	for _, m := range []map[int64]dnsimple.ZoneRecord{a, b} {
+		for id := range m {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -0,0 +1,51 @@
+package watch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+func TestDiff(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	zone := dnsimple.Zone{Name: "example.com", Active: true}
+	prev := NewSnapshot(zone, []dnsimple.ZoneRecord{
+		{ID: 1, Type: "A", Name: "www", Content: "203.0.113.10", TTL: 3600},
+		{ID: 2, Type: "TXT", Name: "", Content: "v=spf1 -all", TTL: 3600},
+		{ID: 3, Type: "MX", Name: "", Content: "mx.example.net", TTL: 3600, Priority: 10},
+	})
+
+	zone.Active = false
+	next := NewSnapshot(zone, []dnsimple.ZoneRecord{
+		{ID: 1, Type: "A", Name: "www", Content: "203.0.113.11", TTL: 300, UpdatedAt: "later"},
+		{ID: 3, Type: "MX", Name: "", Content: "mx.example.net", TTL: 3600, Priority: 10, UpdatedAt: "later"},
+		{ID: 4, Type: "AAAA", Name: "www", Content: "2001:db8::1", TTL: 3600},
+	})
+
+	changes := Diff(prev, next, at)
+	want := []Kind{ZoneDeactivated, RecordChanged, RecordRemoved, RecordAdded}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes: %+v", len(changes), changes)
+	}
+	for i, c := range changes {
+		if c.Kind != want[i] || c.Zone != "example.com" || !c.Time.Equal(at) {
+			t.Errorf("change %d = %s %s, want %s", i, c.Kind, c.Zone, want[i])
+		}
+	}
+
+	changed := changes[1]
+	if len(changed.Fields) != 2 || changed.Fields[0] != "content" || changed.Fields[1] != "ttl" {
+		t.Errorf("fields = %v, want [content ttl]", changed.Fields)
+	}
+	if got := changed.FieldChange("content"); got != "content 203.0.113.10 → 203.0.113.11" {
+		t.Errorf("FieldChange = %q", got)
+	}
+	if changes[2].Record.ID != 2 || changes[3].Record.ID != 4 {
+		t.Errorf("removed/added records = %d/%d", changes[2].Record.ID, changes[3].Record.ID)
+	}
+
+	if again := Diff(next, next, at); len(again) != 0 {
+		t.Errorf("identical snapshots produced %d changes", len(again))
+	}
+}
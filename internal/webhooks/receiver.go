@@ -0,0 +1,221 @@
+// Package webhooks receives DNSimple webhook deliveries: it validates each
+// POST, appends it to a JSONL log, optionally forwards it, and hands it to
+// a callback for printing or hooks.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple/webhook"
+)
+
+const (
+	// maxBody caps a delivery; real events are a few kilobytes.
+	maxBody = 1 << 20
+	// forwardTimeout bounds a single --forward-to request.
+	forwardTimeout = 10 * time.Second
+	// seenLimit is how many request identifiers are remembered to drop
+	// redelivered events.
+	seenLimit = 1000
+)
+
+// eventName matches resource.action, with the optional :status suffix
+// DNSimple adds to events such as domain.registrant_change:started.
+var eventName = regexp.MustCompile(`^[a-z_]+\.[a-z_]+(:[a-z_]+)?$`)
+
+// Event is a validated delivery.
+type Event struct {
+	ReceivedAt time.Time
+	*webhook.Event
+}
+
+// Receiver is an http.Handler for webhook deliveries. The zero value
+// accepts and validates events and does nothing else.
+type Receiver struct {
+	// Log receives each event's payload as one JSON line.
+	Log io.Writer
+	// ForwardTo, when set, receives a copy of each payload by POST.
+	ForwardTo string
+	// OnEvent is called for each new event, after logging and forwarding.
+	// Calls never overlap, so output and hooks stay in arrival order.
+	OnEvent func(context.Context, Event)
+	// OnError reports problems that don't fail the delivery, such as a
+	// forward that could not be made.
+	OnError func(error)
+
+	Client *http.Client
+
+	mu      sync.Mutex
+	seen    map[string]bool
+	order   []string
+	eventMu sync.Mutex
+}
+
+// ParsePayload validates a delivery body and parses it.
+func ParsePayload(body []byte) (*webhook.Event, error) {
+	var shape struct {
+		Name       string          `json:"name"`
+		APIVersion string          `json:"api_version"`
+		RequestID  string          `json:"request_identifier"`
+		Data       json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &shape); err != nil {
+		return nil, fmt.Errorf("payload is not a JSON object: %w", err)
+	}
+	switch {
+	case shape.Name == "":
+		return nil, errors.New("payload has no event name")
+	case !eventName.MatchString(shape.Name):
+		return nil, fmt.Errorf("event name %q is not of the form resource.action[:status]", shape.Name)
+	case shape.RequestID == "":
+		return nil, errors.New("payload has no request_identifier")
+	case shape.APIVersion == "":
+		return nil, errors.New("payload has no api_version")
+	case len(shape.Data) == 0 || shape.Data[0] != '{':
+		return nil, errors.New("payload data is not an object")
+	}
+	event, err := webhook.ParseEvent(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s event: %w", shape.Name, err)
+	}
+	return event, nil
+}
+
+func (rv *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "webhook deliveries must be POST", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBody+1))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxBody {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	parsed, err := ParsePayload(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// DNSimple retries deliveries it is unsure about; acknowledge a
+	// repeat without handling it twice.
+	if !rv.firstSighting(parsed.RequestID) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	event := Event{ReceivedAt: time.Now().UTC(), Event: parsed}
+	if err := rv.log(body); err != nil {
+		// Let DNSimple's retry through, since nothing was recorded.
+		rv.forget(parsed.RequestID)
+		http.Error(w, "failed to record event", http.StatusInternalServerError)
+		rv.report(err)
+		return
+	}
+	// Answer before forwarding and hooks run, so a slow hook never makes
+	// DNSimple time out and redeliver.
+	w.WriteHeader(http.StatusOK)
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	// The delivery is answered, so DNSimple may hang up; that must not
+	// cancel forwarding or the hook.
+	ctx := context.WithoutCancel(r.Context())
+	if rv.ForwardTo != "" {
+		if err := rv.forward(ctx, body); err != nil {
+			rv.report(err)
+		}
+	}
+	if rv.OnEvent != nil {
+		rv.eventMu.Lock()
+		defer rv.eventMu.Unlock()
+		rv.OnEvent(ctx, event)
+	}
+}
+
+func (rv *Receiver) firstSighting(id string) bool {
+	rv.mu.Lock()
+	defer rv.mu.Unlock()
+	if rv.seen == nil {
+		rv.seen = map[string]bool{}
+	}
+	if rv.seen[id] {
+		return false
+	}
+	rv.seen[id] = true
+	rv.order = append(rv.order, id)
+	if len(rv.order) > seenLimit {
+		delete(rv.seen, rv.order[0])
+		rv.order = rv.order[1:]
+	}
+	return true
+}
+
+func (rv *Receiver) forget(id string) {
+	rv.mu.Lock()
+	defer rv.mu.Unlock()
+	delete(rv.seen, id)
+}
+
+func (rv *Receiver) log(body []byte) error {
+	if rv.Log == nil {
+		return nil
+	}
+	var line bytes.Buffer
+	if err := json.Compact(&line, body); err != nil {
+		return err
+	}
+	line.WriteByte('\n')
+
+	rv.mu.Lock()
+	defer rv.mu.Unlock()
+	if _, err := rv.Log.Write(line.Bytes()); err != nil {
+		return fmt.Errorf("failed to write event log: %w", err)
+	}
+	return nil
+}
+
+func (rv *Receiver) forward(ctx context.Context, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), forwardTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rv.ForwardTo, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to forward event: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := rv.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to forward event: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to forward event: %s answered %s", rv.ForwardTo, resp.Status)
+	}
+	return nil
+}
+
+func (rv *Receiver) report(err error) {
+	if rv.OnError != nil {
+		rv.OnError(err)
+	}
+}
@@ -0,0 +1,173 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile("testdata/" + name + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func post(t *testing.T, url string, body []byte) *http.Response {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestReceiverLogsForwardsAndDeduplicates(t *testing.T) {
+	var forwarded [][]byte
+	var fmu sync.Mutex
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		fmu.Lock()
+		forwarded = append(forwarded, b)
+		fmu.Unlock()
+	}))
+	defer target.Close()
+
+	var log bytes.Buffer
+	var events []Event
+	rv := &Receiver{
+		Log:       &log,
+		ForwardTo: target.URL,
+		OnEvent:   func(_ context.Context, e Event) { events = append(events, e) },
+		OnError:   func(err error) { t.Errorf("OnError: %v", err) },
+	}
+	srv := httptest.NewServer(rv)
+	defer srv.Close()
+
+	record := fixture(t, "zone_record.create")
+	renew := fixture(t, "domain.renew")
+	started := fixture(t, "domain.registrant_change-started")
+	for _, body := range [][]byte{record, renew, started, record} {
+		if resp := post(t, srv.URL, body); resp.StatusCode != http.StatusOK {
+			t.Fatalf("POST = %s", resp.Status)
+		}
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3 (the repeat is dropped)", len(events))
+	}
+	if got := Subject(events[0].Event); got != "A example.zone → 127.0.0.1" {
+		t.Errorf("zone_record.create subject = %q", got)
+	}
+	if got := Subject(events[1].Event); got != "example-alpha.com (automatic), now expires 2021-06-05" {
+		t.Errorf("domain.renew subject = %q", got)
+	}
+	if events[2].Name != "domain.registrant_change:started" || Subject(events[2].Event) != "example-alpha.com" {
+		t.Errorf("registrant change event = %q, subject %q", events[2].Name, Subject(events[2].Event))
+	}
+
+	lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("log has %d lines:\n%s", len(lines), log.String())
+	}
+	var logged struct{ Name string }
+	if err := json.Unmarshal([]byte(lines[1]), &logged); err != nil || logged.Name != "domain.renew" {
+		t.Errorf("log line 2 = %s (%v)", lines[1], err)
+	}
+
+	fmu.Lock()
+	defer fmu.Unlock()
+	if len(forwarded) != 3 || !bytes.Equal(forwarded[0], record) {
+		t.Errorf("forwarded %d payloads", len(forwarded))
+	}
+}
+
+func TestReceiverRejectsBadDeliveries(t *testing.T) {
+	srv := httptest.NewServer(&Receiver{})
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET = %s", resp.Status)
+	}
+
+	for body, want := range map[string]string{
+		`not json`: "not a JSON object",
+		`{"name": "zone_record.create", "api_version": "v2", "data": {}}`:                          "no request_identifier",
+		`{"name": "Zone Record", "request_identifier": "x", "api_version": "v2", "data": {}}`:      "resource.action",
+		`{"name": "zone.create:", "request_identifier": "x", "api_version": "v2", "data": {}}`:     "resource.action",
+		`{"name": "zone.create", "request_identifier": "x", "api_version": "v2", "data": []}`:      "data is not an object",
+		`{"name": "zone.create", "request_identifier": "x", "data": {"zone": {"name": "a.test"}}}`: "no api_version",
+	} {
+		_, err := ParsePayload([]byte(body))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParsePayload(%s) = %v, want %q", body, err, want)
+		}
+		if resp := post(t, srv.URL, []byte(body)); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("POST %s = %s, want 400", body, resp.Status)
+		}
+	}
+}
+
+func TestReceiverHookOutlivesClientDisconnect(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	hookErr := make(chan error, 1)
+	rv := &Receiver{
+		OnEvent: func(ctx context.Context, _ Event) {
+			close(started)
+			<-release
+			hookErr <- ctx.Err()
+		},
+		OnError: func(err error) { t.Errorf("OnError: %v", err) },
+	}
+	requests := make(chan context.Context, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r.Context()
+		rv.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL, bytes.NewReader(fixture(t, "domain.renew")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("POST = %s", resp.Status)
+	}
+
+	// Hang up while the hook is still running.
+	<-started
+	cancel()
+	resp.Body.Close()
+	select {
+	case <-(<-requests).Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("server never noticed the client hang up")
+	}
+	close(release)
+
+	if err := <-hookErr; err != nil {
+		t.Errorf("hook context was cancelled by the disconnect: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple/webhook"
+)
+
+// Subject describes what an event is about in a few words, such as
+// "A www.example.com → 203.0.113.10" or "example.com".
+func Subject(e *webhook.Event) string {
+	switch d := e.GetData().(type) {
+	case *webhook.ZoneRecordEventData:
+		if r := d.ZoneRecord; r != nil {
+			host := r.ZoneID
+			if r.Name != "" {
+				host = r.Name + "." + r.ZoneID
+			}
+			return fmt.Sprintf("%s %s → %s", r.Type, host, r.Content)
+		}
+	case *webhook.ZoneEventData:
+		if d.Zone != nil {
+			return d.Zone.Name
+		}
+	case *webhook.DomainEventData:
+		if d.Domain != nil {
+			s := d.Domain.Name
+			if d.Auto {
+				s += " (automatic)"
+			}
+			if d.Domain.ExpiresAt != "" && strings.HasSuffix(e.Name, ".renew") {
+				s += ", now expires " + d.Domain.ExpiresAt[:min(10, len(d.Domain.ExpiresAt))]
+			}
+			return s
+		}
+	case *webhook.DNSSECEventData:
+		if d.DelegationSignerRecord != nil {
+			return fmt.Sprintf("DS key tag %s", d.DelegationSignerRecord.Keytag)
+		}
+	case *webhook.EmailForwardEventData:
+		if f := d.EmailForward; f != nil {
+			return fmt.Sprintf("%s → %s", f.AliasEmail, f.DestinationEmail)
+		}
+	case *webhook.WhoisPrivacyEventData:
+		if d.Domain != nil {
+			return d.Domain.Name
+		}
+	case *webhook.CertificateEventData:
+		if d.Certificate != nil {
+			return d.Certificate.CommonName
+		}
+	case *webhook.ContactEventData:
+		if c := d.Contact; c != nil {
+			return strings.TrimSpace(c.FirstName + " " + c.LastName)
+		}
+	case *webhook.WebhookEventData:
+		if d.Webhook != nil {
+			return d.Webhook.URL
+		}
+	}
+	return ""
+}